@@ -0,0 +1,478 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+)
+
+// command — команда forumctl. setup регистрирует флаги команды и возвращает функцию, которая выполняет
+// команду с оставшимися позиционными аргументами
+type command struct {
+	name    string
+	args    string
+	summary string
+	setup   func(fs *flag.FlagSet, a *app) func(ctx context.Context, args []string) error
+}
+
+// usageError — неверные аргументы команды; forumctl печатает справку по команде и завершается с кодом 2
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// dryRunNote печатается после таблицы, когда команда ничего не изменила
+const dryRunNote = "dry run: nothing was changed"
+
+var commands = []command{
+	{name: "topics list", summary: "list topics in display order", setup: topicsList},
+	{name: "topics create", summary: "create a topic", setup: topicsCreate},
+	{name: "topics rename", args: "ID TITLE", summary: "change the title of a topic", setup: topicsRename},
+	{name: "topics delete", args: "ID", summary: "move a topic with its subtopics, posts and comments to the trash", setup: topicsDelete},
+	{name: "posts move", args: "POST_ID...", summary: "move posts with their comments to another topic", setup: postsMove},
+	{name: "users purge", args: "USER_ID", summary: "permanently erase posts, comments and chat messages of a user", setup: usersPurge},
+	{name: "stats recompute", summary: "recompute topic and post counters and last activity", setup: statsRecompute},
+	{name: "retention run", summary: "purge the trash, orphaned attachments and old chat messages now", setup: retentionRun},
+	{name: "activity list", summary: "list recent posts, comments and chat messages", setup: activityList},
+}
+
+// findCommand находит команду по первым двум аргументам и возвращает остальные
+func findCommand(args []string) (command, []string, error) {
+	if len(args) < 2 {
+		return command{}, nil, errors.New("missing command")
+	}
+	name := args[0] + " " + args[1]
+	for _, c := range commands {
+		if c.name == name {
+			return c, args[2:], nil
+		}
+	}
+	return command{}, nil, fmt.Errorf("unknown command %q", name)
+}
+
+// parseIDs разбирает положительные ID из аргументов
+func parseIDs(args []string) ([]int, error) {
+	ids := make([]int, 0, len(args))
+	for _, s := range args {
+		id, err := strconv.Atoi(s)
+		if err != nil || id <= 0 {
+			return nil, usagef("%q is not a valid ID", s)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseID разбирает единственный позиционный аргумент — ID
+func parseID(args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, usagef("expected exactly one ID, got %d arguments", len(args))
+	}
+	ids, err := parseIDs(args)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+func topicState(t model.Topic) string {
+	var s []string
+	if t.Archived {
+		s = append(s, "archived")
+	}
+	if t.Locked {
+		s = append(s, "locked")
+	}
+	if len(s) == 0 {
+		return "-"
+	}
+	return strings.Join(s, ",")
+}
+
+func topicReport(topics []model.Topic) report {
+	r := report{
+		value:  topics,
+		header: []string{"ID", "PARENT", "POSITION", "TITLE", "POSTS", "COMMENTS", "STATE"},
+	}
+	for _, t := range topics {
+		r.rows = append(r.rows, []string{
+			itoa(t.ID), optInt(t.ParentID), itoa(t.Position), t.Title, itoa(t.PostCount), itoa(t.CommentCount), topicState(t),
+		})
+	}
+	return r
+}
+
+func topicsList(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	archived := fs.Bool("archived", false, "include archived topics")
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments %q", args)
+		}
+		topics, err := a.topics.GetAll(ctx, *archived)
+		if err != nil {
+			return err
+		}
+		return a.out.print(topicReport(topics))
+	}
+}
+
+func topicsCreate(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	title := fs.String("title", "", "topic title (required)")
+	description := fs.String("description", "", "topic description")
+	parent := fs.Int("parent", 0, "ID of the parent topic; 0 creates a top-level topic")
+	position := fs.Int("position", 0, "display order among sibling topics")
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments %q", args)
+		}
+		if *title == "" {
+			return usagef("-title is required")
+		}
+		topic := &model.Topic{Title: *title, Description: *description, Position: *position}
+		if *parent != 0 {
+			topic.ParentID = parent
+		}
+		if err := a.topics.Create(ctx, topic); err != nil {
+			return err
+		}
+		return a.out.print(topicReport([]model.Topic{*topic}))
+	}
+}
+
+func topicsRename(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		if len(args) != 2 {
+			return usagef("expected ID and TITLE")
+		}
+		id, err := parseID(args[:1])
+		if err != nil {
+			return err
+		}
+		topic, err := a.topics.Update(ctx, &model.TopicUpdateRequest{ID: id, Title: &args[1]})
+		if err != nil {
+			return err
+		}
+		return a.out.print(topicReport([]model.Topic{*topic}))
+	}
+}
+
+// deleteResult — темы, удаленные в корзину вместе с темой
+type deleteResult struct {
+	DryRun bool          `json:"dry_run"`
+	Topics []model.Topic `json:"topics"`
+}
+
+func topicsDelete(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	dryRun := fs.Bool("dry-run", false, "show the topics that would be deleted without deleting them")
+	return func(ctx context.Context, args []string) error {
+		id, err := parseID(args)
+		if err != nil {
+			return err
+		}
+		all, err := a.topics.GetAll(ctx, true)
+		if err != nil {
+			return err
+		}
+		subtree := topicSubtree(all, id)
+		if len(subtree) == 0 {
+			return usecase.ErrTopicNotFound
+		}
+		if !*dryRun {
+			// удаливший неизвестен: команда выполняется не от имени пользователя форума
+			if err := a.topics.Delete(ctx, id, 0); err != nil {
+				return err
+			}
+		}
+		r := topicReport(subtree)
+		r.value = deleteResult{DryRun: *dryRun, Topics: subtree}
+		if *dryRun {
+			r.notes = append(r.notes, dryRunNote)
+		} else {
+			r.notes = append(r.notes, "moved to the trash; restore with the trash API until it is purged")
+		}
+		return a.out.print(r)
+	}
+}
+
+// topicSubtree возвращает тему id и все ее подтемы в порядке topics
+func topicSubtree(topics []model.Topic, id int) []model.Topic {
+	in := map[int]bool{id: true}
+	// родитель может идти в списке после подтемы, поэтому обход повторяется, пока поддерево растет
+	for grown := true; grown; {
+		grown = false
+		for _, t := range topics {
+			if !in[t.ID] && t.ParentID != nil && in[*t.ParentID] {
+				in[t.ID] = true
+				grown = true
+			}
+		}
+	}
+	var res []model.Topic
+	for _, t := range topics {
+		if in[t.ID] {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// movedPost — пост в отчете о переносе
+type movedPost struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	From     int    `json:"from_topic_id"`
+	To       int    `json:"to_topic_id"`
+	Comments int    `json:"comments"`
+	Skipped  string `json:"skipped,omitempty"`
+}
+
+type moveResult struct {
+	DryRun bool        `json:"dry_run"`
+	Moved  int         `json:"moved"`
+	Posts  []movedPost `json:"posts"`
+}
+
+func postsMove(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	to := fs.Int("to", 0, "ID of the target topic (required)")
+	dryRun := fs.Bool("dry-run", false, "show the posts that would be moved without moving them")
+	return func(ctx context.Context, args []string) error {
+		if *to <= 0 {
+			return usagef("-to is required")
+		}
+		if len(args) == 0 {
+			return usagef("no posts to move")
+		}
+		ids, err := parseIDs(args)
+		if err != nil {
+			return err
+		}
+		target, err := a.topics.GetByID(ctx, *to)
+		if err != nil {
+			return err
+		}
+		if target.Archived {
+			return usecase.ErrTopicArchived
+		}
+
+		res := moveResult{DryRun: *dryRun}
+		var movable []int
+		for _, id := range ids {
+			p, err := a.posts.GetByID(ctx, id, 0)
+			if errors.Is(err, usecase.ErrPostNotFound) {
+				res.Posts = append(res.Posts, movedPost{ID: id, To: *to, Skipped: "not found"})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			mp := movedPost{ID: p.ID, Title: p.Title, From: p.TopicID, To: *to, Comments: p.CommentCount}
+			if p.TopicID == *to {
+				mp.Skipped = "already in topic"
+			} else {
+				movable = append(movable, p.ID)
+			}
+			res.Posts = append(res.Posts, mp)
+		}
+		res.Moved = len(movable)
+		if !*dryRun && len(movable) > 0 {
+			if res.Moved, err = a.posts.Move(ctx, movable, *to); err != nil {
+				return err
+			}
+		}
+
+		r := report{value: res, header: []string{"ID", "FROM", "TO", "COMMENTS", "TITLE", "SKIPPED"}}
+		for _, p := range res.Posts {
+			skipped := p.Skipped
+			if skipped == "" {
+				skipped = "-"
+			}
+			r.rows = append(r.rows, []string{itoa(p.ID), itoa(p.From), itoa(p.To), itoa(p.Comments), excerpt(p.Title, 60), skipped})
+		}
+		r.notes = append(r.notes, fmt.Sprintf("%d posts moved to topic %d", res.Moved, *to))
+		if *dryRun {
+			r.notes = []string{fmt.Sprintf("%d posts would be moved to topic %d", res.Moved, *to), dryRunNote}
+		}
+		return a.out.print(r)
+	}
+}
+
+type purgeResult struct {
+	DryRun bool `json:"dry_run"`
+	model.UserContent
+}
+
+func usersPurge(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	dryRun := fs.Bool("dry-run", false, "count the content that would be erased without erasing it")
+	return func(ctx context.Context, args []string) error {
+		userID, err := parseID(args)
+		if err != nil {
+			return err
+		}
+		var content *model.UserContent
+		if *dryRun {
+			content, err = a.activity.UserContent(ctx, userID)
+		} else {
+			content, err = a.activity.PurgeUser(ctx, userID)
+		}
+		if content == nil {
+			return err
+		}
+		r := report{
+			value:  purgeResult{DryRun: *dryRun, UserContent: *content},
+			header: []string{"USER", "POSTS", "COMMENTS", "REPLIES", "MESSAGES"},
+			rows: [][]string{{
+				itoa(content.UserID), itoa(content.Posts), itoa(content.Comments), itoa(content.Replies), itoa(content.Messages),
+			}},
+		}
+		if *dryRun {
+			r.notes = append(r.notes, dryRunNote)
+		}
+		// контент стерт, даже если следом не удалось пересчитать статистику тем
+		if perr := a.out.print(r); perr != nil {
+			return perr
+		}
+		if err != nil {
+			return fmt.Errorf("%w; run forumctl stats recompute", err)
+		}
+		return nil
+	}
+}
+
+func statsRecompute(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments %q", args)
+		}
+		n, err := a.topics.RecomputeStats(ctx)
+		if err != nil {
+			return err
+		}
+		return a.out.print(report{
+			value:  n,
+			header: []string{"UPDATED TOPICS", "UPDATED POSTS"},
+			rows:   [][]string{{itoa(n.Topics), itoa(n.Posts)}},
+		})
+	}
+}
+
+// retentionTask — одна из очисток по сроку хранения
+type retentionTask struct {
+	Name      string `json:"name"`
+	OlderThan string `json:"older_than"`
+	Count     int    `json:"count"`
+}
+
+type retentionResult struct {
+	DryRun bool            `json:"dry_run"`
+	Tasks  []retentionTask `json:"tasks"`
+}
+
+func retentionRun(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	trash := fs.Duration("trash", a.cfg.Trash.Retention, "purge trash items deleted longer ago than this")
+	orphans := fs.Duration("attachments", a.cfg.Attachments.OrphanTTL, "delete attachments left unattached longer than this")
+	chat := fs.Duration("chat", a.cfg.Chat.MessageRetention, "delete chat messages older than this")
+	dryRun := fs.Bool("dry-run", false, "count what would be removed without removing it")
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments %q", args)
+		}
+		chatUseCase := a.chat(*chat)
+		tasks := []struct {
+			name      string
+			olderThan time.Duration
+			count     func(context.Context) (int, error)
+			run       func(context.Context) (int, error)
+		}{
+			{
+				name:      "trash",
+				olderThan: *trash,
+				count:     func(ctx context.Context) (int, error) { return a.trash.CountPurgeable(ctx, *trash) },
+				run:       func(ctx context.Context) (int, error) { return a.trash.Purge(ctx, *trash) },
+			},
+			{
+				name:      "attachments",
+				olderThan: *orphans,
+				count:     func(ctx context.Context) (int, error) { return a.attachments.CountOrphans(ctx, *orphans) },
+				run:       func(ctx context.Context) (int, error) { return a.attachments.CollectOrphans(ctx, *orphans) },
+			},
+			{
+				name:      "chat",
+				olderThan: *chat,
+				count:     chatUseCase.CountExpired,
+				run:       chatUseCase.DeleteExpired,
+			},
+		}
+
+		// сроки проверяются до первой очистки, чтобы неверный флаг не оставил очистку выполненной наполовину
+		for _, t := range tasks {
+			if t.olderThan <= 0 {
+				return usagef("-%s must be a positive duration", t.name)
+			}
+		}
+
+		res := retentionResult{DryRun: *dryRun}
+		r := report{header: []string{"TASK", "OLDER THAN", "REMOVED"}}
+		if *dryRun {
+			r.header[2] = "TO REMOVE"
+		}
+		for _, t := range tasks {
+			step := t.run
+			if *dryRun {
+				step = t.count
+			}
+			n, err := step(ctx)
+			if err != nil {
+				return fmt.Errorf("%s: %w", t.name, err)
+			}
+			res.Tasks = append(res.Tasks, retentionTask{Name: t.name, OlderThan: t.olderThan.String(), Count: n})
+			r.rows = append(r.rows, []string{t.name, t.olderThan.String(), itoa(n)})
+		}
+		r.value = res
+		if *dryRun {
+			r.notes = append(r.notes, dryRunNote)
+		}
+		return a.out.print(r)
+	}
+}
+
+func activityList(fs *flag.FlagSet, a *app) func(context.Context, []string) error {
+	userID := fs.Int("user", 0, "show only the activity of this user ID")
+	limit := fs.Int("limit", 20, "number of entries, at most 1000")
+	return func(ctx context.Context, args []string) error {
+		if len(args) > 0 {
+			return usagef("unexpected arguments %q", args)
+		}
+		if *limit <= 0 || *limit > 1000 {
+			return usagef("-limit must be between 1 and 1000")
+		}
+		items, err := a.activity.Recent(ctx, *userID, *limit)
+		if err != nil {
+			return err
+		}
+		if items == nil {
+			items = []model.Activity{}
+		}
+		r := report{value: items, header: []string{"TIME", "TYPE", "ID", "TOPIC", "POST", "USER", "TEXT"}}
+		for _, it := range items {
+			r.rows = append(r.rows, []string{
+				formatTime(it.Timestamp), it.Type, itoa(it.ID), optInt(it.TopicID), optInt(it.PostID),
+				fmt.Sprintf("%s (%d)", it.Username, it.UserID), excerpt(it.Text, 60),
+			})
+		}
+		return a.out.print(r)
+	}
+}
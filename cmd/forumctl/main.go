@@ -0,0 +1,181 @@
+// Команда forumctl выполняет задачи обслуживания форума без ручного SQL: управляет темами, переносит посты,
+// стирает контент пользователя, пересчитывает счетчики, запускает очистку по срокам хранения и показывает
+// последнюю активность. Настройки берутся из того же файла конфигурации и окружения, что и у сервера.
+//
+//	forumctl [-config file] [-o table|json] [-v] <group> <command> [flags] [args]
+//
+// Разрушающие команды принимают -dry-run: они показывают, что будет изменено, ничего не меняя
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"golangforum/internal/config"
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/impl"
+	"golangforum/internal/usecase"
+	usecaseImpl "golangforum/internal/usecase/impl"
+)
+
+// app — зависимости команд
+type app struct {
+	cfg         *config.Config
+	out         *output
+	topics      usecase.TopicUseCase
+	posts       usecase.PostUseCase
+	activity    usecase.ActivityUseCase
+	trash       usecase.TrashUseCase
+	attachments usecase.AttachmentUseCase
+	// chat создает use case чата со сроком хранения сообщений retention
+	chat func(retention time.Duration) usecase.ChatUseCase
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run выполняет forumctl с аргументами args и возвращает код завершения: 0 — успех, 1 — ошибка команды,
+// 2 — неверные аргументы
+func run(args []string, stdout, stderr io.Writer) int {
+	fset := flag.NewFlagSet("forumctl", flag.ContinueOnError)
+	fset.SetOutput(stderr)
+	configPath := fset.String("config", "", "путь к YAML-файлу конфигурации (по умолчанию CONFIG_FILE)")
+	format := fset.String("o", formatTable, "формат вывода: table или json")
+	verbose := fset.Bool("v", false, "писать в stderr журнал use case")
+	fset.Usage = func() { usage(stderr, fset) }
+	if err := fset.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *format != formatTable && *format != formatJSON {
+		fmt.Fprintf(stderr, "forumctl: unknown output format %q\n", *format)
+		return 2
+	}
+	cmd, rest, err := findCommand(fset.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "forumctl: %v\n\n", err)
+		usage(stderr, fset)
+		return 2
+	}
+
+	level := zerolog.WarnLevel
+	if *verbose {
+		level = zerolog.InfoLevel
+	}
+	log.Logger = zerolog.New(stderr).Level(level).With().Timestamp().Logger()
+	zerolog.DefaultContextLogger = &log.Logger
+
+	var configArgs []string
+	if *configPath != "" {
+		configArgs = []string{"-config", *configPath}
+	}
+	cfg, err := config.Load(configArgs)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	model.SetLimits(cfg.ModelLimits())
+
+	// sql.Open не подключается к базе, поэтому справка по команде работает и без нее
+	db, err := sql.Open("postgres", cfg.Database.URL)
+	if err != nil {
+		fmt.Fprintf(stderr, "forumctl: open database: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	a, err := newApp(cfg, db, &output{w: stdout, format: *format})
+	if err != nil {
+		fmt.Fprintf(stderr, "forumctl: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return execute(ctx, a, cmd, rest, stderr)
+}
+
+// execute разбирает флаги команды cmd из args и выполняет ее. Коды завершения те же, что у run
+func execute(ctx context.Context, a *app, cmd command, args []string, stderr io.Writer) int {
+	cset := flag.NewFlagSet("forumctl "+cmd.name, flag.ContinueOnError)
+	cset.SetOutput(stderr)
+	cset.Usage = func() {
+		fmt.Fprintf(stderr, "usage: forumctl %s [flags] %s\n\n%s\n", cmd.name, cmd.args, cmd.summary)
+		cset.PrintDefaults()
+	}
+	runCmd := cmd.setup(cset, a)
+	if err := cset.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if err := runCmd(ctx, cset.Args()); err != nil {
+		var uerr usageError
+		if errors.As(err, &uerr) {
+			fmt.Fprintf(stderr, "forumctl %s: %v\n\n", cmd.name, err)
+			cset.Usage()
+			return 2
+		}
+		fmt.Fprintf(stderr, "forumctl %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+func newApp(cfg *config.Config, db *sql.DB, out *output) (*app, error) {
+	blobStore, err := impl.NewLocalBlobStore(cfg.Attachments.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("open attachment storage: %w", err)
+	}
+	topicRepo := impl.NewTopicRepository(db)
+	mentionRepo := impl.NewMentionRepository(db)
+	attachmentRepo := impl.NewAttachmentRepository(db)
+	return &app{
+		cfg:    cfg,
+		out:    out,
+		topics: usecaseImpl.NewTopicUseCase(topicRepo),
+		posts: usecaseImpl.NewPostUseCase(
+			impl.NewPostRepository(db),
+			mentionRepo,
+			attachmentRepo,
+			impl.NewReactionRepository(db),
+			impl.NewTagRepository(db),
+			topicRepo,
+			markdown.NewRenderer(0),
+			nil,
+		),
+		activity:    usecaseImpl.NewActivityUseCase(impl.NewActivityRepository(db), topicRepo),
+		trash:       usecaseImpl.NewTrashUseCase(impl.NewTrashRepository(db)),
+		attachments: usecaseImpl.NewAttachmentUseCase(attachmentRepo, blobStore, cfg.Attachments.MaxSize),
+		chat: func(retention time.Duration) usecase.ChatUseCase {
+			return usecaseImpl.NewChatUseCase(impl.NewChatRepository(db), mentionRepo, retention, nil)
+		},
+	}, nil
+}
+
+func usage(w io.Writer, fset *flag.FlagSet) {
+	fmt.Fprintln(w, "usage: forumctl [flags] <group> <command> [flags] [args]\n\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-18s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w, "\nflags:")
+	fset.PrintDefaults()
+	fmt.Fprintln(w, "\nforumctl <group> <command> -h describes the command")
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/config"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+	"golangforum/internal/usecase/mocks"
+)
+
+// testApp — app на моках use case; stdout и stderr команд собираются в буферы
+type testApp struct {
+	app         *app
+	stdout      bytes.Buffer
+	stderr      bytes.Buffer
+	topics      *mocks.MockTopicUseCase
+	posts       *mocks.MockPostUseCase
+	activity    *mocks.MockActivityUseCase
+	trash       *mocks.MockTrashUseCase
+	attachments *mocks.MockAttachmentUseCase
+	chat        *mocks.MockChatUseCase
+	// chatRetention — срок хранения, с которым команда создала use case чата
+	chatRetention time.Duration
+}
+
+func newTestApp(t *testing.T, format string) *testApp {
+	ctrl := gomock.NewController(t)
+	cfg := config.Default()
+	ta := &testApp{
+		topics:      mocks.NewMockTopicUseCase(ctrl),
+		posts:       mocks.NewMockPostUseCase(ctrl),
+		activity:    mocks.NewMockActivityUseCase(ctrl),
+		trash:       mocks.NewMockTrashUseCase(ctrl),
+		attachments: mocks.NewMockAttachmentUseCase(ctrl),
+		chat:        mocks.NewMockChatUseCase(ctrl),
+	}
+	ta.app = &app{
+		cfg:         &cfg,
+		out:         &output{w: &ta.stdout, format: format},
+		topics:      ta.topics,
+		posts:       ta.posts,
+		activity:    ta.activity,
+		trash:       ta.trash,
+		attachments: ta.attachments,
+		chat: func(retention time.Duration) usecase.ChatUseCase {
+			ta.chatRetention = retention
+			return ta.chat
+		},
+	}
+	return ta
+}
+
+// exec выполняет команду forumctl, заданную args, и возвращает код завершения
+func (ta *testApp) exec(t *testing.T, args ...string) int {
+	t.Helper()
+	cmd, rest, err := findCommand(args)
+	require.NoError(t, err)
+	return execute(context.Background(), ta.app, cmd, rest, &ta.stderr)
+}
+
+var testTopics = []model.Topic{
+	{ID: 1, Title: "Go"},
+	{ID: 2, ParentID: intPtr(1), Position: 1, Title: "Tools", PostCount: 3},
+	{ID: 3, Title: "Off-topic", Archived: true},
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestRun_Usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"help", []string{"-h"}, 0},
+		{"no command", nil, 2},
+		{"group only", []string{"topics"}, 2},
+		{"unknown command", []string{"topics", "frobnicate"}, 2},
+		{"unknown flag", []string{"-bogus", "topics", "list"}, 2},
+		{"unknown format", []string{"-o", "xml", "topics", "list"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			// до подключения к базе дело не доходит
+			code := run(tt.args, &stdout, &stderr)
+
+			assert.Equal(t, tt.want, code)
+			assert.Empty(t, stdout.String())
+			assert.NotEmpty(t, stderr.String())
+		})
+	}
+}
+
+func TestExecute_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		setup func(ta *testApp)
+		want  int
+	}{
+		{
+			name:  "success",
+			args:  []string{"topics", "list"},
+			setup: func(ta *testApp) { ta.topics.EXPECT().GetAll(gomock.Any(), false).Return(testTopics, nil) },
+			want:  0,
+		},
+		{
+			name: "command help",
+			args: []string{"topics", "delete", "-h"},
+			want: 0,
+		},
+		{
+			name: "unknown command flag",
+			args: []string{"topics", "list", "-bogus"},
+			want: 2,
+		},
+		{
+			name: "unexpected argument",
+			args: []string{"topics", "list", "extra"},
+			want: 2,
+		},
+		{
+			name: "missing ID",
+			args: []string{"topics", "delete"},
+			want: 2,
+		},
+		{
+			name: "invalid ID",
+			args: []string{"topics", "delete", "abc"},
+			want: 2,
+		},
+		{
+			name: "missing title",
+			args: []string{"topics", "rename", "1"},
+			want: 2,
+		},
+		{
+			name: "missing target topic",
+			args: []string{"posts", "move", "1", "2"},
+			want: 2,
+		},
+		{
+			name: "non-positive duration",
+			args: []string{"retention", "run", "-chat", "0s"},
+			want: 2,
+		},
+		{
+			name: "use case error",
+			args: []string{"topics", "list"},
+			setup: func(ta *testApp) {
+				ta.topics.EXPECT().GetAll(gomock.Any(), false).Return(nil, errors.New("connection refused"))
+			},
+			want: 1,
+		},
+		{
+			name:  "topic not found",
+			args:  []string{"topics", "delete", "42"},
+			setup: func(ta *testApp) { ta.topics.EXPECT().GetAll(gomock.Any(), true).Return(testTopics, nil) },
+			want:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ta := newTestApp(t, formatTable)
+			if tt.setup != nil {
+				tt.setup(ta)
+			}
+
+			assert.Equal(t, tt.want, ta.exec(t, tt.args...), ta.stderr.String())
+		})
+	}
+}
+
+func TestTopicsList_Table(t *testing.T) {
+	ta := newTestApp(t, formatTable)
+	ta.topics.EXPECT().GetAll(gomock.Any(), true).Return(testTopics, nil)
+
+	require.Equal(t, 0, ta.exec(t, "topics", "list", "-archived"))
+
+	assert.Equal(t, strings.Join([]string{
+		"ID  PARENT  POSITION  TITLE      POSTS  COMMENTS  STATE",
+		"1   -       0         Go         0      0         -",
+		"2   1       1         Tools      3      0         -",
+		"3   -       0         Off-topic  0      0         archived",
+		"",
+	}, "\n"), ta.stdout.String())
+}
+
+func TestTopicsList_JSON(t *testing.T) {
+	ta := newTestApp(t, formatJSON)
+	ta.topics.EXPECT().GetAll(gomock.Any(), false).Return(testTopics, nil)
+
+	require.Equal(t, 0, ta.exec(t, "topics", "list"))
+
+	var got []model.Topic
+	require.NoError(t, json.Unmarshal(ta.stdout.Bytes(), &got))
+	assert.Equal(t, testTopics, got)
+}
+
+func TestTopicsDelete(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		ta := newTestApp(t, formatJSON)
+		ta.topics.EXPECT().GetAll(gomock.Any(), true).Return(testTopics, nil)
+		args := []string{"topics", "delete", "1"}
+		if dryRun {
+			// Delete не ожидается: вызов провалит тест
+			args = []string{"topics", "delete", "-dry-run", "1"}
+		} else {
+			ta.topics.EXPECT().Delete(gomock.Any(), 1, 0).Return(nil)
+		}
+
+		require.Equal(t, 0, ta.exec(t, args...), ta.stderr.String())
+
+		var got deleteResult
+		require.NoError(t, json.Unmarshal(ta.stdout.Bytes(), &got))
+		assert.Equal(t, deleteResult{DryRun: dryRun, Topics: testTopics[:2]}, got)
+	}
+}
+
+func TestPostsMove_DryRun(t *testing.T) {
+	ta := newTestApp(t, formatTable)
+	ta.topics.EXPECT().GetByID(gomock.Any(), 2).Return(&testTopics[1], nil)
+	ta.posts.EXPECT().GetByID(gomock.Any(), 10, 0).Return(&model.Post{ID: 10, TopicID: 1, Title: "Modules", CommentCount: 4}, nil)
+	ta.posts.EXPECT().GetByID(gomock.Any(), 11, 0).Return(nil, usecase.ErrPostNotFound)
+
+	require.Equal(t, 0, ta.exec(t, "posts", "move", "-to", "2", "-dry-run", "10", "11"), ta.stderr.String())
+
+	assert.Contains(t, ta.stdout.String(), "1 posts would be moved to topic 2")
+	assert.Contains(t, ta.stdout.String(), dryRunNote)
+}
+
+func TestUsersPurge_DryRun(t *testing.T) {
+	ta := newTestApp(t, formatJSON)
+	content := &model.UserContent{UserID: 7, Posts: 2, Comments: 5, Replies: 1, Messages: 3}
+	ta.activity.EXPECT().UserContent(gomock.Any(), 7).Return(content, nil)
+
+	require.Equal(t, 0, ta.exec(t, "users", "purge", "-dry-run", "7"), ta.stderr.String())
+
+	var got purgeResult
+	require.NoError(t, json.Unmarshal(ta.stdout.Bytes(), &got))
+	assert.Equal(t, purgeResult{DryRun: true, UserContent: *content}, got)
+}
+
+func TestStatsRecompute(t *testing.T) {
+	ta := newTestApp(t, formatJSON)
+	ta.topics.EXPECT().RecomputeStats(gomock.Any()).Return(model.StatsRecount{Topics: 3, Posts: 5}, nil)
+
+	require.Equal(t, 0, ta.exec(t, "stats", "recompute"))
+
+	assert.JSONEq(t, `{"topics":3,"posts":5}`, ta.stdout.String())
+}
+
+func TestRetentionRun(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		ta := newTestApp(t, formatJSON)
+		args := []string{"retention", "run", "-chat", "48h"}
+		if dryRun {
+			args = append(args, "-dry-run")
+			ta.trash.EXPECT().CountPurgeable(gomock.Any(), ta.app.cfg.Trash.Retention).Return(1, nil)
+			ta.attachments.EXPECT().CountOrphans(gomock.Any(), ta.app.cfg.Attachments.OrphanTTL).Return(2, nil)
+			ta.chat.EXPECT().CountExpired(gomock.Any()).Return(3, nil)
+		} else {
+			ta.trash.EXPECT().Purge(gomock.Any(), ta.app.cfg.Trash.Retention).Return(1, nil)
+			ta.attachments.EXPECT().CollectOrphans(gomock.Any(), ta.app.cfg.Attachments.OrphanTTL).Return(2, nil)
+			ta.chat.EXPECT().DeleteExpired(gomock.Any()).Return(3, nil)
+		}
+
+		require.Equal(t, 0, ta.exec(t, args...), ta.stderr.String())
+
+		assert.Equal(t, 48*time.Hour, ta.chatRetention)
+		var got retentionResult
+		require.NoError(t, json.Unmarshal(ta.stdout.Bytes(), &got))
+		assert.Equal(t, dryRun, got.DryRun)
+		if assert.Len(t, got.Tasks, 3) {
+			assert.Equal(t, retentionTask{Name: "chat", OlderThan: "48h0m0s", Count: 3}, got.Tasks[2])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Форматы вывода
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// report — результат команды. В формате JSON печатается value, в табличном — rows под заголовком header
+// и заметки notes после таблицы
+type report struct {
+	value  any
+	header []string
+	rows   [][]string
+	notes  []string
+}
+
+type output struct {
+	w      io.Writer
+	format string
+}
+
+func (o *output) print(r report) error {
+	if o.format == formatJSON {
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r.value)
+	}
+	tw := tabwriter.NewWriter(o.w, 0, 0, 2, ' ', 0)
+	if len(r.header) > 0 {
+		fmt.Fprintln(tw, strings.Join(r.header, "\t"))
+	}
+	for _, row := range r.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, n := range r.notes {
+		if _, err := fmt.Fprintln(o.w, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+// optInt печатает необязательный ID; отсутствующий — как "-"
+func optInt(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
+
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// excerpt сокращает текст до одной строки не длиннее n символов
+func excerpt(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+// Команда reconcile заново вычисляет денормализованную статистику тем (количество постов и комментариев,
+// последний пост и время последней активности) и постов (количество комментариев и время последней активности)
+// по таблицам posts и comments.
+// Запускается вручную или по расписанию, если счетчики разошлись с данными
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	_ "github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"golangforum/internal/config"
+	"golangforum/internal/repository/impl"
+	usecaseImpl "golangforum/internal/usecase/impl"
+)
+
+func main() {
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	db, err := sql.Open("postgres", cfg.Database.URL)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to open database connection")
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		logger.Fatal().Err(err).Msg("failed to ping database")
+	}
+
+	n, err := usecaseImpl.NewTopicUseCase(impl.NewTopicRepository(db)).RecomputeStats(ctx)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to recompute topic statistics")
+	}
+	logger.Info().Int("topics", n.Topics).Int("posts", n.Posts).Msg("topic statistics reconciled")
+}
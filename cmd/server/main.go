@@ -1,19 +1,38 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"flag"
+	"fmt"
 	"golangforum/internal/repository/impl"
-	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
-	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"github.com/gorilla/websocket"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog"
-	httpSwagger "github.com/swaggo/http-swagger"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	_ "golangforum/docs"
 	"golangforum/internal/client"
+	"golangforum/internal/config"
 	"golangforum/internal/handler"
+	"golangforum/internal/health"
+	"golangforum/internal/markdown"
+	"golangforum/internal/metrics"
+	"golangforum/internal/middleware"
+	"golangforum/internal/model"
+	"golangforum/internal/schema"
+	"golangforum/internal/tracing"
 	usecaseImpl "golangforum/internal/usecase/impl"
 )
 
@@ -21,78 +40,315 @@ var logger zerolog.Logger
 
 func init() {
 	logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+	// use case пишут в логгер запроса из контекста; фоновые задачи, у которых его нет, — в общий
+	log.Logger = logger
+	zerolog.DefaultContextLogger = &logger
 }
 
 // @title API сервиса форума
 // @version 1.0
 // @host localhost:8080
-// @BasePath /
+// @BasePath /api/v1
 // @schemes http
 func main() {
-	if err := godotenv.Load(); err != nil {
-		logger.Fatal().Err(err).Msg("failed to load .env")
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
 	}
 
-	db, err := sql.Open("postgres", os.Getenv("DATABASE_URL"))
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Options{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to initialize tracing")
+	}
+
+	connector, err := pq.NewConnector(cfg.Database.URL)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to open database connection")
 	}
+	var dbConnector driver.Connector = connector
+	if tracing.Enabled() {
+		dbConnector = tracing.WrapConnector(connector, "golangforum/internal/repository/impl.")
+	}
+	db := sql.OpenDB(dbConnector)
 	defer db.Close()
+	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+
+	if cfg.Database.AutoMigrate {
+		if err := migrate(ctx, cfg.Database); err != nil {
+			logger.Fatal().Err(err).Msg("failed to apply migrations")
+		}
+	}
+
+	m := metrics.New()
+	m.RegisterDB(db, "postgres")
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
 		logger.Fatal().Err(err).Msg("failed to ping database")
 	}
 
-	authClient, err := client.NewClient(os.Getenv("AUTH_SERVICE_ADDR"))
+	authClient, err := client.NewClient(cfg.Auth.Addr, cfg.Auth.DialTimeout, cfg.Auth.Timeout, m)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to initialize auth client")
 	}
 
+	migrationVersions, err := schema.Embedded()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to read migrations")
+	}
+	probes := health.New(cfg.Health.CacheTTL, cfg.Health.CheckTimeout)
+	probes.Add("database", db.PingContext)
+	probes.Add("migrations", health.Migrations(db, migrationVersions))
+	probes.Add("auth", authClient.Ping)
+
+	blobStore, err := impl.NewLocalBlobStore(cfg.Attachments.Dir)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to initialize attachment storage")
+	}
+	model.SetLimits(cfg.ModelLimits())
+
+	mentionRepo := impl.NewMentionRepository(db)
+	attachmentRepo := impl.NewAttachmentRepository(db)
+	reactionRepo := impl.NewReactionRepository(db)
+	tagRepo := impl.NewTagRepository(db)
+	topicRepo := impl.NewTopicRepository(db)
+	renderer := markdown.NewRenderer(markdown.DefaultCacheSize)
+	attachmentUseCase := usecaseImpl.NewAttachmentUseCase(attachmentRepo, blobStore, cfg.Attachments.MaxSize)
+	commentUseCase := usecaseImpl.NewCommentUseCase(impl.NewCommentRepository(db), mentionRepo, attachmentRepo, reactionRepo, topicRepo, renderer, m)
+
+	origins := middleware.NewOrigins(cfg.CORS.AllowedOrigins)
+	if origins.Any() {
+		logger.Warn().Msg("CORS allows any origin; set CORS_ALLOWED_ORIGINS to restrict it")
+	}
 	chatHandler := handler.NewChatHandler(
-		usecaseImpl.NewChatUseCase(impl.NewChatRepository(db)),
+		usecaseImpl.NewChatUseCase(impl.NewChatRepository(db), mentionRepo, cfg.Chat.MessageRetention, m),
 		authClient,
+		origins,
+		m,
 	)
 	topicHandler := handler.NewTopicHandler(
-		usecaseImpl.NewTopicUseCase(impl.NewTopicRepository(db)),
+		usecaseImpl.NewTopicUseCase(topicRepo),
+		authClient,
 	)
 	postHandler := handler.NewPostHandler(
-		usecaseImpl.NewPostUseCase(impl.NewPostRepository(db)),
+		usecaseImpl.NewPostUseCase(impl.NewPostRepository(db), mentionRepo, attachmentRepo, reactionRepo, tagRepo, topicRepo, renderer, m),
+		commentUseCase,
 		authClient,
 	)
-	commentHandler := handler.NewCommentHandler(
-		usecaseImpl.NewCommentUseCase(impl.NewCommentRepository(db)),
+	commentHandler := handler.NewCommentHandler(commentUseCase, authClient)
+	mentionHandler := handler.NewMentionHandler(
+		usecaseImpl.NewMentionUseCase(mentionRepo),
 		authClient,
 	)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentUseCase, authClient, cfg.Attachments.MaxSize)
+	tagHandler := handler.NewTagHandler(
+		usecaseImpl.NewTagUseCase(tagRepo),
+		authClient,
+	)
+	trashUseCase := usecaseImpl.NewTrashUseCase(impl.NewTrashRepository(db))
+	trashHandler := handler.NewTrashHandler(trashUseCase, authClient)
+	reactionHandler := handler.NewReactionHandler(
+		usecaseImpl.NewReactionUseCase(reactionRepo),
+		authClient,
+	)
+
+	// сокет открывается до запуска фоновых задач: если адрес занят или сертификат не читается, сервер
+	// завершается с ошибкой, ничего не начав
+	var tlsConfig *tls.Config
+	if cfg.HTTP.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.HTTP.TLSCertFile, cfg.HTTP.TLSKeyFile)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to load TLS certificate")
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+	ln, err := net.Listen("tcp", cfg.HTTP.Addr)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to listen")
+	}
+
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		collectOrphanedAttachments(
+			ctx,
+			attachmentUseCase,
+			cfg.Attachments.GCInterval,
+			cfg.Attachments.OrphanTTL,
+		)
+	}()
+	go func() {
+		defer workers.Done()
+		purgeTrash(
+			ctx,
+			trashUseCase,
+			cfg.Trash.PurgeInterval,
+			cfg.Trash.Retention,
+		)
+	}()
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/chat", chatHandler.ServeWS)
-	mux.HandleFunc("/chat/messages", chatHandler.GetAllMessages)
-	mux.HandleFunc("/topics", topicHandler.GetAll)
-	mux.HandleFunc("/topics/create", topicHandler.Create)
-	mux.HandleFunc("/topics/delete", topicHandler.Delete)
-	mux.HandleFunc("/posts", postHandler.GetByTopic)
-	mux.HandleFunc("/posts/all", postHandler.GetAll)
-	mux.HandleFunc("/posts/create", postHandler.Create)
-	mux.HandleFunc("/posts/delete", postHandler.Delete)
-	mux.HandleFunc("/comments", commentHandler.GetByPost)
-	mux.HandleFunc("/comments/create", commentHandler.Create)
-	mux.HandleFunc("/comments/delete", commentHandler.Delete)
-	mux.Handle("/swagger/", httpSwagger.WrapHandler)
-
-	logger.Info().Msg("Starting server on :8080")
-	log.Fatal(http.ListenAndServe(":8080", withCORS(mux)))
+	registerRoutes(mux, handlers{
+		chat:        chatHandler,
+		topics:      topicHandler,
+		posts:       postHandler,
+		comments:    commentHandler,
+		mentions:    mentionHandler,
+		attachments: attachmentHandler,
+		tags:        tagHandler,
+		reactions:   reactionHandler,
+		trash:       trashHandler,
+		metrics:     m.Handler(),
+		health:      probes,
+	})
+
+	var h http.Handler = middleware.Route(mux)
+	h = withTimeout(h, cfg.HTTP.RequestTimeout)
+	h = middleware.Metrics(h, m)
+	h = middleware.CORS(h, origins, cfg.CORS.MaxAge)
+	h = middleware.SecurityHeaders(h, middleware.SecurityOptions{
+		ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+		SwaggerPolicy:         cfg.Security.SwaggerPolicy,
+		SwaggerPrefix:         "/swagger/",
+		FrameOptions:          cfg.Security.FrameOptions,
+		HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+	})
+	// журнал запросов снаружи, чтобы в него попадали и отклоненные CORS запросы
+	h = middleware.Logging(h, logger)
+	if tracing.Enabled() {
+		// снаружи журнала, чтобы в записи о запросе попал trace_id; опросы метрик и проверки готовности не трассируются
+		h = otelhttp.NewHandler(h, "http.request", otelhttp.WithFilter(func(r *http.Request) bool {
+			switch r.URL.Path {
+			case "/metrics", "/healthz", "/readyz":
+				return false
+			}
+			return true
+		}))
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.HTTP.Addr,
+		Handler:           h,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		TLSConfig:         tlsConfig,
+	}
+	srv.RegisterOnShutdown(chatHandler.CloseAll)
+
+	s := &server{
+		http:            srv,
+		probes:          probes,
+		chat:            chatHandler,
+		drainDelay:      cfg.HTTP.DrainDelay,
+		shutdownTimeout: cfg.HTTP.ShutdownTimeout,
+	}
+	exitCode := 0
+	if err := s.run(ctx, ln); err != nil {
+		logger.Error().Err(err).Msg("server failed")
+		exitCode = 1
+	}
+	// после сигнала или падения сервера останавливаются и фоновые задачи
+	stop()
+	workers.Wait()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	if err := shutdownTracing(flushCtx); err != nil {
+		logger.Error().Err(err).Msg("failed to flush traces")
+	}
+	cancel()
+	logger.Info().Msg("server stopped")
+	if exitCode != 0 {
+		// os.Exit не выполняет отложенные вызовы, поэтому база закрывается явно
+		db.Close()
+		os.Exit(exitCode)
+	}
 }
 
-func withCORS(next http.Handler) http.Handler {
+// migrate применяет встроенные миграции до того, как сервер начнет обращаться к базе. Одновременно
+// запущенные экземпляры ждут друг друга на блокировке миграций
+func migrate(ctx context.Context, cfg config.Database) error {
+	migrator, err := schema.New(cfg.URL, cfg.MigrateLockTimeout, logger)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+	if err := migrator.Up(ctx); err != nil {
+		return err
+	}
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	logger.Info().Uint("version", status.Version).Msg("database schema is up to date")
+	return nil
+}
+
+// withTimeout ограничивает время обработки запроса: по истечении timeout контекст запроса отменяется,
+// а вместе с ним и запросы к базе. WebSocket-соединения живут дольше одного запроса и не ограничиваются
+func withTimeout(next http.Handler, timeout time.Duration) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger.Debug().Str("method", r.Method).Str("url", r.URL.String()).Msg("handling request")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization")
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
+		if websocket.IsWebSocketUpgrade(r) {
+			next.ServeHTTP(w, r)
 			return
 		}
-		next.ServeHTTP(w, r)
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// collectOrphanedAttachments периодически удаляет загруженные файлы, так и не прикрепленные к постам или комментариям.
+// Останавливается с отменой ctx
+func collectOrphanedAttachments(ctx context.Context, uc *usecaseImpl.AttachmentUseCase, interval, ttl time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := uc.CollectOrphans(ctx, ttl); err != nil && ctx.Err() == nil {
+				logger.Error().Err(err).Msg("failed to collect orphaned attachments")
+			}
+		}
+	}
+}
+
+// purgeTrash периодически окончательно стирает темы, посты и комментарии, пролежавшие в корзине дольше retention.
+// Останавливается с отменой ctx
+func purgeTrash(ctx context.Context, uc *usecaseImpl.TrashUseCase, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := uc.Purge(ctx, retention); err != nil && ctx.Err() == nil {
+				logger.Error().Err(err).Msg("failed to purge trash")
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"golangforum/internal/config"
+	"golangforum/internal/schema"
+)
+
+const migrateUsage = `usage: server migrate <command> [flags]
+
+commands:
+  up         apply all pending migrations
+  down N     roll back the last N migrations
+  status     print the applied and the latest migration
+  force V    mark migration V as applied and clear the dirty flag without running it
+
+flags are the same as for the server, see server -h`
+
+// migrateCommand — разобранные аргументы подкоманды migrate
+type migrateCommand struct {
+	action string
+	n      int // число миграций для down или версия для force
+}
+
+// parseMigrateCommand разбирает команду и ее аргумент; остальные аргументы — флаги конфигурации
+func parseMigrateCommand(args []string) (migrateCommand, []string, error) {
+	if len(args) == 0 {
+		return migrateCommand{}, nil, errors.New("missing command")
+	}
+	cmd := migrateCommand{action: args[0]}
+	args = args[1:]
+	switch cmd.action {
+	case "up", "status":
+		return cmd, args, nil
+	case "down", "force":
+		if len(args) == 0 {
+			return migrateCommand{}, nil, fmt.Errorf("%s requires a number", cmd.action)
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return migrateCommand{}, nil, fmt.Errorf("%s: %q is not a number", cmd.action, args[0])
+		}
+		if cmd.action == "down" && n <= 0 {
+			return migrateCommand{}, nil, fmt.Errorf("down: number of migrations must be positive, got %d", n)
+		}
+		cmd.n = n
+		return cmd, args[1:], nil
+	}
+	return migrateCommand{}, nil, fmt.Errorf("unknown command %q", cmd.action)
+}
+
+// runMigrate выполняет подкоманду migrate и возвращает код завершения процесса
+func runMigrate(args []string) int {
+	cmd, rest, err := parseMigrateCommand(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, migrateUsage)
+		return 2
+	}
+	cfg, err := config.Load(rest)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	migrator, err := schema.New(cfg.Database.URL, cfg.Database.MigrateLockTimeout, logger)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to open migrations")
+		return 1
+	}
+	defer migrator.Close()
+
+	switch cmd.action {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx, cmd.n)
+	case "force":
+		err = migrator.Force(cmd.n)
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("command", cmd.action).Msg("migration failed")
+		return 1
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to read schema version")
+		return 1
+	}
+	printStatus(os.Stdout, status)
+	return 0
+}
+
+func printStatus(w io.Writer, s schema.Status) {
+	fmt.Fprintf(w, "version: %d\nlatest: %d\npending: %d\n", s.Version, s.Latest, s.Pending)
+	if s.Dirty {
+		fmt.Fprintf(w, "dirty: migration %d failed; fix the schema by hand and run force\n", s.Version)
+	}
+}
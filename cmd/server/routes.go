@@ -0,0 +1,125 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	httpSwagger "github.com/swaggo/http-swagger"
+	"golangforum/internal/handler"
+	"golangforum/internal/health"
+)
+
+// legacyDeprecatedAt — момент, с которого маршруты без /api/v1 считаются устаревшими
+var legacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+type handlers struct {
+	chat        *handler.ChatHandler
+	topics      *handler.TopicHandler
+	posts       *handler.PostHandler
+	comments    *handler.CommentHandler
+	mentions    *handler.MentionHandler
+	attachments *handler.AttachmentHandler
+	tags        *handler.TagHandler
+	reactions   *handler.ReactionHandler
+	trash       *handler.TrashHandler
+	metrics     http.Handler
+	health      *health.Health
+}
+
+// registerRoutes регистрирует API /api/v1 и прежние маршруты, оставленные на время перехода клиентов
+func registerRoutes(mux *http.ServeMux, h handlers) {
+	const v1 = "/api/v1"
+	routes := []struct {
+		pattern string
+		handler http.HandlerFunc
+	}{
+		{"GET " + v1 + "/chat", h.chat.ServeWS},
+		{"GET " + v1 + "/chat/messages", h.chat.GetAllMessages},
+		{"GET " + v1 + "/topics", h.topics.GetAll},
+		{"POST " + v1 + "/topics", h.topics.Create},
+		{"GET " + v1 + "/topics/tree", h.topics.GetTree},
+		{"GET " + v1 + "/topics/{id}", h.topics.GetByID},
+		{"PATCH " + v1 + "/topics/{id}", h.topics.Update},
+		{"DELETE " + v1 + "/topics/{id}", h.topics.Delete},
+		{"POST " + v1 + "/topics/{id}/move", h.topics.Move},
+		{"POST " + v1 + "/topics/{id}/state", h.topics.SetState},
+		{"GET " + v1 + "/topics/{id}/posts", h.posts.GetByTopic},
+		{"GET " + v1 + "/posts", h.posts.GetAll},
+		{"POST " + v1 + "/posts", h.posts.Create},
+		{"GET " + v1 + "/posts/{id}", h.posts.GetByID},
+		{"PATCH " + v1 + "/posts/{id}", h.posts.Update},
+		{"DELETE " + v1 + "/posts/{id}", h.posts.Delete},
+		{"GET " + v1 + "/posts/{id}/comments", h.comments.GetByPost},
+		{"POST " + v1 + "/comments", h.comments.Create},
+		{"GET " + v1 + "/comments/{id}", h.comments.GetByID},
+		{"DELETE " + v1 + "/comments/{id}", h.comments.Delete},
+		{"GET " + v1 + "/mentions", h.mentions.GetMine},
+		{"POST " + v1 + "/attachments", h.attachments.Upload},
+		{"GET " + v1 + "/attachments/{id}", h.attachments.Download},
+		{"GET " + v1 + "/tags", h.tags.List},
+		{"PATCH " + v1 + "/tags/{id}", h.tags.Rename},
+		{"POST " + v1 + "/tags/merge", h.tags.Merge},
+		{"POST " + v1 + "/votes", h.reactions.Vote},
+		{"POST " + v1 + "/reactions", h.reactions.ToggleReaction},
+		{"GET " + v1 + "/trash", h.trash.List},
+		{"POST " + v1 + "/trash/restore", h.trash.Restore},
+	}
+	for _, rt := range routes {
+		mux.HandleFunc(rt.pattern, rt.handler)
+	}
+
+	legacy := []struct {
+		pattern string
+		handler http.HandlerFunc
+	}{
+		{"GET /chat", h.chat.ServeWS},
+		{"GET /chat/messages", h.chat.GetAllMessages},
+		{"GET /topics", h.topics.GetAll},
+		{"GET /topics/tree", h.topics.GetTree},
+		{"GET /topics/get", h.topics.GetByID},
+		{"POST /topics/create", h.topics.Create},
+		{"PUT /topics/update", h.topics.Update},
+		{"POST /topics/move", h.topics.Move},
+		{"POST /topics/state", h.topics.SetState},
+		{"DELETE /topics/delete", h.topics.Delete},
+		{"GET /posts", h.posts.GetByTopic},
+		{"GET /posts/all", h.posts.GetAll},
+		{"GET /posts/get", h.posts.GetByID},
+		{"POST /posts/create", h.posts.Create},
+		{"PUT /posts/update", h.posts.Update},
+		{"DELETE /posts/delete", h.posts.Delete},
+		{"GET /comments", h.comments.GetByPost},
+		{"GET /comments/get", h.comments.GetByID},
+		{"POST /comments/create", h.comments.Create},
+		{"DELETE /comments/delete", h.comments.Delete},
+		{"GET /mentions", h.mentions.GetMine},
+		{"POST /attachments/upload", h.attachments.Upload},
+		{"GET /attachments/download", h.attachments.Download},
+		{"GET /tags", h.tags.List},
+		{"POST /tags/rename", h.tags.Rename},
+		{"POST /tags/merge", h.tags.Merge},
+		{"POST /votes", h.reactions.Vote},
+		{"POST /reactions", h.reactions.ToggleReaction},
+		{"GET /trash", h.trash.List},
+		{"POST /trash/restore", h.trash.Restore},
+	}
+	for _, rt := range legacy {
+		mux.HandleFunc(rt.pattern, deprecated(rt.handler))
+	}
+
+	mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	mux.Handle("GET /metrics", h.metrics)
+	mux.HandleFunc("GET /healthz", h.health.Live)
+	mux.HandleFunc("GET /readyz", h.health.Ready)
+}
+
+// deprecated помечает ответ заголовком Deprecation (RFC 9745), чтобы клиенты заметили переход на /api/v1
+func deprecated(next http.HandlerFunc) http.HandlerFunc {
+	value := "@" + strconv.FormatInt(legacyDeprecatedAt.Unix(), 10)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", value)
+		w.Header().Set("Link", `</swagger/index.html>; rel="deprecation"`)
+		next(w, r)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/client"
+	"golangforum/internal/client/clienttest"
+	"golangforum/internal/handler"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase/mocks"
+)
+
+// newTestMux регистрирует маршруты сервера; обработчики, которые тесту не нужны, остаются пустыми
+func newTestMux(h handlers) *http.ServeMux {
+	if h.metrics == nil {
+		h.metrics = http.NotFoundHandler()
+	}
+	mux := http.NewServeMux()
+	registerRoutes(mux, h)
+	return mux
+}
+
+func serve(mux http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	return w
+}
+
+func TestRoutes_PostGetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	posts := mocks.NewMockPostUseCase(ctrl)
+	posts.EXPECT().GetByID(gomock.Any(), 42, 0).Return(&model.Post{ID: 42, Title: "Title"}, nil).Times(2)
+	mux := newTestMux(handlers{
+		posts: handler.NewPostHandler(posts, mocks.NewMockCommentUseCase(ctrl), clienttest.NewAuthClient(t, nil)),
+	})
+
+	for _, target := range []string{"/api/v1/posts/42", "/posts/get?post_id=42"} {
+		w := serve(mux, httptest.NewRequest(http.MethodGet, target, nil))
+
+		require.Equal(t, http.StatusOK, w.Code, target)
+		var got model.Post
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		assert.Equal(t, 42, got.ID, target)
+	}
+}
+
+func TestRoutes_PostGetByID_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mux := newTestMux(handlers{
+		posts: handler.NewPostHandler(mocks.NewMockPostUseCase(ctrl), mocks.NewMockCommentUseCase(ctrl), clienttest.NewAuthClient(t, nil)),
+	})
+
+	w := serve(mux, httptest.NewRequest(http.MethodGet, "/api/v1/posts/abc", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRoutes_PostCreateLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	posts := mocks.NewMockPostUseCase(ctrl)
+	var created model.Post
+	posts.EXPECT().Create(gomock.Any(), "alice", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, p *model.Post) error {
+			p.ID = 42
+			created = *p
+			return nil
+		})
+	posts.EXPECT().GetByID(gomock.Any(), 42, 7).DoAndReturn(
+		func(context.Context, int, int) (*model.Post, error) {
+			return &created, nil
+		})
+	auth := clienttest.NewAuthClient(t, map[string]client.User{"token": {ID: 7, Username: "alice"}})
+	mux := newTestMux(handlers{posts: handler.NewPostHandler(posts, mocks.NewMockCommentUseCase(ctrl), auth)})
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/posts", strings.NewReader(`{"topic_id":1,"title":"Title","content":"Text"}`))
+	r.Header.Set("Authorization", "Bearer token")
+	w := serve(mux, r)
+	require.Equal(t, http.StatusCreated, w.Code)
+	location := w.Header().Get("Location")
+	require.Equal(t, "/api/v1/posts/42", location)
+
+	r = httptest.NewRequest(http.MethodGet, location, nil)
+	r.Header.Set("Authorization", "Bearer token")
+	w = serve(mux, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var got model.Post
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, 42, got.ID)
+	assert.Equal(t, "Title", got.Title)
+	assert.Equal(t, 7, got.UserID)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"golangforum/internal/handler"
+	"golangforum/internal/health"
+)
+
+// server — HTTP-сервер вместе с тем, что нужно остановить вместе с ним
+type server struct {
+	http   *http.Server
+	probes *health.Health
+	chat   *handler.ChatHandler
+	// drainDelay — сколько /readyz отвечает 503 до закрытия слушающего сокета
+	drainDelay time.Duration
+	// shutdownTimeout ограничивает ожидание текущих запросов и WebSocket-соединений
+	shutdownTimeout time.Duration
+}
+
+// run обслуживает соединения ln, пока не отменят ctx или сервер не упадет. Сервер объявляется готовым, только
+// когда сокет уже открыт. После отмены ctx /readyz отвечает 503, через drainDelay сервер перестает принимать
+// соединения и дожидается текущих запросов и чатов. Возвращает ошибку, если сервер упал сам
+func (s *server) run(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info().Str("addr", ln.Addr().String()).Msg("Starting server")
+		if s.http.TLSConfig != nil {
+			// сертификат уже загружен в TLSConfig
+			serveErr <- s.http.ServeTLS(ln, "", "")
+			return
+		}
+		serveErr <- s.http.Serve(ln)
+	}()
+	s.probes.SetReady()
+
+	var failed error
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			failed = err
+		}
+	case <-ctx.Done():
+		logger.Info().Msg("shutdown signal received")
+		s.probes.SetDraining()
+		// балансировщик должен успеть заметить 503 на /readyz и перестать присылать новые запросы
+		time.Sleep(s.drainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if err := s.http.Shutdown(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("failed to drain HTTP requests")
+	}
+	if err := s.chat.Wait(shutdownCtx); err != nil {
+		logger.Error().Err(err).Msg("chat connections did not close in time")
+	}
+	return failed
+}
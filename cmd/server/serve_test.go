@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golangforum/internal/handler"
+	"golangforum/internal/health"
+	"golangforum/internal/middleware"
+)
+
+func newTestServer(mux *http.ServeMux, drainDelay time.Duration) *server {
+	probes := health.New(time.Second, time.Second)
+	mux.HandleFunc("GET /readyz", probes.Ready)
+	chat := handler.NewChatHandler(nil, nil, middleware.NewOrigins(nil), nil)
+	srv := &http.Server{Handler: mux}
+	srv.RegisterOnShutdown(chat.CloseAll)
+	return &server{http: srv, probes: probes, chat: chat, drainDelay: drainDelay, shutdownTimeout: 5 * time.Second}
+}
+
+func readyStatus(t *testing.T, base string) int {
+	t.Helper()
+	resp, err := http.Get(base + "/readyz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServer_Drain(t *testing.T) {
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+		io.WriteString(w, "done")
+	})
+	s := newTestServer(mux, 200*time.Millisecond)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	base := "http://" + ln.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- s.run(ctx, ln) }()
+
+	// сокет открыт до того, как сервер объявлен готовым
+	require.Eventually(t, func() bool { return readyStatus(t, base) == http.StatusOK }, time.Second, 10*time.Millisecond)
+
+	slow := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			slow <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		slow <- string(body)
+	}()
+	<-started
+	cancel()
+
+	// пока идет задержка, сервер еще принимает запросы, но /readyz уже отвечает 503
+	require.Eventually(t, func() bool {
+		return readyStatus(t, base) == http.StatusServiceUnavailable
+	}, 150*time.Millisecond, 10*time.Millisecond)
+
+	// начатый до сигнала запрос завершается
+	assert.Equal(t, "done", <-slow)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop")
+	}
+	_, err = http.Get(base + "/readyz")
+	assert.Error(t, err, "listener must be closed after shutdown")
+}
+
+func TestServer_ServeFailure(t *testing.T) {
+	s := newTestServer(http.NewServeMux(), time.Second)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ln.Close()
+
+	err = s.run(context.Background(), ln)
+
+	assert.Error(t, err)
+}
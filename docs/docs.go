@@ -15,6 +15,133 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/attachments": {
+            "post": {
+                "description": "Загружает файл для последующего прикрепления к посту или комментарию через attachment_ids. Тип файла определяется по содержимому; для изображений создается миниатюра. Непривязанные файлы удаляются по истечении срока хранения",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Вложения"
+                ],
+                "summary": "Загрузить файл",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Файл загружен",
+                        "schema": {
+                            "$ref": "#/definitions/model.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Недопустимый тип файла",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attachments/{id}": {
+            "get": {
+                "description": "Отдает содержимое вложения или его миниатюры. Поддерживаются запросы диапазонов (заголовок Range)",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Вложения"
+                ],
+                "summary": "Скачать файл",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID вложения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Отдать миниатюру вместо исходного файла",
+                        "name": "thumbnail",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Содержимое файла",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Часть содержимого файла",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный id",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Вложение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/chat": {
             "get": {
                 "description": "Устанавливает WebSocket соединение для чата",
@@ -47,13 +174,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "401": {
+                        "description": "Неверный токен",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
@@ -85,15 +212,15 @@ const docTemplate = `{
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
         "/comments": {
-            "get": {
-                "description": "Получает список всех комментариев для заданного поста",
+            "post": {
+                "description": "Создает новый комментарий для заданного поста",
                 "consumes": [
                     "application/json"
                 ],
@@ -103,50 +230,67 @@ const docTemplate = `{
                 "tags": [
                     "Комментарии"
                 ],
-                "summary": "Получить все комментарии для поста",
+                "summary": "Создать новый комментарий",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID поста",
-                        "name": "post_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Комментарий",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Comment"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Список комментариев",
+                    "201": {
+                        "description": "Созданный комментарий",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Comment"
+                            "$ref": "#/definitions/model.Comment"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Адрес созданного комментария"
                             }
                         }
                     },
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Тема в архиве",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/comments/create": {
-            "post": {
-                "description": "Создает новый комментарий для заданного поста",
+        "/comments/{id}": {
+            "get": {
+                "description": "Возвращает комментарий по ID. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -156,61 +300,51 @@ const docTemplate = `{
                 "tags": [
                     "Комментарии"
                 ],
-                "summary": "Создать новый комментарий",
+                "summary": "Получить комментарий",
                 "parameters": [
                     {
-                        "description": "Комментарий",
-                        "name": "comment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Comment"
-                        }
+                        "type": "integer",
+                        "description": "ID комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Комментарий создан",
+                    "200": {
+                        "description": "Комментарий",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.Comment"
                         }
                     },
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Неверный токен",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Комментарий не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/comments/delete": {
+            },
             "delete": {
-                "description": "Удаляет комментарий по ID",
+                "description": "Переносит комментарий в корзину, откуда его может восстановить администратор. С заголовком Authorization сохраняется, кто удалил комментарий",
                 "consumes": [
                     "application/json"
                 ],
@@ -225,8 +359,8 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "description": "ID комментария",
-                        "name": "comment_id",
-                        "in": "query",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -243,27 +377,33 @@ const docTemplate = `{
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Комментарий не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts": {
+        "/mentions": {
             "get": {
-                "description": "Возвращает список всех постов для указанной темы",
+                "description": "Возвращает упоминания текущего пользователя в постах, комментариях и сообщениях чата, начиная с самых новых. Общее количество передается в заголовке X-Total-Count",
                 "consumes": [
                     "application/json"
                 ],
@@ -271,52 +411,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Посты"
+                    "Упоминания"
                 ],
-                "summary": "Получить все посты по теме",
+                "summary": "Получить упоминания текущего пользователя",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID темы",
-                        "name": "topic_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Количество записей (по умолчанию 20, не более 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список постов",
+                        "description": "Список упоминаний",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Post"
+                                "$ref": "#/definitions/model.Mention"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество упоминаний"
                             }
                         }
                     },
                     "400": {
-                        "description": "Неверный topic_id",
+                        "description": "Неверные параметры пагинации",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/all": {
+        "/posts": {
             "get": {
-                "description": "Возвращает список всех постов",
+                "description": "Возвращает страницу постов всех тем с фильтрами и сортировкой. Общее количество передается в заголовке X-Total-Count. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -326,32 +477,101 @@ const docTemplate = `{
                 "tags": [
                     "Посты"
                 ],
-                "summary": "Получить все посты",
+                "summary": "Получить посты всех тем",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя автора (без учета регистра)",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Посты, созданные не раньше даты (RFC 3339 или ГГГГ-ММ-ДД)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Посты, созданные раньше даты (RFC 3339 или ГГГГ-ММ-ДД)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true — только посты с комментариями, false — только без комментариев",
+                        "name": "has_comments",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "all — посты со всеми тегами (по умолчанию), any — хотя бы с одним",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: newest (по умолчанию), oldest, comments, activity, top",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 20, не более 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Список всех постов",
+                        "description": "Список постов",
                         "schema": {
                             "type": "array",
                             "items": {
                                 "$ref": "#/definitions/model.Post"
                             }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество постов"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/posts/create": {
+            },
             "post": {
-                "description": "Создает новый пост в указанной теме",
+                "description": "Создает новый пост в указанной теме. Теги нормализуются: приводятся к нижнему регистру, пробелы заменяются дефисом; у поста не более 5 тегов",
                 "consumes": [
                     "application/json"
                 ],
@@ -375,47 +595,125 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "201": {
-                        "description": "Пост успешно создан",
+                        "description": "Созданный пост",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "$ref": "#/definitions/model.Post"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Адрес созданного поста"
                             }
                         }
                     },
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Не авторизован",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тема не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Тема в архиве",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Тема закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/posts/delete": {
+        "/posts/{id}": {
+            "get": {
+                "description": "Возвращает пост по ID. С comments=true в ответ добавляются комментарии к посту. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Посты"
+                ],
+                "summary": "Получить пост",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включить комментарии",
+                        "name": "comments",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка комментариев: top — по рейтингу; по умолчанию — в порядке создания",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пост",
+                        "schema": {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "delete": {
-                "description": "Удаляет пост по его ID",
+                "description": "Переносит пост вместе с комментариями в корзину, откуда его может восстановить администратор. С заголовком Authorization сохраняется, кто удалил пост",
                 "consumes": [
                     "application/json"
                 ],
@@ -430,8 +728,8 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "description": "ID поста",
-                        "name": "post_id",
-                        "in": "query",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -448,27 +746,108 @@ const docTemplate = `{
                     "400": {
                         "description": "Неверный post_id",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Изменяет заголовок, текст и теги поста. Отсутствующие в запросе поля не изменяются, переданные теги заменяют прежние. Изменять пост может только его автор",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Посты"
+                ],
+                "summary": "Изменить пост",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля поста",
+                        "name": "post",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PostUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Измененный пост",
+                        "schema": {
+                            "$ref": "#/definitions/model.Post"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Пост принадлежит другому пользователю",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Тема в архиве",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/topics": {
+        "/posts/{id}/comments": {
             "get": {
-                "description": "Возвращает список всех тем форума",
+                "description": "Получает список всех комментариев для заданного поста. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -476,34 +855,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Темы"
+                    "Комментарии"
+                ],
+                "summary": "Получить все комментарии для поста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: top — по рейтингу; по умолчанию — в порядке создания",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получить все темы",
                 "responses": {
                     "200": {
-                        "description": "Список тем",
+                        "description": "Список комментариев",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Topic"
+                                "$ref": "#/definitions/model.Comment"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/topics/create": {
+        "/reactions": {
             "post": {
-                "description": "Создает новую тему с заголовком и описанием, используя структуру Topic",
+                "description": "Ставит эмодзи-реакцию на пост или комментарий; если пользователь уже поставил такую реакцию, она снимается",
                 "consumes": [
                     "application/json"
                 ],
@@ -511,54 +914,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Темы"
+                    "Реакции"
                 ],
-                "summary": "Создать тему",
+                "summary": "Поставить или снять реакцию",
                 "parameters": [
                     {
-                        "description": "Данные темы",
-                        "name": "topic",
+                        "description": "Реакция",
+                        "name": "reaction",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Topic"
+                            "$ref": "#/definitions/model.ReactionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Тема успешно создана",
+                    "200": {
+                        "description": "Количество таких реакций после изменения",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ReactionCount"
                         }
                     },
                     "400": {
                         "description": "Неверный запрос",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост или комментарий не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/topics/delete": {
-            "delete": {
-                "description": "Удаляет тему по ее ID",
+        "/tags": {
+            "get": {
+                "description": "Возвращает теги с количеством постов, от самых используемых. С параметром q возвращаются только теги, начинающиеся с q (автодополнение)",
                 "consumes": [
                     "application/json"
                 ],
@@ -566,77 +972,1181 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Темы"
+                    "Теги"
                 ],
-                "summary": "Удалить тему",
+                "summary": "Получить теги",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало имени тега",
+                        "name": "q",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID темы",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Количество записей (по умолчанию 20, не более 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Тема успешно удалена",
+                        "description": "Список тегов",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Tag"
                             }
                         }
                     },
                     "400": {
-                        "description": "Неверный id",
+                        "description": "Неверный запрос",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.Comment": {
-            "description": "Структура комментария с необходимыми полями для хранения данных о комментарии",
-            "type": "object",
-            "properties": {
-                "content": {
-                    "description": "Текст комментария",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "ID комментария",
-                    "type": "integer"
-                },
-                "post_id": {
-                    "description": "ID поста, к которому относится комментарий",
-                    "type": "integer"
-                },
-                "timestamp": {
-                    "description": "Время создания комментария",
+        },
+        "/tags/merge": {
+            "post": {
+                "description": "Переносит тег source_id на его посты в виде тега target_id и удаляет source_id. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Слить теги",
+                "parameters": [
+                    {
+                        "description": "ID поглощаемого и остающегося тегов",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Оставшийся тег",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тег не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "patch": {
+                "description": "Меняет имя тега у всех постов. Если тег с новым именем уже существует, теги нужно слить. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Теги"
+                ],
+                "summary": "Переименовать тег",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тега",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое имя",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagRenameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Переименованный тег",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тег не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Тег с таким именем уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics": {
+            "get": {
+                "description": "Возвращает плоский список всех тем форума в порядке отображения со статистикой: количество постов и комментариев, последний пост и время последней активности. Архивные темы и их подтемы по умолчанию скрыты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Получить все темы",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Включить архивные темы",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список тем",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Topic"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создает новую тему с заголовком и описанием, используя структуру Topic. С parent_id тема создается как подфорум указанной темы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Создать тему",
+                "parameters": [
+                    {
+                        "description": "Данные темы",
+                        "name": "topic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Topic"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданная тема",
+                        "schema": {
+                            "$ref": "#/definitions/model.Topic"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "Адрес созданной темы"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Родительская тема не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/tree": {
+            "get": {
+                "description": "Возвращает темы верхнего уровня с вложенными подфорумами в поле children. Темы одного уровня упорядочены по position. Архивные темы и их подтемы по умолчанию скрыты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Получить дерево тем",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Включить архивные темы",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Дерево тем",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Topic"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{id}": {
+            "get": {
+                "description": "Возвращает тему по ID со статистикой: количество постов и комментариев, последний пост и время последней активности",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Получить тему",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID темы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тема",
+                        "schema": {
+                            "$ref": "#/definitions/model.Topic"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный id",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тема не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Переносит тему вместе с подтемами, постами и комментариями в корзину, откуда ее может восстановить администратор. С заголовком Authorization сохраняется, кто удалил тему",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Удалить тему",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID темы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тема успешно удалена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный id",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тема не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Изменяет заголовок, описание и порядок отображения темы; отсутствующие в запросе поля не изменяются. Родительская тема меняется через /topics/{id}/move. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Изменить тему",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID темы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля темы",
+                        "name": "topic",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TopicUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Измененная тема",
+                        "schema": {
+                            "$ref": "#/definitions/model.Topic"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тема не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{id}/move": {
+            "post": {
+                "description": "Переносит тему вместе с подфорумами под другую родительскую тему. Без parent_id тема переносится на верхний уровень. Перенос темы в саму себя или в собственный подфорум отклоняется. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Перенести тему",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID темы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID новой родительской темы",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TopicMoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тема перенесена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тема не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Перенос создал бы цикл",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{id}/posts": {
+            "get": {
+                "description": "Возвращает страницу постов указанной темы с фильтрами и сортировкой. Общее количество передается в заголовке X-Total-Count. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Посты"
+                ],
+                "summary": "Получить посты темы",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID темы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя автора (без учета регистра)",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Посты, созданные не раньше даты (RFC 3339 или ГГГГ-ММ-ДД)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Посты, созданные раньше даты (RFC 3339 или ГГГГ-ММ-ДД)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "true — только посты с комментариями, false — только без комментариев",
+                        "name": "has_comments",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Теги через запятую",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "all — посты со всеми тегами (по умолчанию), any — хотя бы с одним",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: newest (по умолчанию), oldest, comments, activity, top",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 20, не более 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список постов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Post"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество постов"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный токен",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{id}/state": {
+            "post": {
+                "description": "Помещает тему в архив (только чтение, скрыта из списка тем) или закрывает ее для новых постов. Отсутствующие поля не изменяются. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Темы"
+                ],
+                "summary": "Архивировать или закрыть тему",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID темы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые признаки",
+                        "name": "state",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TopicStateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тема после изменения",
+                        "schema": {
+                            "$ref": "#/definitions/model.Topic"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тема не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "description": "Возвращает страницу удаленных тем, постов или комментариев, начиная с удаленных последними. Общее количество передается в заголовке X-Total-Count. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Корзина"
+                ],
+                "summary": "Просмотреть корзину",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип объектов: topic, post или comment",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 20, не более 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленные объекты",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TrashItem"
+                            }
+                        },
+                        "headers": {
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Общее количество объектов в корзине"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/restore": {
+            "post": {
+                "description": "Восстанавливает тему, пост или комментарий вместе с вложенными объектами, удаленными вместе с ним. Объект, родитель которого удален, восстановить нельзя — сначала нужно восстановить родителя. Доступно только администраторам",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Корзина"
+                ],
+                "summary": "Восстановить из корзины",
+                "parameters": [
+                    {
+                        "description": "Тип и ID объекта",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TrashRestoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Объект восстановлен",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Объекта нет в корзине",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Родитель объекта удален",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/votes": {
+            "post": {
+                "description": "Ставит голос +1 или -1. Повторный такой же голос отменяет его, противоположный — заменяет прежний. От одного пользователя учитывается один голос",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Реакции"
+                ],
+                "summary": "Проголосовать за пост или комментарий",
+                "parameters": [
+                    {
+                        "description": "Голос",
+                        "name": "vote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.VoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рейтинг после голосования",
+                        "schema": {
+                            "$ref": "#/definitions/model.VoteResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост или комментарий не найден",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.Attachment": {
+            "description": "Метаданные загруженного файла. Пока файл не привязан к посту или комментарию, post_id и comment_id отсутствуют",
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "description": "ID комментария, к которому прикреплен файл",
+                    "type": "integer"
+                },
+                "content_type": {
+                    "description": "MIME-тип, определенный по содержимому",
+                    "type": "string"
+                },
+                "filename": {
+                    "description": "Исходное имя файла",
+                    "type": "string"
+                },
+                "has_thumbnail": {
+                    "description": "Есть ли у файла миниатюра",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "ID вложения",
+                    "type": "integer"
+                },
+                "post_id": {
+                    "description": "ID поста, к которому прикреплен файл",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "Размер файла в байтах",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Время загрузки",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "ID пользователя, загрузившего файл",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Имя пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Comment": {
+            "description": "Структура комментария с необходимыми полями для хранения данных о комментарии",
+            "type": "object",
+            "properties": {
+                "attachment_ids": {
+                    "description": "ID ранее загруженных файлов, которые нужно прикрепить при создании",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "attachments": {
+                    "description": "Прикрепленные файлы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Attachment"
+                    }
+                },
+                "content": {
+                    "description": "Текст комментария",
+                    "type": "string"
+                },
+                "content_html": {
+                    "description": "Текст, отрендеренный из Markdown в безопасный HTML",
+                    "type": "string"
+                },
+                "downvotes": {
+                    "description": "Количество голосов против",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID комментария",
+                    "type": "integer"
+                },
+                "mentions": {
+                    "description": "Упоминания пользователей в тексте",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MentionRange"
+                    }
+                },
+                "my_vote": {
+                    "description": "Голос текущего пользователя, если он авторизован",
+                    "type": "integer"
+                },
+                "post_id": {
+                    "description": "ID поста, к которому относится комментарий",
+                    "type": "integer"
+                },
+                "reactions": {
+                    "description": "Реакции",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReactionCount"
+                    }
+                },
+                "score": {
+                    "description": "Рейтинг: разница голосов за и против",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Время создания комментария",
+                    "type": "string"
+                },
+                "upvotes": {
+                    "description": "Количество голосов за",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "ID пользователя, оставившего комментарий",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Имя пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ErrorResponse": {
+            "description": "Единый формат ошибок API: code — машиночитаемый код, message — описание для человека",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Машиночитаемый код ошибки",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Ошибки в отдельных полях запроса",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldError"
+                    }
+                },
+                "message": {
+                    "description": "Описание ошибки",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "ID запроса для поиска в журналах",
+                    "type": "string"
+                }
+            }
+        },
+        "model.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Имя поля JSON или параметра строки запроса",
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "ID пользователя, оставившего комментарий",
+                "message": {
+                    "description": "Что не так со значением",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Mention": {
+            "description": "Структура упоминания с источником и упомянутым пользователем",
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Имя автора упоминания",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID упоминания",
                     "type": "integer"
                 },
+                "source_id": {
+                    "description": "ID источника упоминания",
+                    "type": "integer"
+                },
+                "source_type": {
+                    "description": "Тип источника: post, comment или message",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Время создания упоминания",
+                    "type": "string"
+                },
                 "username": {
-                    "description": "Имя пользователя",
+                    "description": "Имя упомянутого пользователя",
+                    "type": "string"
+                }
+            }
+        },
+        "model.MentionRange": {
+            "description": "Диапазон символов [start, end), занимаемый упоминанием @username",
+            "type": "object",
+            "properties": {
+                "end": {
+                    "description": "Позиция, следующая за последним символом имени",
+                    "type": "integer"
+                },
+                "start": {
+                    "description": "Позиция символа @ (в символах, не в байтах)",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "Имя упомянутого пользователя",
                     "type": "string"
                 }
             }
@@ -645,14 +2155,87 @@ const docTemplate = `{
             "description": "Структура поста с необходимыми полями для хранения данных о посте",
             "type": "object",
             "properties": {
+                "attachment_ids": {
+                    "description": "ID ранее загруженных файлов, которые нужно прикрепить при создании",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "attachments": {
+                    "description": "Прикрепленные файлы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Attachment"
+                    }
+                },
+                "breadcrumbs": {
+                    "description": "Путь от темы верхнего уровня до темы поста",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TopicRef"
+                    }
+                },
+                "comment_count": {
+                    "description": "Количество комментариев",
+                    "type": "integer"
+                },
+                "comments": {
+                    "description": "Комментарии, если они запрошены вместе с постом",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Comment"
+                    }
+                },
                 "content": {
                     "description": "Текст поста",
                     "type": "string"
                 },
+                "content_html": {
+                    "description": "Текст, отрендеренный из Markdown в безопасный HTML",
+                    "type": "string"
+                },
+                "downvotes": {
+                    "description": "Количество голосов против",
+                    "type": "integer"
+                },
                 "id": {
                     "description": "ID поста",
                     "type": "integer"
                 },
+                "last_activity_at": {
+                    "description": "Время создания поста или последнего комментария к нему",
+                    "type": "string"
+                },
+                "mentions": {
+                    "description": "Упоминания пользователей в тексте",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MentionRange"
+                    }
+                },
+                "my_vote": {
+                    "description": "Голос текущего пользователя, если он авторизован",
+                    "type": "integer"
+                },
+                "reactions": {
+                    "description": "Реакции",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReactionCount"
+                    }
+                },
+                "score": {
+                    "description": "Рейтинг: разница голосов за и против",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Теги поста",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "timestamp": {
                     "description": "Время создания поста",
                     "type": "string"
@@ -665,6 +2248,10 @@ const docTemplate = `{
                     "description": "ID темы, к которой относится пост",
                     "type": "integer"
                 },
+                "upvotes": {
+                    "description": "Количество голосов за",
+                    "type": "integer"
+                },
                 "user_id": {
                     "description": "ID пользователя, создавшего пост",
                     "type": "integer"
@@ -675,10 +2262,132 @@ const docTemplate = `{
                 }
             }
         },
+        "model.PostUpdateRequest": {
+            "description": "Отсутствующие поля не изменяются; переданный tags заменяет теги поста целиком, пустой список удаляет их",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Новый текст",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID поста (в /api/v1 берется из пути)",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Новый список тегов",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "description": "Новый заголовок",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReactionCount": {
+            "description": "Количество реакций с одним эмодзи и отметка, поставил ли ее текущий пользователь",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Количество реакций",
+                    "type": "integer"
+                },
+                "emoji": {
+                    "description": "Эмодзи реакции",
+                    "type": "string"
+                },
+                "reacted": {
+                    "description": "Поставил ли реакцию текущий пользователь",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.ReactionRequest": {
+            "description": "Повторная такая же реакция от того же пользователя снимает ее",
+            "type": "object",
+            "properties": {
+                "emoji": {
+                    "description": "Эмодзи реакции",
+                    "type": "string"
+                },
+                "target_id": {
+                    "description": "ID поста или комментария",
+                    "type": "integer"
+                },
+                "target_type": {
+                    "description": "Тип объекта: post или comment",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Tag": {
+            "description": "Тег и количество постов, отмеченных им",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID тега",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Нормализованное имя тега",
+                    "type": "string"
+                },
+                "post_count": {
+                    "description": "Количество постов с тегом",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TagMergeRequest": {
+            "description": "Посты с тегом source_id получают тег target_id, после чего тег source_id удаляется",
+            "type": "object",
+            "properties": {
+                "source_id": {
+                    "description": "ID тега, который поглощается",
+                    "type": "integer"
+                },
+                "target_id": {
+                    "description": "ID тега, который остается",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TagRenameRequest": {
+            "description": "Новое имя нормализуется так же, как теги постов",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID тега (в /api/v1 берется из пути)",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Новое имя",
+                    "type": "string"
+                }
+            }
+        },
         "model.Topic": {
-            "description": "Структура темы с необходимыми полями для хранения данных о теме",
+            "description": "Структура темы с необходимыми полями для хранения данных о теме. Темы образуют дерево: у подфорума указан parent_id",
             "type": "object",
             "properties": {
+                "archived": {
+                    "description": "Тема в архиве: доступна только для чтения и скрыта из списка по умолчанию",
+                    "type": "boolean"
+                },
+                "children": {
+                    "description": "Вложенные темы (только в дереве тем)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Topic"
+                    }
+                },
+                "comment_count": {
+                    "description": "Количество комментариев к постам темы",
+                    "type": "integer"
+                },
                 "created_at": {
                     "description": "Дата и время создания темы",
                     "type": "string"
@@ -687,6 +2396,84 @@ const docTemplate = `{
                     "description": "Описание темы",
                     "type": "string"
                 },
+                "id": {
+                    "description": "ID темы",
+                    "type": "integer"
+                },
+                "last_activity_at": {
+                    "description": "Время последнего поста или комментария",
+                    "type": "string"
+                },
+                "last_post": {
+                    "description": "Последний пост темы",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.TopicLastPost"
+                        }
+                    ]
+                },
+                "locked": {
+                    "description": "Тема закрыта: новые посты запрещены",
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "description": "ID родительской темы; отсутствует у тем верхнего уровня",
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "Порядок отображения среди тем одного уровня",
+                    "type": "integer"
+                },
+                "post_count": {
+                    "description": "Количество постов в теме (без подтем)",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "Заголовок темы",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TopicLastPost": {
+            "description": "ID, заголовок, автор и время создания поста",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID поста",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Дата и время создания поста",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Заголовок поста",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Имя автора",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TopicMoveRequest": {
+            "description": "Без parent_id тема переносится на верхний уровень",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID переносимой темы (в /api/v1 берется из пути)",
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "description": "ID новой родительской темы",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TopicRef": {
+            "description": "ID и заголовок темы",
+            "type": "object",
+            "properties": {
                 "id": {
                     "description": "ID темы",
                     "type": "integer"
@@ -696,6 +2483,134 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "model.TopicStateRequest": {
+            "description": "Отсутствующие поля не изменяются",
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "Поместить тему в архив или вернуть из архива",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "ID темы (в /api/v1 берется из пути)",
+                    "type": "integer"
+                },
+                "locked": {
+                    "description": "Закрыть тему для новых постов или открыть ее",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.TopicUpdateRequest": {
+            "description": "Отсутствующие поля не изменяются",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Новое описание",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID темы (в /api/v1 берется из пути)",
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "Новый порядок отображения среди тем одного уровня",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "Новый заголовок",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TrashItem": {
+            "description": "Тема, пост или комментарий, удаленные, но еще не стертые окончательно",
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "Время удаления",
+                    "type": "string"
+                },
+                "deleted_by": {
+                    "description": "ID удалившего пользователя",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID объекта",
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "description": "ID темы поста или поста комментария; у темы — ID родительской темы",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "Заголовок темы или поста, начало текста комментария",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Тип объекта: topic, post или comment",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "Автор поста или комментария",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TrashRestoreRequest": {
+            "description": "Вместе с объектом восстанавливаются вложенные объекты, удаленные вместе с ним",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID объекта",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Тип объекта: topic, post или comment",
+                    "type": "string"
+                }
+            }
+        },
+        "model.VoteRequest": {
+            "description": "Голос +1 или -1. Повторный такой же голос отменяет его, противоположный — заменяет",
+            "type": "object",
+            "properties": {
+                "target_id": {
+                    "description": "ID поста или комментария",
+                    "type": "integer"
+                },
+                "target_type": {
+                    "description": "Тип объекта: post или comment",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "1 — за, -1 — против",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.VoteResult": {
+            "description": "Итоговые счетчики голосов и голос текущего пользователя (0, если голос отменен)",
+            "type": "object",
+            "properties": {
+                "downvotes": {
+                    "description": "Количество голосов против",
+                    "type": "integer"
+                },
+                "my_vote": {
+                    "description": "Голос текущего пользователя",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "Рейтинг: разница голосов за и против",
+                    "type": "integer"
+                },
+                "upvotes": {
+                    "description": "Количество голосов за",
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -704,9 +2619,9 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
-	BasePath:         "/",
+	BasePath:         "/api/v1",
 	Schemes:          []string{"http"},
-	Title:            "Golang Форум API",
+	Title:            "API сервиса форума",
 	Description:      "",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
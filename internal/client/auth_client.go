@@ -3,57 +3,122 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/snailrake/sstu-auth-proto/proto/auth"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golangforum/internal/metrics"
+	"golangforum/internal/middleware"
+	"golangforum/internal/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type AuthClient struct {
-	client auth.AuthServiceClient
+	client  auth.AuthServiceClient
+	conn    *grpc.ClientConn
+	timeout time.Duration
+	metrics *metrics.Metrics
 }
 
-func NewClient(addr string) (*AuthClient, error) {
+// RoleAdmin — роль администратора форума в claim role токена
+const RoleAdmin = "admin"
+
+// User описывает пользователя, данные которого извлечены из токена
+type User struct {
+	ID       int
+	Username string
+	Role     string
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// NewClient подключается к сервису авторизации. dialTimeout ограничивает установку соединения,
+// timeout — ожидание ответа на проверку токена. Время и исход проверок учитываются в m
+func NewClient(addr string, dialTimeout, timeout time.Duration, m *metrics.Metrics) (*AuthClient, error) {
 	dialer := func(ctx context.Context, address string) (net.Conn, error) {
-		return (&net.Dialer{Timeout: 5 * time.Second}).DialContext(ctx, "tcp", address)
+		return (&net.Dialer{Timeout: dialTimeout}).DialContext(ctx, "tcp", address)
 	}
-	conn, err := grpc.NewClient(addr,
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithContextDialer(dialer),
-		grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: 5 * time.Second}),
-	)
+		grpc.WithConnectParams(grpc.ConnectParams{MinConnectTimeout: dialTimeout}),
+	}
+	if tracing.Enabled() {
+		// span для каждого вызова и передача контекста трассировки сервису авторизации
+		opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+	}
+	conn, err := grpc.NewClient(addr, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return &AuthClient{client: auth.NewAuthServiceClient(conn)}, nil
+	return &AuthClient{client: auth.NewAuthServiceClient(conn), conn: conn, timeout: timeout, metrics: m}, nil
+}
+
+// Ping проверяет, что сервис авторизации доступен: ждет, пока соединение с ним перейдет в состояние Ready,
+// но не дольше, чем живет ctx
+func (a *AuthClient) Ping(ctx context.Context) error {
+	a.conn.Connect()
+	for {
+		state := a.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Shutdown:
+			return errors.New("auth client is closed")
+		}
+		if !a.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("auth service unreachable (connection %s): %w", strings.ToLower(state.String()), ctx.Err())
+		}
+	}
 }
 
-func (a *AuthClient) VerifyToken(token string) (map[string]interface{}, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+// VerifyToken проверяет токен в сервисе авторизации; ожидание ответа ограничено и отменяется вместе с ctx
+func (a *AuthClient) VerifyToken(ctx context.Context, token string) (map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
 	defer cancel()
+	start := time.Now()
 	resp, err := a.client.VerifyToken(ctx, &auth.VerifyTokenRequest{Token: token})
+	a.metrics.ObserveAuth(time.Since(start), err)
 	if err != nil {
 		return nil, err
 	}
 	return resp.Claims.AsMap(), nil
 }
 
-func (a *AuthClient) GetUsername(r *http.Request) (string, error) {
-	parts := strings.Fields(r.Header.Get("Authorization"))
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return "", errors.New("invalid auth header")
+func (a *AuthClient) GetUser(r *http.Request) (*User, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
 	}
-	claims, err := a.VerifyToken(parts[1])
+	claims, err := a.VerifyToken(r.Context(), token)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	id, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, errors.New("user_id not found")
 	}
 	name, ok := claims["username"].(string)
 	if !ok {
-		return "", errors.New("username not found")
+		return nil, errors.New("username not found")
+	}
+	role, _ := claims["role"].(string)
+	middleware.SetUserID(r.Context(), int(id))
+	return &User{ID: int(id), Username: name, Role: role}, nil
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	parts := strings.Fields(r.Header.Get("Authorization"))
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", errors.New("invalid auth header")
 	}
-	return name, nil
+	return parts[1], nil
 }
@@ -0,0 +1,58 @@
+// Package clienttest поднимает в тестах сервис авторизации, который принимает заранее известные токены
+package clienttest
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/snailrake/sstu-auth-proto/proto/auth"
+	"golangforum/internal/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// authServer выдает claims пользователя по его токену; неизвестный токен отклоняется
+type authServer struct {
+	auth.UnimplementedAuthServiceServer
+	users map[string]client.User
+}
+
+func (s *authServer) VerifyToken(_ context.Context, req *auth.VerifyTokenRequest) (*auth.VerifyTokenResponse, error) {
+	u, ok := s.users[req.GetToken()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	claims, err := structpb.NewStruct(map[string]any{
+		"user_id":  u.ID,
+		"username": u.Username,
+		"role":     u.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &auth.VerifyTokenResponse{Claims: claims}, nil
+}
+
+// NewAuthClient запускает на локальном порту сервис авторизации, который знает токены users, и возвращает
+// подключенный к нему клиент. Сервис останавливается по завершении теста
+func NewAuthClient(t testing.TB, users map[string]client.User) *client.AuthClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	auth.RegisterAuthServiceServer(srv, &authServer{users: users})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	c, err := client.NewClient(lis.Addr().String(), time.Second, 5*time.Second, nil)
+	if err != nil {
+		t.Fatalf("auth client: %v", err)
+	}
+	return c
+}
@@ -0,0 +1,182 @@
+// Package config собирает настройки сервиса из нескольких источников. Каждый следующий источник
+// переопределяет предыдущий: значения по умолчанию, YAML-файл (флаг -config или CONFIG_FILE),
+// переменные окружения (в том числе из необязательного файла .env) и флаги командной строки
+package config
+
+import (
+	"time"
+
+	"golangforum/internal/model"
+)
+
+// Config — настройки сервиса форума. Тег yaml задает ключ в файле и имя флага (точки и подчеркивания
+// заменяются дефисами: http.read_timeout -> -http-read-timeout), тег env — переменную окружения
+type Config struct {
+	HTTP        HTTP        `yaml:"http"`
+	Database    Database    `yaml:"database"`
+	Auth        Auth        `yaml:"auth"`
+	CORS        CORS        `yaml:"cors"`
+	Security    Security    `yaml:"security"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Health      Health      `yaml:"health"`
+	Chat        Chat        `yaml:"chat"`
+	Attachments Attachments `yaml:"attachments"`
+	Trash       Trash       `yaml:"trash"`
+	Limits      Limits      `yaml:"limits"`
+}
+
+type HTTP struct {
+	Addr              string        `yaml:"addr" env:"HTTP_ADDR" usage:"адрес, на котором слушает HTTP-сервер"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" usage:"время на чтение заголовков запроса"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" usage:"время на чтение всего запроса вместе с телом"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" usage:"время на отправку ответа"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" usage:"сколько держать открытым простаивающее keep-alive соединение"`
+	RequestTimeout    time.Duration `yaml:"request_timeout" env:"REQUEST_TIMEOUT" usage:"время на обработку запроса, включая запросы к базе"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" usage:"сколько ждать завершения запросов при остановке"`
+	DrainDelay        time.Duration `yaml:"drain_delay" env:"HTTP_DRAIN_DELAY" usage:"сколько после сигнала остановки отвечать 503 на /readyz, прежде чем перестать принимать запросы"`
+	TLSCertFile       string        `yaml:"tls_cert_file" env:"HTTP_TLS_CERT_FILE" usage:"сертификат TLS; вместе с ключом включает HTTPS"`
+	TLSKeyFile        string        `yaml:"tls_key_file" env:"HTTP_TLS_KEY_FILE" usage:"закрытый ключ TLS"`
+}
+
+type Database struct {
+	URL                string        `yaml:"url" env:"DATABASE_URL" usage:"строка подключения к PostgreSQL"`
+	MaxOpenConns       int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" usage:"максимум открытых соединений; 0 — без ограничения"`
+	MaxIdleConns       int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" usage:"максимум простаивающих соединений в пуле"`
+	ConnMaxLifetime    time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" usage:"время жизни соединения; 0 — без ограничения"`
+	ConnMaxIdleTime    time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" usage:"сколько соединение может простаивать; 0 — без ограничения"`
+	AutoMigrate        bool          `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE" usage:"применять встроенные миграции при запуске сервера"`
+	MigrateLockTimeout time.Duration `yaml:"migrate_lock_timeout" env:"DB_MIGRATE_LOCK_TIMEOUT" usage:"сколько ждать блокировку миграций, пока их применяет другой экземпляр"`
+}
+
+type Auth struct {
+	Addr        string        `yaml:"addr" env:"AUTH_SERVICE_ADDR" usage:"адрес gRPC-сервиса авторизации"`
+	Timeout     time.Duration `yaml:"timeout" env:"AUTH_TIMEOUT" usage:"время на проверку токена"`
+	DialTimeout time.Duration `yaml:"dial_timeout" env:"AUTH_DIAL_TIMEOUT" usage:"время на установку соединения с сервисом авторизации"`
+}
+
+type CORS struct {
+	AllowedOrigins []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" usage:"источники через запятую, которым разрешены запросы из браузера и чат; * — любой, пусто — только свой"`
+	MaxAge         time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" usage:"сколько браузер может кешировать ответ на предварительный запрос"`
+}
+
+type Security struct {
+	ContentSecurityPolicy string        `yaml:"content_security_policy" env:"SECURITY_CSP" usage:"Content-Security-Policy для ответов API"`
+	SwaggerPolicy         string        `yaml:"swagger_policy" env:"SECURITY_SWAGGER_CSP" usage:"Content-Security-Policy для документации /swagger/"`
+	FrameOptions          string        `yaml:"frame_options" env:"SECURITY_FRAME_OPTIONS" usage:"X-Frame-Options: DENY, SAMEORIGIN или пусто"`
+	HSTSMaxAge            time.Duration `yaml:"hsts_max_age" env:"SECURITY_HSTS_MAX_AGE" usage:"срок Strict-Transport-Security для HTTPS; 0 — не отправлять"`
+}
+
+type Tracing struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" usage:"куда отправлять трассировки: none, otlp или stdout"`
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_OTLP_ENDPOINT" usage:"адрес OTLP/gRPC-коллектора"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_OTLP_INSECURE" usage:"подключаться к коллектору без TLS"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" usage:"доля трассируемых запросов от 0 до 1"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" usage:"имя сервиса в трассировках"`
+}
+
+type Health struct {
+	CacheTTL     time.Duration `yaml:"cache_ttl" env:"HEALTH_CACHE_TTL" usage:"сколько /readyz отдает сохраненный результат проверок"`
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" usage:"время на проверку одной зависимости"`
+}
+
+type Chat struct {
+	MessageRetention  time.Duration `yaml:"message_retention" env:"CHAT_MESSAGE_RETENTION_PERIOD" usage:"сколько хранить сообщения чата"`
+	MaxMessageLength  int           `yaml:"max_message_length" env:"MAX_MESSAGE_LENGTH" usage:"максимальная длина сообщения в символах"`
+	MaxUsernameLength int           `yaml:"max_username_length" env:"MAX_USERNAME_LENGTH" usage:"максимальная длина имени пользователя в символах"`
+}
+
+type Attachments struct {
+	Dir        string        `yaml:"dir" env:"ATTACHMENTS_DIR" usage:"каталог для загруженных файлов"`
+	MaxSize    int64         `yaml:"max_size" env:"ATTACHMENT_MAX_SIZE" usage:"максимальный размер файла в байтах"`
+	GCInterval time.Duration `yaml:"gc_interval" env:"ATTACHMENT_GC_INTERVAL" usage:"как часто удалять неприкрепленные файлы"`
+	OrphanTTL  time.Duration `yaml:"orphan_ttl" env:"ATTACHMENT_ORPHAN_TTL" usage:"через сколько неприкрепленный файл удаляется"`
+}
+
+type Trash struct {
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL" usage:"как часто очищать корзину"`
+	Retention     time.Duration `yaml:"retention" env:"TRASH_RETENTION" usage:"сколько хранить удаленное в корзине"`
+}
+
+type Limits struct {
+	Title       int `yaml:"title" env:"MAX_TITLE_LENGTH" usage:"максимальная длина заголовка в символах"`
+	Description int `yaml:"description" env:"MAX_DESCRIPTION_LENGTH" usage:"максимальная длина описания темы в символах"`
+	Post        int `yaml:"post" env:"MAX_POST_LENGTH" usage:"максимальная длина поста в символах"`
+	Comment     int `yaml:"comment" env:"MAX_COMMENT_LENGTH" usage:"максимальная длина комментария в символах"`
+}
+
+// Default возвращает настройки, которые действуют, если ни один источник их не переопределил
+func Default() Config {
+	return Config{
+		HTTP: HTTP{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			RequestTimeout:    10 * time.Second,
+			ShutdownTimeout:   15 * time.Second,
+		},
+		Database: Database{
+			MaxOpenConns:       25,
+			MaxIdleConns:       25,
+			ConnMaxLifetime:    30 * time.Minute,
+			ConnMaxIdleTime:    5 * time.Minute,
+			MigrateLockTimeout: time.Minute,
+		},
+		Auth: Auth{
+			Addr:        "localhost:50051",
+			Timeout:     3 * time.Second,
+			DialTimeout: 5 * time.Second,
+		},
+		CORS: CORS{MaxAge: 10 * time.Minute},
+		Security: Security{
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			SwaggerPolicy:         "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'",
+			FrameOptions:          "DENY",
+			HSTSMaxAge:            180 * 24 * time.Hour,
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			Endpoint:    "localhost:4317",
+			SampleRatio: 1,
+			ServiceName: "golangforum",
+		},
+		Health: Health{
+			CacheTTL:     2 * time.Second,
+			CheckTimeout: 2 * time.Second,
+		},
+		Chat: Chat{
+			MessageRetention:  24 * time.Hour,
+			MaxMessageLength:  model.DefaultLimits.Message,
+			MaxUsernameLength: model.DefaultLimits.Username,
+		},
+		Attachments: Attachments{
+			Dir:        "data/attachments",
+			MaxSize:    10 << 20,
+			GCInterval: time.Hour,
+			OrphanTTL:  24 * time.Hour,
+		},
+		Trash: Trash{
+			PurgeInterval: time.Hour,
+			Retention:     30 * 24 * time.Hour,
+		},
+		Limits: Limits{
+			Title:       model.DefaultLimits.Title,
+			Description: model.DefaultLimits.Description,
+			Post:        model.DefaultLimits.Post,
+			Comment:     model.DefaultLimits.Comment,
+		},
+	}
+}
+
+// ModelLimits возвращает ограничения длины текстовых полей для model.SetLimits
+func (c *Config) ModelLimits() model.Limits {
+	return model.Limits{
+		Title:       c.Limits.Title,
+		Description: c.Limits.Description,
+		Post:        c.Limits.Post,
+		Comment:     c.Limits.Comment,
+		Message:     c.Chat.MaxMessageLength,
+		Username:    c.Chat.MaxUsernameLength,
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/forum")
+
+	cfg, err := Load(nil)
+
+	require.NoError(t, err)
+	want := Default()
+	want.Database.URL = "postgres://localhost/forum"
+	assert.Equal(t, &want, cfg)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, `
+http:
+  addr: ":9000"
+  request_timeout: 5s
+database:
+  url: postgres://file/forum
+  max_open_conns: 10
+  max_idle_conns: 5
+cors:
+  allowed_origins: ["https://file.example"]
+`)
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("HTTP_ADDR", ":9001")
+	t.Setenv("DB_MAX_OPEN_CONNS", "20")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://a.example, https://b.example")
+
+	cfg, err := Load([]string{"-http-addr", ":9002"})
+
+	require.NoError(t, err)
+	assert.Equal(t, ":9002", cfg.HTTP.Addr)                    // флаг важнее окружения
+	assert.Equal(t, 20, cfg.Database.MaxOpenConns)             // окружение важнее файла
+	assert.Equal(t, 5*time.Second, cfg.HTTP.RequestTimeout)    // файл важнее значений по умолчанию
+	assert.Equal(t, "postgres://file/forum", cfg.Database.URL) // из файла
+	assert.Equal(t, 30*time.Second, cfg.HTTP.WriteTimeout)     // по умолчанию
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, cfg.CORS.AllowedOrigins)
+}
+
+func TestLoad_ConfigFlagOverridesEnv(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+	path := writeFile(t, "database:\n  url: postgres://flag/forum\n")
+
+	cfg, err := Load([]string{"-config", path})
+
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://flag/forum", cfg.Database.URL)
+}
+
+func TestLoad_UnknownFileKey(t *testing.T) {
+	path := writeFile(t, "database:\n  url: postgres://localhost/forum\n  pool: 5\n")
+
+	_, err := Load([]string{"-config", path})
+
+	assert.ErrorContains(t, err, "pool")
+}
+
+func TestLoad_InvalidValues(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/forum")
+	t.Setenv("REQUEST_TIMEOUT", "10")
+	t.Setenv("DB_MAX_OPEN_CONNS", "many")
+
+	_, err := Load([]string{"-trash-retention", "week"})
+
+	var cerr *Error
+	require.True(t, errors.As(err, &cerr))
+	assert.Equal(t, []string{
+		`http.request_timeout (REQUEST_TIMEOUT="10"): invalid duration, expected a value like 30s or 5m`,
+		`database.max_open_conns (DB_MAX_OPEN_CONNS="many"): invalid integer`,
+		`trash.retention (-trash-retention="week"): invalid duration, expected a value like 30s or 5m`,
+	}, cerr.Problems)
+}
+
+func TestLoad_Help(t *testing.T) {
+	_, err := Load([]string{"-h"})
+
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
+
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	cfg := Default()
+	cfg.HTTP.RequestTimeout = time.Minute
+	cfg.Database.MaxIdleConns = 50
+	cfg.CORS.AllowedOrigins = []string{"https://ok.example", "example.com", "https://x.example/path"}
+	cfg.Limits.Post = 0
+
+	var cerr *Error
+	require.True(t, errors.As(cfg.Validate(), &cerr))
+	assert.Equal(t, []string{
+		"http.request_timeout: must be shorter than http.write_timeout, otherwise the response cannot be sent",
+		"database.url: is required",
+		"database.max_idle_conns: must not exceed database.max_open_conns",
+		`cors.allowed_origins: "example.com" is not an origin like https://example.com`,
+		`cors.allowed_origins: "https://x.example/path" is not an origin like https://example.com`,
+		"limits.post: must be positive",
+	}, cerr.Problems)
+	assert.Contains(t, cerr.Error(), "invalid configuration:\n  - http.request_timeout")
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Load собирает настройки из всех источников и проверяет их. args — аргументы командной строки без имени
+// программы; с -h возвращается flag.ErrHelp. Все найденные ошибки возвращаются вместе одной *Error
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+	opts := options(&cfg)
+
+	fset := flag.NewFlagSet("forum", flag.ContinueOnError)
+	path := fset.String("config", "", "путь к YAML-файлу конфигурации (по умолчанию CONFIG_FILE)")
+	// значения флагов применяются после окружения, поэтому при разборе они только запоминаются
+	type flagValue struct {
+		opt   option
+		value string
+	}
+	var flags []flagValue
+	for _, o := range opts {
+		fset.Func(o.flagName(), o.usage+" ("+o.env+")", func(v string) error {
+			flags = append(flags, flagValue{o, v})
+			return nil
+		})
+	}
+	if err := fset.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+	if *path == "" {
+		*path = os.Getenv("CONFIG_FILE")
+	}
+	if *path != "" {
+		if err := loadFile(*path, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	var problems []string
+	for _, o := range opts {
+		if v, ok := os.LookupEnv(o.env); ok {
+			if err := o.set(v); err != nil {
+				problems = append(problems, fmt.Sprintf("%s (%s=%q): %v", o.key, o.env, v, err))
+			}
+		}
+	}
+	for _, f := range flags {
+		if err := f.opt.set(f.value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (-%s=%q): %v", f.opt.key, f.opt.flagName(), f.value, err))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &Error{Problems: problems}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func loadFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open config file: %w", err)
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// option — одна настройка: ключ в файле, переменная окружения и поле, куда записывается значение
+type option struct {
+	key   string
+	env   string
+	usage string
+	value reflect.Value
+}
+
+func (o option) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(o.key)
+}
+
+// options перечисляет настройки cfg, обходя вложенные структуры; настройкой считается поле с тегом env
+func options(cfg *Config) []option {
+	var res []option
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key := prefix + f.Tag.Get("yaml")
+			if env := f.Tag.Get("env"); env != "" {
+				res = append(res, option{key: key, env: env, usage: f.Tag.Get("usage"), value: v.Field(i)})
+				continue
+			}
+			if f.Type.Kind() == reflect.Struct {
+				walk(v.Field(i), key+".")
+			}
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return res
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set разбирает строковое значение из окружения или флага по типу поля. Списки задаются через запятую
+func (o option) set(s string) error {
+	s = strings.TrimSpace(s)
+	switch {
+	case o.value.Type() == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return errors.New("invalid duration, expected a value like 30s or 5m")
+		}
+		o.value.SetInt(int64(d))
+	case o.value.Kind() == reflect.String:
+		o.value.SetString(s)
+	case o.value.Kind() == reflect.Int, o.value.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return errors.New("invalid integer")
+		}
+		o.value.SetInt(n)
+	case o.value.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.New("invalid number")
+		}
+		o.value.SetFloat(f)
+	case o.value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.New("invalid boolean, expected true or false")
+		}
+		o.value.SetBool(b)
+	case o.value.Kind() == reflect.Slice && o.value.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		o.value.Set(reflect.ValueOf(items))
+	default:
+		panic("config: unsupported type " + o.value.Type().String() + " of " + o.key)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Error перечисляет все ошибки конфигурации, чтобы их можно было исправить за один раз
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate проверяет настройки и возвращает *Error со всеми найденными ошибками
+func (c *Config) Validate() error {
+	var p problems
+
+	p.required("http.addr", c.HTTP.Addr)
+	p.positive("http.read_header_timeout", c.HTTP.ReadHeaderTimeout)
+	p.positive("http.read_timeout", c.HTTP.ReadTimeout)
+	p.positive("http.write_timeout", c.HTTP.WriteTimeout)
+	p.positive("http.idle_timeout", c.HTTP.IdleTimeout)
+	p.positive("http.request_timeout", c.HTTP.RequestTimeout)
+	p.positive("http.shutdown_timeout", c.HTTP.ShutdownTimeout)
+	p.nonNegative("http.drain_delay", int(c.HTTP.DrainDelay))
+	if c.HTTP.RequestTimeout > 0 && c.HTTP.WriteTimeout > 0 && c.HTTP.RequestTimeout >= c.HTTP.WriteTimeout {
+		p.add("http.request_timeout", "must be shorter than http.write_timeout, otherwise the response cannot be sent")
+	}
+	if (c.HTTP.TLSCertFile == "") != (c.HTTP.TLSKeyFile == "") {
+		p.add("http.tls_cert_file", "must be set together with http.tls_key_file")
+	}
+
+	p.required("database.url", c.Database.URL)
+	p.nonNegative("database.max_open_conns", c.Database.MaxOpenConns)
+	p.nonNegative("database.max_idle_conns", c.Database.MaxIdleConns)
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		p.add("database.max_idle_conns", "must not exceed database.max_open_conns")
+	}
+	p.nonNegative("database.conn_max_lifetime", int(c.Database.ConnMaxLifetime))
+	p.nonNegative("database.conn_max_idle_time", int(c.Database.ConnMaxIdleTime))
+	p.positive("database.migrate_lock_timeout", c.Database.MigrateLockTimeout)
+
+	p.required("auth.addr", c.Auth.Addr)
+	p.positive("auth.timeout", c.Auth.Timeout)
+	p.positive("auth.dial_timeout", c.Auth.DialTimeout)
+
+	for _, o := range c.CORS.AllowedOrigins {
+		if o != "*" && !validOrigin(o) {
+			p.add("cors.allowed_origins", fmt.Sprintf("%q is not an origin like https://example.com", o))
+		}
+	}
+	p.nonNegative("cors.max_age", int(c.CORS.MaxAge))
+
+	switch c.Security.FrameOptions {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		p.add("security.frame_options", "must be DENY, SAMEORIGIN or empty")
+	}
+	p.nonNegative("security.hsts_max_age", int(c.Security.HSTSMaxAge))
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		p.required("tracing.endpoint", c.Tracing.Endpoint)
+	default:
+		p.add("tracing.exporter", "must be none, otlp or stdout")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		p.add("tracing.sample_ratio", "must be between 0 and 1")
+	}
+	p.required("tracing.service_name", c.Tracing.ServiceName)
+
+	p.nonNegative("health.cache_ttl", int(c.Health.CacheTTL))
+	p.positive("health.check_timeout", c.Health.CheckTimeout)
+
+	p.positive("chat.message_retention", c.Chat.MessageRetention)
+	p.positiveInt("chat.max_message_length", c.Chat.MaxMessageLength)
+	p.positiveInt("chat.max_username_length", c.Chat.MaxUsernameLength)
+
+	p.required("attachments.dir", c.Attachments.Dir)
+	p.positiveInt("attachments.max_size", int(c.Attachments.MaxSize))
+	p.positive("attachments.gc_interval", c.Attachments.GCInterval)
+	p.positive("attachments.orphan_ttl", c.Attachments.OrphanTTL)
+
+	p.positive("trash.purge_interval", c.Trash.PurgeInterval)
+	p.positive("trash.retention", c.Trash.Retention)
+
+	p.positiveInt("limits.title", c.Limits.Title)
+	p.positiveInt("limits.description", c.Limits.Description)
+	p.positiveInt("limits.post", c.Limits.Post)
+	p.positiveInt("limits.comment", c.Limits.Comment)
+
+	if len(p) > 0 {
+		return &Error{Problems: p}
+	}
+	return nil
+}
+
+// validOrigin проверяет, что строка — источник: схема http или https и хост без пути
+func validOrigin(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.Path == "" && u.RawQuery == "" && u.Fragment == ""
+}
+
+type problems []string
+
+func (p *problems) add(key, message string) {
+	*p = append(*p, key+": "+message)
+}
+
+func (p *problems) required(key, v string) {
+	if strings.TrimSpace(v) == "" {
+		p.add(key, "is required")
+	}
+}
+
+func (p *problems) positive(key string, d time.Duration) {
+	if d <= 0 {
+		p.add(key, "must be a positive duration")
+	}
+}
+
+func (p *problems) positiveInt(key string, n int) {
+	if n <= 0 {
+		p.add(key, "must be positive")
+	}
+}
+
+func (p *problems) nonNegative(key string, n int) {
+	if n < 0 {
+		p.add(key, "must not be negative")
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golangforum/internal/client"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+)
+
+// multipartOverhead — запас на заголовки и границы multipart-запроса сверх максимального размера файла
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	UseCase    usecase.AttachmentUseCase
+	AuthClient *client.AuthClient
+	MaxSize    int64
+}
+
+func NewAttachmentHandler(uc usecase.AttachmentUseCase, authClient *client.AuthClient, maxSize int64) *AttachmentHandler {
+	return &AttachmentHandler{UseCase: uc, AuthClient: authClient, MaxSize: maxSize}
+}
+
+// Upload godoc
+// @Summary Загрузить файл
+// @Description Загружает файл для последующего прикрепления к посту или комментарию через attachment_ids. Тип файла определяется по содержимому; для изображений создается миниатюра. Непривязанные файлы удаляются по истечении срока хранения
+// @Tags Вложения
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Файл"
+// @Success 201 {object} model.Attachment "Файл загружен"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 413 {object} model.ErrorResponse "Файл слишком большой"
+// @Failure 415 {object} model.ErrorResponse "Недопустимый тип файла"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /attachments [post]
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	user, err := h.AuthClient.GetUser(r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.MaxSize+multipartOverhead)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		writeBadRequest(w, r, "multipart form expected", nil)
+		return
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			writeBadRequest(w, r, "file field is missing", model.InvalidField("file", "is required"))
+			return
+		}
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				writeUseCaseError(w, r, usecase.ErrAttachmentTooLarge)
+			} else {
+				writeBadRequest(w, r, "invalid multipart form", nil)
+			}
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+		a, err := h.UseCase.Upload(r.Context(), user.ID, user.Username, part.FileName(), part)
+		part.Close()
+		if err != nil {
+			writeUploadError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(a)
+		return
+	}
+}
+
+// Download godoc
+// @Summary Скачать файл
+// @Description Отдает содержимое вложения или его миниатюры. Поддерживаются запросы диапазонов (заголовок Range)
+// @Tags Вложения
+// @Produce octet-stream
+// @Param id path int true "ID вложения"
+// @Param thumbnail query bool false "Отдать миниатюру вместо исходного файла"
+// @Success 200 {file} file "Содержимое файла"
+// @Success 206 {file} file "Часть содержимого файла"
+// @Failure 400 {object} model.ErrorResponse "Неверный id"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 404 {object} model.ErrorResponse "Вложение не найдено"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /attachments/{id} [get]
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.AuthClient.GetUser(r); err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+	thumb, _ := strconv.ParseBool(r.URL.Query().Get("thumbnail"))
+	a, content, err := h.UseCase.Open(r.Context(), id, thumb)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	disposition := "attachment"
+	if strings.HasPrefix(a.ContentType, "image/") {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": a.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	http.ServeContent(w, r, a.Filename, a.Timestamp, content)
+}
+
+// writeUploadError дополняет таблицу apiErrors превышением лимита размера тела запроса
+func writeUploadError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = usecase.ErrAttachmentTooLarge
+	}
+	writeUseCaseError(w, r, err)
+}
@@ -1,30 +1,47 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
 	"golangforum/internal/client"
+	"golangforum/internal/metrics"
+	"golangforum/internal/middleware"
 	"golangforum/internal/usecase"
 	"net/http"
+	"sync"
+	"time"
 )
 
+// closeFrameTimeout — сколько ждать отправки кадра закрытия при остановке сервера
+const closeFrameTimeout = time.Second
+
 type ChatHandler struct {
 	UC      usecase.ChatUseCase
 	AC      *client.AuthClient
 	clients map[*websocket.Conn]struct{}
+	// upgrader пускает в чат только источники, которым разрешен и API
+	upgrader websocket.Upgrader
+	metrics  *metrics.Metrics
+
+	shutdown  chan struct{}
+	closeOnce sync.Once
+	conns     sync.WaitGroup
 }
 
-func NewChatHandler(uc usecase.ChatUseCase, ac *client.AuthClient) *ChatHandler {
+func NewChatHandler(uc usecase.ChatUseCase, ac *client.AuthClient, origins *middleware.Origins, m *metrics.Metrics) *ChatHandler {
 	return &ChatHandler{
-		UC:      uc,
-		AC:      ac,
-		clients: make(map[*websocket.Conn]struct{}),
+		UC:       uc,
+		AC:       ac,
+		clients:  make(map[*websocket.Conn]struct{}),
+		upgrader: websocket.Upgrader{CheckOrigin: origins.CheckOrigin},
+		metrics:  m,
+		shutdown: make(chan struct{}),
 	}
 }
 
-var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
-
 // GetAllMessages godoc
 // @Summary Получить все сообщения чата
 // @Description Возвращает список всех сообщений чата
@@ -32,16 +49,12 @@ var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { retu
 // @Accept json
 // @Produce json
 // @Success 200 {array} string "OK"
-// @Failure 500 {object} string "Ошибка сервера"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
 // @Router /chat/messages [get]
 func (h *ChatHandler) GetAllMessages(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-	msgs, err := h.UC.GetAllMessages()
+	msgs, err := h.UC.GetAllMessages(r.Context())
 	if err != nil {
-		http.Error(w, "could not fetch messages", http.StatusInternalServerError)
+		writeUseCaseError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -56,42 +69,76 @@ func (h *ChatHandler) GetAllMessages(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param token query string true "Токен для аутентификации"
 // @Success 101 {object} string "WebSocket соединение установлено"
-// @Failure 400 {object} string "Неверный запрос"
-// @Failure 500 {object} string "Ошибка сервера"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
 // @Router /chat [get]
 func (h *ChatHandler) ServeWS(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-	conn, err := upgrader.Upgrade(w, r, nil)
+	user, id, err := h.getUser(r)
 	if err != nil {
-		http.Error(w, "upgrade failed", http.StatusInternalServerError)
+		writeUseCaseError(w, r, err)
 		return
 	}
 
-	user, id, err := h.getUser(r)
+	// соединение учитывается до перехвата, пока его еще ждет http.Server.Shutdown, — так Wait не пропустит его
+	h.conns.Add(1)
+	defer h.conns.Done()
+
+	// при неудаче upgrader сам отправляет клиенту ответ с ошибкой
+	conn, err := h.upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrMissingToken):
-			http.Error(w, "missing token", http.StatusBadRequest)
-		case errors.Is(err, usecase.ErrInvalidToken),
-			errors.Is(err, usecase.ErrInvalidUserID),
-			errors.Is(err, usecase.ErrInvalidUsername):
-			http.Error(w, err.Error(), http.StatusUnauthorized)
-		default:
-			http.Error(w, "internal error", http.StatusInternalServerError)
-		}
-		conn.Close()
 		return
 	}
 
+	// все записи лога за время сессии, включая итоговую запись о запросе, помечаются ее идентификатором
+	middleware.UpdateLogger(r.Context(), func(c zerolog.Context) zerolog.Context {
+		return c.Str("session_id", uuid.NewString())
+	})
+
+	h.metrics.WebSocketOpened()
 	h.clients[conn] = struct{}{}
+	done := make(chan struct{})
 	defer func() {
+		close(done)
 		delete(h.clients, conn)
 		conn.Close()
+		h.metrics.WebSocketClosed()
+	}()
+	go func() {
+		select {
+		case <-h.shutdown:
+			closeGoingAway(conn)
+		case <-done:
+		}
 	}()
-	h.UC.HandleConnection(conn, user, id, h.clients)
+	h.UC.HandleConnection(r.Context(), conn, user, id, h.clients)
+}
+
+// CloseAll отправляет всем подключенным клиентам кадр закрытия 1001 (going away) и закрывает соединения;
+// новые соединения закрываются сразу после установки. http.Server.Shutdown не отслеживает перехваченные
+// WebSocket-соединения, поэтому метод регистрируется через RegisterOnShutdown
+func (h *ChatHandler) CloseAll() {
+	h.closeOnce.Do(func() { close(h.shutdown) })
+}
+
+// Wait ждет, пока завершится обработка всех WebSocket-соединений, но не дольше, чем живет ctx
+func (h *ChatHandler) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.conns.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func closeGoingAway(conn *websocket.Conn) {
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+	_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeFrameTimeout))
+	conn.Close()
 }
 
 func (h *ChatHandler) getUser(r *http.Request) (string, int, error) {
@@ -99,7 +146,7 @@ func (h *ChatHandler) getUser(r *http.Request) (string, int, error) {
 	if t == "" {
 		return "", 0, usecase.ErrMissingToken
 	}
-	claims, err := h.AC.VerifyToken(t)
+	claims, err := h.AC.VerifyToken(r.Context(), t)
 	if err != nil {
 		return "", 0, usecase.ErrInvalidToken
 	}
@@ -111,5 +158,6 @@ func (h *ChatHandler) getUser(r *http.Request) (string, int, error) {
 	if !ok {
 		return "", 0, usecase.ErrInvalidUsername
 	}
+	middleware.SetUserID(r.Context(), int(uid))
 	return name, int(uid), nil
 }
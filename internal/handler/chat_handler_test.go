@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/client/clienttest"
+	"golangforum/internal/middleware"
+	"golangforum/internal/usecase/mocks"
+)
+
+func TestChatHandler_CloseAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chat := mocks.NewMockChatUseCase(ctrl)
+	connected := make(chan struct{})
+	// сессия читает сообщения, пока соединение не закроют
+	chat.EXPECT().HandleConnection(gomock.Any(), gomock.Any(), "bob", 1, gomock.Any()).Do(
+		func(_ context.Context, conn *websocket.Conn, _ string, _ int, _ map[*websocket.Conn]struct{}) {
+			close(connected)
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		})
+	h := NewChatHandler(chat, clienttest.NewAuthClient(t, testUsers), middleware.NewOrigins(nil), nil)
+	srv := httptest.NewServer(http.HandlerFunc(h.ServeWS))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"?token=user", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	<-connected
+
+	h.CloseAll()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "got %v", err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, h.Wait(ctx))
+}
+
+func TestChatHandler_Wait_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chat := mocks.NewMockChatUseCase(ctrl)
+	release := make(chan struct{})
+	connected := make(chan struct{})
+	// сессия не замечает закрытия соединения, пока ее не отпустят
+	chat.EXPECT().HandleConnection(gomock.Any(), gomock.Any(), "bob", 1, gomock.Any()).Do(
+		func(context.Context, *websocket.Conn, string, int, map[*websocket.Conn]struct{}) {
+			close(connected)
+			<-release
+		})
+	h := NewChatHandler(chat, clienttest.NewAuthClient(t, testUsers), middleware.NewOrigins(nil), nil)
+	srv := httptest.NewServer(http.HandlerFunc(h.ServeWS))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"?token=user", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	<-connected
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, h.Wait(ctx), context.DeadlineExceeded)
+
+	close(release)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, h.Wait(ctx))
+}
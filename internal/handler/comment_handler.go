@@ -2,7 +2,6 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -27,65 +26,95 @@ func NewCommentHandler(uc usecase.CommentUseCase, auth *client.AuthClient) *Comm
 // @Accept json
 // @Produce json
 // @Param comment body model.Comment true "Комментарий"
-// @Success 201 {object} map[string]string "Комментарий создан"
-// @Failure 400 {object} map[string]string "Неверный запрос"
-// @Failure 401 {object} map[string]string "Не авторизован"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /comments/create [post]
+// @Success 201 {object} model.Comment "Созданный комментарий"
+// @Header 201 {string} Location "Адрес созданного комментария"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 404 {object} model.ErrorResponse "Пост не найден"
+// @Failure 409 {object} model.ErrorResponse "Тема в архиве"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /comments [post]
 func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-	user, err := h.auth.GetUsername(r)
+	user, err := h.auth.GetUser(r)
 	if err != nil {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeUnauthorized(w, r)
 		return
 	}
 	var c model.Comment
 	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		writeBadRequest(w, r, "bad request", nil)
 		return
 	}
 	defer r.Body.Close()
+	c.UserID = user.ID
 
-	if err := h.uc.Create(user, &c); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrInvalidCommentData):
-			http.Error(w, "invalid comment", http.StatusBadRequest)
-		default:
-			http.Error(w, "internal error", http.StatusInternalServerError)
-		}
+	if err := h.uc.Create(r.Context(), user.Username, &c); err != nil {
+		writeUseCaseError(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]string{"message": "created"})
+	writeCreated(w, "/api/v1/comments/"+strconv.Itoa(c.ID), c)
+}
+
+// GetByID godoc
+// @Summary Получить комментарий
+// @Description Возвращает комментарий по ID. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя
+// @Tags Комментарии
+// @Accept json
+// @Produce json
+// @Param id path int true "ID комментария"
+// @Success 200 {object} model.Comment "Комментарий"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 404 {object} model.ErrorResponse "Комментарий не найден"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /comments/{id} [get]
+func (h *CommentHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "comment_id")
+	if err != nil {
+		writeBadRequest(w, r, "bad request", nil)
+		return
+	}
+	viewer, err := viewerID(h.auth, r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	c, err := h.uc.GetByID(r.Context(), id, viewer)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
 }
 
 // GetByPost godoc
 // @Summary Получить все комментарии для поста
-// @Description Получает список всех комментариев для заданного поста
+// @Description Получает список всех комментариев для заданного поста. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя
 // @Tags Комментарии
 // @Accept json
 // @Produce json
-// @Param post_id query int true "ID поста"
+// @Param id path int true "ID поста"
+// @Param sort query string false "Сортировка: top — по рейтингу; по умолчанию — в порядке создания"
 // @Success 200 {array} model.Comment "Список комментариев"
-// @Failure 400 {object} map[string]string "Неверный запрос"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /comments [get]
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /posts/{id}/comments [get]
 func (h *CommentHandler) GetByPost(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	id, err := pathID(r, "post_id")
+	if err != nil {
+		writeBadRequest(w, r, "bad request", nil)
 		return
 	}
-	id, err := strconv.Atoi(r.URL.Query().Get("post_id"))
+	viewer, err := viewerID(h.auth, r)
 	if err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		writeUnauthorized(w, r)
 		return
 	}
-	comments, err := h.uc.GetByPost(id)
+	comments, err := h.uc.GetByPost(r.Context(), id, viewer, r.URL.Query().Get("sort"))
 	if err != nil {
-		http.Error(w, "internal error", http.StatusInternalServerError)
+		writeUseCaseError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -94,32 +123,30 @@ func (h *CommentHandler) GetByPost(w http.ResponseWriter, r *http.Request) {
 
 // Delete godoc
 // @Summary Удалить комментарий
-// @Description Удаляет комментарий по ID
+// @Description Переносит комментарий в корзину, откуда его может восстановить администратор. С заголовком Authorization сохраняется, кто удалил комментарий
 // @Tags Комментарии
 // @Accept json
 // @Produce json
-// @Param comment_id query int true "ID комментария"
+// @Param id path int true "ID комментария"
 // @Success 200 {object} map[string]string "Комментарий удален"
-// @Failure 400 {object} map[string]string "Неверный запрос"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /comments/delete [delete]
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 404 {object} model.ErrorResponse "Комментарий не найден"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /comments/{id} [delete]
 func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	id, err := pathID(r, "comment_id")
+	if err != nil {
+		writeBadRequest(w, r, "bad request", nil)
 		return
 	}
-	id, err := strconv.Atoi(r.URL.Query().Get("comment_id"))
+	userID, err := viewerID(h.auth, r)
 	if err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		writeUnauthorized(w, r)
 		return
 	}
-	if err := h.uc.Delete(id); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrCommentNotFound):
-			http.Error(w, "comment not found", http.StatusBadRequest)
-		default:
-			http.Error(w, "internal error", http.StatusInternalServerError)
-		}
+	if err := h.uc.Delete(r.Context(), id, userID); err != nil {
+		writeUseCaseError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+	"golangforum/internal/middleware"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+)
+
+// Коды ошибок, которые возникают в самих обработчиках, до вызова use case
+const (
+	codeInvalidRequest = "invalid_request"
+	codeValidation     = "validation_failed"
+	codeUnauthorized   = "unauthorized"
+	codeForbidden      = "forbidden"
+	codeTimeout        = "timeout"
+	codeInternal       = "internal_error"
+)
+
+// apiError задает статус HTTP и код, с которыми ошибка use case передается клиенту
+type apiError struct {
+	err    error
+	status int
+	code   string
+}
+
+// apiErrors сопоставляет ошибки use case с ответами. Текст ответа берется из самой ошибки-образца,
+// поэтому сюда попадают только ошибки, сообщения которых можно показывать клиенту
+var apiErrors = []apiError{
+	{usecase.ErrMissingToken, http.StatusBadRequest, "missing_token"},
+	{usecase.ErrInvalidToken, http.StatusUnauthorized, "invalid_token"},
+	{usecase.ErrInvalidUserID, http.StatusUnauthorized, "invalid_user_id"},
+	{usecase.ErrInvalidUsername, http.StatusUnauthorized, "invalid_username"},
+	{usecase.ErrForbidden, http.StatusForbidden, codeForbidden},
+
+	{usecase.ErrCommentNotFound, http.StatusNotFound, "comment_not_found"},
+
+	{usecase.ErrPostNotFound, http.StatusNotFound, "post_not_found"},
+	{usecase.ErrInvalidPostFilter, http.StatusBadRequest, "invalid_post_filter"},
+
+	{usecase.ErrTopicNotFound, http.StatusNotFound, "topic_not_found"},
+	{usecase.ErrTopicCycle, http.StatusConflict, "topic_cycle"},
+	{usecase.ErrTopicArchived, http.StatusConflict, "topic_archived"},
+	{usecase.ErrTopicLocked, http.StatusLocked, "topic_locked"},
+
+	{usecase.ErrInvalidAttachment, http.StatusBadRequest, "invalid_attachment"},
+	{usecase.ErrAttachmentNotFound, http.StatusNotFound, "attachment_not_found"},
+	{usecase.ErrAttachmentTooLarge, http.StatusRequestEntityTooLarge, "attachment_too_large"},
+	{usecase.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+
+	{usecase.ErrInvalidTag, http.StatusBadRequest, "invalid_tag"},
+	{usecase.ErrTagNotFound, http.StatusNotFound, "tag_not_found"},
+	{usecase.ErrTagExists, http.StatusConflict, "tag_exists"},
+
+	{usecase.ErrInvalidTrashType, http.StatusBadRequest, "invalid_trash_type"},
+	{usecase.ErrTrashNotFound, http.StatusNotFound, "trash_item_not_found"},
+	{usecase.ErrParentDeleted, http.StatusConflict, "parent_deleted"},
+
+	{usecase.ErrInvalidVote, http.StatusBadRequest, "invalid_vote"},
+	{usecase.ErrInvalidReaction, http.StatusBadRequest, "invalid_reaction"},
+	{usecase.ErrInvalidSort, http.StatusBadRequest, "invalid_sort"},
+}
+
+// writeError отправляет ошибку в формате model.ErrorResponse
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, message string, details ...model.FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(model.ErrorResponse{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: middleware.RequestID(r.Context()),
+	})
+}
+
+// writeUseCaseError переводит ошибку use case в ответ по таблице apiErrors; ошибки проверки полей
+// попадают в details, а без ошибки из таблицы отдаются как validation_failed. Истекший срок запроса
+// отдается как timeout, остальные ошибки — как internal_error без текста исходной ошибки
+func writeUseCaseError(w http.ResponseWriter, r *http.Request, err error) {
+	var details []model.FieldError
+	var verr *model.ValidationError
+	if errors.As(err, &verr) {
+		details = verr.Fields
+	}
+	for _, e := range apiErrors {
+		if errors.Is(err, e.err) {
+			writeError(w, r, e.status, e.code, e.err.Error(), details...)
+			return
+		}
+	}
+	if verr != nil {
+		writeError(w, r, http.StatusBadRequest, codeValidation, "validation failed", details...)
+		return
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(r.Context().Err(), context.DeadlineExceeded):
+		log.Ctx(r.Context()).Warn().Err(err).Str("method", r.Method).Str("path", r.URL.Path).Msg("Request timed out")
+		writeError(w, r, http.StatusServiceUnavailable, codeTimeout, "request timed out")
+		return
+	case errors.Is(err, context.Canceled), errors.Is(r.Context().Err(), context.Canceled):
+		// клиент закрыл соединение, отвечать некому
+		log.Ctx(r.Context()).Debug().Str("method", r.Method).Str("path", r.URL.Path).Msg("Request canceled by client")
+		return
+	}
+	log.Ctx(r.Context()).Error().Err(err).Str("method", r.Method).Str("path", r.URL.Path).Msg("Request failed")
+	writeError(w, r, http.StatusInternalServerError, codeInternal, "internal error")
+}
+
+// writeBadRequest отвечает 400 invalid_request; err с ошибками полей попадает в details
+func writeBadRequest(w http.ResponseWriter, r *http.Request, message string, err error) {
+	var verr *model.ValidationError
+	if errors.As(err, &verr) {
+		writeError(w, r, http.StatusBadRequest, codeInvalidRequest, message, verr.Fields...)
+		return
+	}
+	writeError(w, r, http.StatusBadRequest, codeInvalidRequest, message)
+}
+
+func writeUnauthorized(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusUnauthorized, codeUnauthorized, "unauthorized")
+}
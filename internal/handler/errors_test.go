@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golangforum/internal/middleware"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+)
+
+// writeUseCaseErrorTo отвечает на запрос ошибкой err так же, как обработчик, стоящий за журналом запросов
+func writeUseCaseErrorTo(ctx context.Context, err error) *httptest.ResponseRecorder {
+	h := middleware.Logging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeUseCaseError(w, r, err)
+	}), zerolog.Nop())
+	r := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/posts/1", nil)
+	r.Header.Set(middleware.RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func decodeError(t *testing.T, w *httptest.ResponseRecorder) model.ErrorResponse {
+	t.Helper()
+	var resp model.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	return resp
+}
+
+func TestWriteUseCaseError_Sentinels(t *testing.T) {
+	for _, e := range apiErrors {
+		t.Run(e.code, func(t *testing.T) {
+			// use case оборачивают ошибки-образцы, ответ от этого не меняется
+			w := writeUseCaseErrorTo(context.Background(), fmt.Errorf("post 1: %w", e.err))
+
+			assert.Equal(t, e.status, w.Code)
+			assert.Equal(t, model.ErrorResponse{Code: e.code, Message: e.err.Error(), RequestID: "req-1"}, decodeError(t, w))
+		})
+	}
+}
+
+func TestWriteUseCaseError(t *testing.T) {
+	fields := []model.FieldError{{Field: "title", Message: "must not be empty"}}
+	tests := []struct {
+		name string
+		err  error
+		want int
+		resp model.ErrorResponse
+	}{
+		{
+			name: "validation",
+			err:  &model.ValidationError{Fields: fields},
+			want: http.StatusBadRequest,
+			resp: model.ErrorResponse{Code: codeValidation, Message: "validation failed", Details: fields, RequestID: "req-1"},
+		},
+		{
+			name: "validation with sentinel",
+			err:  errors.Join(usecase.ErrInvalidTag, &model.ValidationError{Fields: fields}),
+			want: http.StatusBadRequest,
+			resp: model.ErrorResponse{Code: "invalid_tag", Message: usecase.ErrInvalidTag.Error(), Details: fields, RequestID: "req-1"},
+		},
+		{
+			name: "deadline",
+			err:  fmt.Errorf("query posts: %w", context.DeadlineExceeded),
+			want: http.StatusServiceUnavailable,
+			resp: model.ErrorResponse{Code: codeTimeout, Message: "request timed out", RequestID: "req-1"},
+		},
+		{
+			name: "unknown",
+			err:  errors.New(`pq: relation "posts" does not exist`),
+			want: http.StatusInternalServerError,
+			resp: model.ErrorResponse{Code: codeInternal, Message: "internal error", RequestID: "req-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := writeUseCaseErrorTo(context.Background(), tt.err)
+
+			assert.Equal(t, tt.want, w.Code)
+			assert.NotContains(t, w.Body.String(), "pq:")
+			assert.Equal(t, tt.resp, decodeError(t, w))
+		})
+	}
+}
+
+func TestWriteUseCaseError_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, err := range []error{context.Canceled, errors.New("query posts: driver: bad connection")} {
+		w := writeUseCaseErrorTo(ctx, err)
+
+		// клиент ушел: ни статус, ни тело не пишутся
+		assert.Empty(t, w.Body.String(), "%v", err)
+		assert.False(t, w.Flushed)
+		assert.Empty(t, w.Header().Get("Content-Type"), "%v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"golangforum/internal/client"
+	"golangforum/internal/usecase"
+)
+
+type MentionHandler struct {
+	UseCase    usecase.MentionUseCase
+	AuthClient *client.AuthClient
+}
+
+func NewMentionHandler(uc usecase.MentionUseCase, authClient *client.AuthClient) *MentionHandler {
+	return &MentionHandler{UseCase: uc, AuthClient: authClient}
+}
+
+// GetMine godoc
+// @Summary Получить упоминания текущего пользователя
+// @Description Возвращает упоминания текущего пользователя в постах, комментариях и сообщениях чата, начиная с самых новых. Общее количество передается в заголовке X-Total-Count
+// @Tags Упоминания
+// @Accept json
+// @Produce json
+// @Param limit query int false "Количество записей (по умолчанию 20, не более 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.Mention "Список упоминаний"
+// @Header 200 {integer} X-Total-Count "Общее количество упоминаний"
+// @Failure 400 {object} model.ErrorResponse "Неверные параметры пагинации"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /mentions [get]
+func (h *MentionHandler) GetMine(w http.ResponseWriter, r *http.Request) {
+	user, err := h.AuthClient.GetUser(r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		writeBadRequest(w, r, "invalid query parameters", err)
+		return
+	}
+	mentions, total, err := h.UseCase.GetByUsername(r.Context(), user.Username, limit, offset)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	json.NewEncoder(w).Encode(mentions)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/client/clienttest"
+	"golangforum/internal/middleware"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase/mocks"
+)
+
+func TestMentionHandler_GetMine_LogsUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mentions := mocks.NewMockMentionUseCase(ctrl)
+	mentions.EXPECT().GetByUsername(gomock.Any(), "bob", 20, 0).Return([]model.Mention{}, 0, nil)
+	h := NewMentionHandler(mentions, clienttest.NewAuthClient(t, testUsers))
+	var buf bytes.Buffer
+	logged := middleware.Logging(http.HandlerFunc(h.GetMine), zerolog.New(&buf))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/mentions", nil)
+	r.Header.Set("Authorization", "Bearer user")
+	w := httptest.NewRecorder()
+	logged.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var access map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &access))
+	assert.Equal(t, "request completed", access["message"])
+	assert.Equal(t, float64(1), access["user_id"])
+}
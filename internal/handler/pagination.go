@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"golangforum/internal/model"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// parsePagination читает параметры limit и offset из строки запроса, подставляя значения по умолчанию
+func parsePagination(r *http.Request) (limit, offset int, err error) {
+	limit, offset = defaultPageLimit, 0
+	q := r.URL.Query()
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 || limit > maxPageLimit {
+			return 0, 0, model.InvalidField("limit", "must be between 1 and "+strconv.Itoa(maxPageLimit))
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, model.InvalidField("offset", "must be a non-negative integer")
+		}
+	}
+	return limit, offset, nil
+}
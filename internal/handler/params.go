@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// pathID возвращает ID из сегмента {id} пути. Устаревшие маршруты без {id} передают его в параметре строки запроса query
+func pathID(r *http.Request, query string) (int, error) {
+	v := r.PathValue("id")
+	if v == "" {
+		v = r.URL.Query().Get(query)
+	}
+	return strconv.Atoi(v)
+}
+
+// bodyID подставляет в *id значение сегмента {id} пути, если он есть; иначе остается ID из тела запроса
+func bodyID(r *http.Request, id *int) error {
+	v := r.PathValue("id")
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return err
+	}
+	*id = n
+	return nil
+}
@@ -2,9 +2,10 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"golangforum/internal/client"
 	"golangforum/internal/model"
@@ -13,137 +14,296 @@ import (
 
 type PostHandler struct {
 	UseCase    usecase.PostUseCase
+	Comments   usecase.CommentUseCase
 	AuthClient *client.AuthClient
 }
 
-func NewPostHandler(uc usecase.PostUseCase, authClient *client.AuthClient) *PostHandler {
-	return &PostHandler{UseCase: uc, AuthClient: authClient}
+func NewPostHandler(uc usecase.PostUseCase, comments usecase.CommentUseCase, authClient *client.AuthClient) *PostHandler {
+	return &PostHandler{UseCase: uc, Comments: comments, AuthClient: authClient}
 }
 
 // Create godoc
 // @Summary Создать новый пост
-// @Description Создает новый пост в указанной теме
+// @Description Создает новый пост в указанной теме. Теги нормализуются: приводятся к нижнему регистру, пробелы заменяются дефисом; у поста не более 5 тегов
 // @Tags Посты
 // @Accept json
 // @Produce json
 // @Param post body model.Post true "Данные поста"
-// @Success 201 {object} map[string]string "Пост успешно создан"
-// @Failure 400 {object} map[string]string "Неверный запрос"
-// @Failure 401 {object} map[string]string "Не авторизован"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /posts/create [post]
+// @Success 201 {object} model.Post "Созданный пост"
+// @Header 201 {string} Location "Адрес созданного поста"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 404 {object} model.ErrorResponse "Тема не найдена"
+// @Failure 409 {object} model.ErrorResponse "Тема в архиве"
+// @Failure 423 {object} model.ErrorResponse "Тема закрыта"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /posts [post]
 func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "use POST", http.StatusMethodNotAllowed)
-		return
-	}
 	var p model.Post
 	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		writeBadRequest(w, r, "invalid request", nil)
 		return
 	}
 	defer r.Body.Close()
-	username, err := h.AuthClient.GetUsername(r)
+	user, err := h.AuthClient.GetUser(r)
 	if err != nil {
-		http.Error(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
+		writeUnauthorized(w, r)
 		return
 	}
-	if err := h.UseCase.Create(username, &p); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrInvalidPostData):
-			http.Error(w, "invalid post data", http.StatusBadRequest)
-		default:
-			http.Error(w, "internal error", http.StatusInternalServerError)
+	p.UserID = user.ID
+	if err := h.UseCase.Create(r.Context(), user.Username, &p); err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	writeCreated(w, "/api/v1/posts/"+strconv.Itoa(p.ID), p)
+}
+
+// GetByID godoc
+// @Summary Получить пост
+// @Description Возвращает пост по ID. С comments=true в ответ добавляются комментарии к посту. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя
+// @Tags Посты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID поста"
+// @Param comments query bool false "Включить комментарии"
+// @Param sort query string false "Сортировка комментариев: top — по рейтингу; по умолчанию — в порядке создания"
+// @Success 200 {object} model.Post "Пост"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 404 {object} model.ErrorResponse "Пост не найден"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /posts/{id} [get]
+func (h *PostHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "post_id")
+	if err != nil {
+		writeBadRequest(w, r, "invalid post_id", nil)
+		return
+	}
+	q := r.URL.Query()
+	withComments := false
+	if v := q.Get("comments"); v != "" {
+		if withComments, err = strconv.ParseBool(v); err != nil {
+			writeBadRequest(w, r, "invalid comments", nil)
+			return
 		}
+	}
+	viewer, err := viewerID(h.AuthClient, r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	post, err := h.UseCase.GetByID(r.Context(), id, viewer)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	if withComments {
+		post.Comments, err = h.Comments.GetByPost(r.Context(), id, viewer, q.Get("sort"))
+		if err != nil {
+			writeUseCaseError(w, r, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(post)
+}
+
+// Update godoc
+// @Summary Изменить пост
+// @Description Изменяет заголовок, текст и теги поста. Отсутствующие в запросе поля не изменяются, переданные теги заменяют прежние. Изменять пост может только его автор
+// @Tags Посты
+// @Accept json
+// @Produce json
+// @Param id path int true "ID поста"
+// @Param post body model.PostUpdateRequest true "Изменяемые поля поста"
+// @Success 200 {object} model.Post "Измененный пост"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Пост принадлежит другому пользователю"
+// @Failure 404 {object} model.ErrorResponse "Пост не найден"
+// @Failure 409 {object} model.ErrorResponse "Тема в архиве"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /posts/{id} [patch]
+func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
+	user, err := h.AuthClient.GetUser(r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	var req model.PostUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	if err := bodyID(r, &req.ID); err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]string{"message": "post created"})
+	post, err := h.UseCase.Update(r.Context(), user.ID, &req)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(post)
 }
 
 // GetByTopic godoc
-// @Summary Получить все посты по теме
-// @Description Возвращает список всех постов для указанной темы
+// @Summary Получить посты темы
+// @Description Возвращает страницу постов указанной темы с фильтрами и сортировкой. Общее количество передается в заголовке X-Total-Count. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя
 // @Tags Посты
 // @Accept json
 // @Produce json
-// @Param topic_id query int true "ID темы"
+// @Param id path int true "ID темы"
+// @Param author query string false "Имя автора (без учета регистра)"
+// @Param from query string false "Посты, созданные не раньше даты (RFC 3339 или ГГГГ-ММ-ДД)"
+// @Param to query string false "Посты, созданные раньше даты (RFC 3339 или ГГГГ-ММ-ДД)"
+// @Param has_comments query bool false "true — только посты с комментариями, false — только без комментариев"
+// @Param tags query string false "Теги через запятую"
+// @Param tag_mode query string false "all — посты со всеми тегами (по умолчанию), any — хотя бы с одним"
+// @Param sort query string false "Сортировка: newest (по умолчанию), oldest, comments, activity, top"
+// @Param limit query int false "Количество записей (по умолчанию 20, не более 100)"
+// @Param offset query int false "Смещение"
 // @Success 200 {array} model.Post "Список постов"
-// @Failure 400 {object} map[string]string "Неверный topic_id"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /posts [get]
+// @Header 200 {integer} X-Total-Count "Общее количество постов"
+// @Failure 400 {object} model.ErrorResponse "Неверные параметры запроса"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics/{id}/posts [get]
 func (h *PostHandler) GetByTopic(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "use GET", http.StatusMethodNotAllowed)
-		return
-	}
-	id, err := strconv.Atoi(r.URL.Query().Get("topic_id"))
+	id, err := pathID(r, "topic_id")
 	if err != nil {
-		http.Error(w, "invalid topic_id", http.StatusBadRequest)
+		writeBadRequest(w, r, "invalid topic_id", nil)
 		return
 	}
-	posts, err := h.UseCase.GetByTopic(id)
+	f, err := parsePostFilter(r)
 	if err != nil {
-		http.Error(w, "could not fetch posts", http.StatusInternalServerError)
+		writeBadRequest(w, r, "invalid query parameters", err)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(posts)
+	f.TopicID = id
+	h.list(w, r, f)
 }
 
 // GetAll godoc
-// @Summary Получить все посты
-// @Description Возвращает список всех постов
+// @Summary Получить посты всех тем
+// @Description Возвращает страницу постов всех тем с фильтрами и сортировкой. Общее количество передается в заголовке X-Total-Count. С заголовком Authorization в ответ добавляются голос и реакции текущего пользователя
 // @Tags Посты
 // @Accept json
 // @Produce json
-// @Success 200 {array} model.Post "Список всех постов"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /posts/all [get]
+// @Param author query string false "Имя автора (без учета регистра)"
+// @Param from query string false "Посты, созданные не раньше даты (RFC 3339 или ГГГГ-ММ-ДД)"
+// @Param to query string false "Посты, созданные раньше даты (RFC 3339 или ГГГГ-ММ-ДД)"
+// @Param has_comments query bool false "true — только посты с комментариями, false — только без комментариев"
+// @Param tags query string false "Теги через запятую"
+// @Param tag_mode query string false "all — посты со всеми тегами (по умолчанию), any — хотя бы с одним"
+// @Param sort query string false "Сортировка: newest (по умолчанию), oldest, comments, activity, top"
+// @Param limit query int false "Количество записей (по умолчанию 20, не более 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.Post "Список постов"
+// @Header 200 {integer} X-Total-Count "Общее количество постов"
+// @Failure 400 {object} model.ErrorResponse "Неверные параметры запроса"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /posts [get]
 func (h *PostHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "use GET", http.StatusMethodNotAllowed)
+	f, err := parsePostFilter(r)
+	if err != nil {
+		writeBadRequest(w, r, "invalid query parameters", err)
+		return
+	}
+	h.list(w, r, f)
+}
+
+func (h *PostHandler) list(w http.ResponseWriter, r *http.Request, f model.PostFilter) {
+	viewer, err := viewerID(h.AuthClient, r)
+	if err != nil {
+		writeUnauthorized(w, r)
 		return
 	}
-	posts, err := h.UseCase.GetAll()
+	posts, total, err := h.UseCase.List(r.Context(), viewer, f)
 	if err != nil {
-		http.Error(w, "could not fetch posts", http.StatusInternalServerError)
+		writeUseCaseError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
 	json.NewEncoder(w).Encode(posts)
 }
 
 // Delete godoc
 // @Summary Удалить пост
-// @Description Удаляет пост по его ID
+// @Description Переносит пост вместе с комментариями в корзину, откуда его может восстановить администратор. С заголовком Authorization сохраняется, кто удалил пост
 // @Tags Посты
 // @Accept json
 // @Produce json
-// @Param post_id query int true "ID поста"
+// @Param id path int true "ID поста"
 // @Success 200 {object} map[string]string "Пост успешно удален"
-// @Failure 400 {object} map[string]string "Неверный post_id"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /posts/delete [delete]
+// @Failure 400 {object} model.ErrorResponse "Неверный post_id"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 404 {object} model.ErrorResponse "Пост не найден"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /posts/{id} [delete]
 func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		http.Error(w, "use DELETE", http.StatusMethodNotAllowed)
+	id, err := pathID(r, "post_id")
+	if err != nil {
+		writeBadRequest(w, r, "invalid post_id", nil)
 		return
 	}
-	id, err := strconv.Atoi(r.URL.Query().Get("post_id"))
+	userID, err := viewerID(h.AuthClient, r)
 	if err != nil {
-		http.Error(w, "invalid post_id", http.StatusBadRequest)
+		writeUnauthorized(w, r)
 		return
 	}
-	if err := h.UseCase.Delete(id); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrPostNotFound):
-			http.Error(w, "post not found", http.StatusBadRequest)
-		default:
-			http.Error(w, "internal error", http.StatusInternalServerError)
-		}
+	if err := h.UseCase.Delete(r.Context(), id, userID); err != nil {
+		writeUseCaseError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"message": "post deleted"})
 }
+
+// parsePostFilter читает параметры фильтрации, сортировки и пагинации списка постов из строки запроса
+func parsePostFilter(r *http.Request) (model.PostFilter, error) {
+	var f model.PostFilter
+	var err error
+	if f.Limit, f.Offset, err = parsePagination(r); err != nil {
+		return f, err
+	}
+	q := r.URL.Query()
+	f.Author = strings.TrimSpace(q.Get("author"))
+	f.Sort = q.Get("sort")
+	f.TagMode = q.Get("tag_mode")
+	for _, v := range q["tags"] {
+		f.Tags = append(f.Tags, strings.Split(v, ",")...)
+	}
+	if f.From, err = parseDateParam(q.Get("from")); err != nil {
+		return f, model.InvalidField("from", "must be an RFC 3339 timestamp or YYYY-MM-DD date")
+	}
+	if f.To, err = parseDateParam(q.Get("to")); err != nil {
+		return f, model.InvalidField("to", "must be an RFC 3339 timestamp or YYYY-MM-DD date")
+	}
+	if v := q.Get("has_comments"); v != "" {
+		has, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, model.InvalidField("has_comments", "must be a boolean")
+		}
+		f.HasComments = &has
+	}
+	return f, nil
+}
+
+// parseDateParam разбирает дату в формате RFC 3339 или ГГГГ-ММ-ДД (начало дня по UTC); пустая строка — отсутствие ограничения
+func parseDateParam(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, v); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
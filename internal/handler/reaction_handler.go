@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"golangforum/internal/client"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+)
+
+type ReactionHandler struct {
+	UseCase    usecase.ReactionUseCase
+	AuthClient *client.AuthClient
+}
+
+func NewReactionHandler(uc usecase.ReactionUseCase, authClient *client.AuthClient) *ReactionHandler {
+	return &ReactionHandler{UseCase: uc, AuthClient: authClient}
+}
+
+// Vote godoc
+// @Summary Проголосовать за пост или комментарий
+// @Description Ставит голос +1 или -1. Повторный такой же голос отменяет его, противоположный — заменяет прежний. От одного пользователя учитывается один голос
+// @Tags Реакции
+// @Accept json
+// @Produce json
+// @Param vote body model.VoteRequest true "Голос"
+// @Success 200 {object} model.VoteResult "Рейтинг после голосования"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 404 {object} model.ErrorResponse "Пост или комментарий не найден"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /votes [post]
+func (h *ReactionHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	user, err := h.AuthClient.GetUser(r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	var req model.VoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	res, err := h.UseCase.Vote(r.Context(), user.ID, req)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// ToggleReaction godoc
+// @Summary Поставить или снять реакцию
+// @Description Ставит эмодзи-реакцию на пост или комментарий; если пользователь уже поставил такую реакцию, она снимается
+// @Tags Реакции
+// @Accept json
+// @Produce json
+// @Param reaction body model.ReactionRequest true "Реакция"
+// @Success 200 {object} model.ReactionCount "Количество таких реакций после изменения"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 404 {object} model.ErrorResponse "Пост или комментарий не найден"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /reactions [post]
+func (h *ReactionHandler) ToggleReaction(w http.ResponseWriter, r *http.Request) {
+	user, err := h.AuthClient.GetUser(r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return
+	}
+	var req model.ReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	res, err := h.UseCase.ToggleReaction(r.Context(), user.ID, req)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// viewerID возвращает ID пользователя для персональных полей ответа (my_vote, reacted).
+// Без заголовка Authorization запрос считается анонимным, и возвращается 0
+func viewerID(auth *client.AuthClient, r *http.Request) (int, error) {
+	if r.Header.Get("Authorization") == "" {
+		return 0, nil
+	}
+	user, err := auth.GetUser(r)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// writeCreated отвечает 201 с созданным объектом и адресом, по которому его можно получить, в заголовке Location
+func writeCreated(w http.ResponseWriter, location string, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", location)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"golangforum/internal/client"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+)
+
+type TagHandler struct {
+	UseCase    usecase.TagUseCase
+	AuthClient *client.AuthClient
+}
+
+func NewTagHandler(uc usecase.TagUseCase, authClient *client.AuthClient) *TagHandler {
+	return &TagHandler{UseCase: uc, AuthClient: authClient}
+}
+
+// List godoc
+// @Summary Получить теги
+// @Description Возвращает теги с количеством постов, от самых используемых. С параметром q возвращаются только теги, начинающиеся с q (автодополнение)
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Param q query string false "Начало имени тега"
+// @Param limit query int false "Количество записей (по умолчанию 20, не более 100)"
+// @Success 200 {array} model.Tag "Список тегов"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /tags [get]
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _, err := parsePagination(r)
+	if err != nil {
+		writeBadRequest(w, r, "invalid query parameters", err)
+		return
+	}
+	tags, err := h.UseCase.List(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+}
+
+// Rename godoc
+// @Summary Переименовать тег
+// @Description Меняет имя тега у всех постов. Если тег с новым именем уже существует, теги нужно слить. Доступно только администраторам
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Param id path int true "ID тега"
+// @Param tag body model.TagRenameRequest true "Новое имя"
+// @Success 200 {object} model.Tag "Переименованный тег"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} model.ErrorResponse "Тег не найден"
+// @Failure 409 {object} model.ErrorResponse "Тег с таким именем уже существует"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /tags/{id} [patch]
+func (h *TagHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(h.AuthClient, w, r) {
+		return
+	}
+	var req model.TagRenameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	if err := bodyID(r, &req.ID); err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+	t, err := h.UseCase.Rename(r.Context(), req.ID, req.Name)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+// Merge godoc
+// @Summary Слить теги
+// @Description Переносит тег source_id на его посты в виде тега target_id и удаляет source_id. Доступно только администраторам
+// @Tags Теги
+// @Accept json
+// @Produce json
+// @Param tags body model.TagMergeRequest true "ID поглощаемого и остающегося тегов"
+// @Success 200 {object} model.Tag "Оставшийся тег"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} model.ErrorResponse "Тег не найден"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /tags/merge [post]
+func (h *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(h.AuthClient, w, r) {
+		return
+	}
+	var req model.TagMergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	t, err := h.UseCase.Merge(r.Context(), req.SourceID, req.TargetID)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t)
+}
+
+// requireAdmin отвечает 401 или 403 и возвращает false, если запрос сделан не администратором
+func requireAdmin(auth *client.AuthClient, w http.ResponseWriter, r *http.Request) bool {
+	user, err := auth.GetUser(r)
+	if err != nil {
+		writeUnauthorized(w, r)
+		return false
+	}
+	if !user.IsAdmin() {
+		writeError(w, r, http.StatusForbidden, codeForbidden, "forbidden")
+		return false
+	}
+	return true
+}
@@ -2,7 +2,7 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
+	"golangforum/internal/client"
 	"golangforum/internal/model"
 	"net/http"
 	"strconv"
@@ -11,107 +11,285 @@ import (
 )
 
 type TopicHandler struct {
-	UseCase usecase.TopicUseCase
+	UseCase    usecase.TopicUseCase
+	AuthClient *client.AuthClient
 }
 
-func NewTopicHandler(uc usecase.TopicUseCase) *TopicHandler {
-	return &TopicHandler{UseCase: uc}
+func NewTopicHandler(uc usecase.TopicUseCase, authClient *client.AuthClient) *TopicHandler {
+	return &TopicHandler{UseCase: uc, AuthClient: authClient}
 }
 
 // Create godoc
 // @Summary Создать тему
-// @Description Создает новую тему с заголовком и описанием, используя структуру Topic
+// @Description Создает новую тему с заголовком и описанием, используя структуру Topic. С parent_id тема создается как подфорум указанной темы
 // @Tags Темы
 // @Accept json
 // @Produce json
 // @Param topic body model.Topic true "Данные темы"
-// @Success 201 {object} map[string]string "Тема успешно создана"
-// @Failure 400 {object} map[string]string "Неверный запрос"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /topics/create [post]
+// @Success 201 {object} model.Topic "Созданная тема"
+// @Header 201 {string} Location "Адрес созданной темы"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 404 {object} model.ErrorResponse "Родительская тема не найдена"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics [post]
 func (h *TopicHandler) Create(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "use POST", http.StatusMethodNotAllowed)
-		return
-	}
 	var topic model.Topic
 	if err := json.NewDecoder(r.Body).Decode(&topic); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+		writeBadRequest(w, r, "invalid request", nil)
 		return
 	}
 	defer r.Body.Close()
 
 	if err := topic.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeUseCaseError(w, r, err)
+		return
+	}
+
+	if err := h.UseCase.Create(r.Context(), &topic); err != nil {
+		writeUseCaseError(w, r, err)
 		return
 	}
+	writeCreated(w, "/api/v1/topics/"+strconv.Itoa(topic.ID), topic)
+}
 
-	if err := h.UseCase.Create(topic.Title, topic.Description); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrInvalidTopicData):
-			http.Error(w, "invalid topic data", http.StatusBadRequest)
-		default:
-			http.Error(w, "internal error", http.StatusInternalServerError)
-		}
+// GetByID godoc
+// @Summary Получить тему
+// @Description Возвращает тему по ID со статистикой: количество постов и комментариев, последний пост и время последней активности
+// @Tags Темы
+// @Accept json
+// @Produce json
+// @Param id path int true "ID темы"
+// @Success 200 {object} model.Topic "Тема"
+// @Failure 400 {object} model.ErrorResponse "Неверный id"
+// @Failure 404 {object} model.ErrorResponse "Тема не найдена"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics/{id} [get]
+func (h *TopicHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+	topic, err := h.UseCase.GetByID(r.Context(), id)
+	if err != nil {
+		writeUseCaseError(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]string{"message": "topic created"})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(topic)
 }
 
 // GetAll godoc
 // @Summary Получить все темы
-// @Description Возвращает список всех тем форума
+// @Description Возвращает плоский список всех тем форума в порядке отображения со статистикой: количество постов и комментариев, последний пост и время последней активности. Архивные темы и их подтемы по умолчанию скрыты
 // @Tags Темы
 // @Accept json
 // @Produce json
+// @Param include_archived query bool false "Включить архивные темы"
 // @Success 200 {array} model.Topic "Список тем"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
 // @Router /topics [get]
 func (h *TopicHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "use GET", http.StatusMethodNotAllowed)
+	includeArchived, err := parseIncludeArchived(r)
+	if err != nil {
+		writeBadRequest(w, r, "invalid include_archived", nil)
 		return
 	}
-	topics, err := h.UseCase.GetAll()
+	topics, err := h.UseCase.GetAll(r.Context(), includeArchived)
 	if err != nil {
-		http.Error(w, "could not fetch topics", http.StatusInternalServerError)
+		writeUseCaseError(w, r, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(topics)
 }
 
+// GetTree godoc
+// @Summary Получить дерево тем
+// @Description Возвращает темы верхнего уровня с вложенными подфорумами в поле children. Темы одного уровня упорядочены по position. Архивные темы и их подтемы по умолчанию скрыты
+// @Tags Темы
+// @Accept json
+// @Produce json
+// @Param include_archived query bool false "Включить архивные темы"
+// @Success 200 {array} model.Topic "Дерево тем"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics/tree [get]
+func (h *TopicHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	includeArchived, err := parseIncludeArchived(r)
+	if err != nil {
+		writeBadRequest(w, r, "invalid include_archived", nil)
+		return
+	}
+	tree, err := h.UseCase.GetTree(r.Context(), includeArchived)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tree)
+}
+
+// Update godoc
+// @Summary Изменить тему
+// @Description Изменяет заголовок, описание и порядок отображения темы; отсутствующие в запросе поля не изменяются. Родительская тема меняется через /topics/{id}/move. Доступно только администраторам
+// @Tags Темы
+// @Accept json
+// @Produce json
+// @Param id path int true "ID темы"
+// @Param topic body model.TopicUpdateRequest true "Изменяемые поля темы"
+// @Success 200 {object} model.Topic "Измененная тема"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} model.ErrorResponse "Тема не найдена"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics/{id} [patch]
+func (h *TopicHandler) Update(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(h.AuthClient, w, r) {
+		return
+	}
+	var req model.TopicUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	if err := bodyID(r, &req.ID); err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+
+	topic, err := h.UseCase.Update(r.Context(), &req)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(topic)
+}
+
+// Move godoc
+// @Summary Перенести тему
+// @Description Переносит тему вместе с подфорумами под другую родительскую тему. Без parent_id тема переносится на верхний уровень. Перенос темы в саму себя или в собственный подфорум отклоняется. Доступно только администраторам
+// @Tags Темы
+// @Accept json
+// @Produce json
+// @Param id path int true "ID темы"
+// @Param move body model.TopicMoveRequest true "ID новой родительской темы"
+// @Success 200 {object} map[string]string "Тема перенесена"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} model.ErrorResponse "Тема не найдена"
+// @Failure 409 {object} model.ErrorResponse "Перенос создал бы цикл"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics/{id}/move [post]
+func (h *TopicHandler) Move(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(h.AuthClient, w, r) {
+		return
+	}
+	var req model.TopicMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	if err := bodyID(r, &req.ID); err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+	if req.ID <= 0 || (req.ParentID != nil && *req.ParentID <= 0) {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+
+	if err := h.UseCase.Move(r.Context(), req.ID, req.ParentID); err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "topic moved"})
+}
+
+// SetState godoc
+// @Summary Архивировать или закрыть тему
+// @Description Помещает тему в архив (только чтение, скрыта из списка тем) или закрывает ее для новых постов. Отсутствующие поля не изменяются. Доступно только администраторам
+// @Tags Темы
+// @Accept json
+// @Produce json
+// @Param id path int true "ID темы"
+// @Param state body model.TopicStateRequest true "Новые признаки"
+// @Success 200 {object} model.Topic "Тема после изменения"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} model.ErrorResponse "Тема не найдена"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics/{id}/state [post]
+func (h *TopicHandler) SetState(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(h.AuthClient, w, r) {
+		return
+	}
+	var req model.TopicStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	if err := bodyID(r, &req.ID); err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+	if req.ID <= 0 {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+
+	topic, err := h.UseCase.SetState(r.Context(), req.ID, req.Archived, req.Locked)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(topic)
+}
+
 // Delete godoc
 // @Summary Удалить тему
-// @Description Удаляет тему по ее ID
+// @Description Переносит тему вместе с подтемами, постами и комментариями в корзину, откуда ее может восстановить администратор. С заголовком Authorization сохраняется, кто удалил тему
 // @Tags Темы
 // @Accept json
 // @Produce json
-// @Param id query int true "ID темы"
+// @Param id path int true "ID темы"
 // @Success 200 {object} map[string]string "Тема успешно удалена"
-// @Failure 400 {object} map[string]string "Неверный id"
-// @Failure 500 {object} map[string]string "Ошибка сервера"
-// @Router /topics/delete [delete]
+// @Failure 400 {object} model.ErrorResponse "Неверный id"
+// @Failure 401 {object} model.ErrorResponse "Неверный токен"
+// @Failure 404 {object} model.ErrorResponse "Тема не найдена"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /topics/{id} [delete]
 func (h *TopicHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		http.Error(w, "use DELETE", http.StatusMethodNotAllowed)
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeBadRequest(w, r, "invalid id", nil)
 		return
 	}
-	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	userID, err := viewerID(h.AuthClient, r)
 	if err != nil {
-		http.Error(w, "invalid id", http.StatusBadRequest)
+		writeUnauthorized(w, r)
 		return
 	}
-	if err := h.UseCase.Delete(id); err != nil {
-		switch {
-		case errors.Is(err, usecase.ErrTopicNotFound):
-			http.Error(w, "topic not found", http.StatusBadRequest)
-		default:
-			http.Error(w, "internal error", http.StatusInternalServerError)
-		}
+	if err := h.UseCase.Delete(r.Context(), id, userID); err != nil {
+		writeUseCaseError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(`{"message":"topic deleted"}`))
 }
+
+func parseIncludeArchived(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("include_archived")
+	if v == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/client"
+	"golangforum/internal/client/clienttest"
+	"golangforum/internal/usecase/mocks"
+)
+
+var testUsers = map[string]client.User{
+	"user":  {ID: 1, Username: "bob"},
+	"admin": {ID: 2, Username: "root", Role: client.RoleAdmin},
+}
+
+func TestTopicHandler_AdminOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	// use case не вызывается: без прав администратора запрос отклоняется до разбора тела
+	h := NewTopicHandler(mocks.NewMockTopicUseCase(ctrl), clienttest.NewAuthClient(t, testUsers))
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+	}{
+		{"Update", h.Update, `{"title":"Go","description":"Язык Go"}`},
+		{"Move", h.Move, `{"parent_id":3}`},
+	}
+	for _, tt := range tests {
+		for _, auth := range []struct {
+			token string
+			want  int
+		}{
+			{"", http.StatusUnauthorized},
+			{"unknown", http.StatusUnauthorized},
+			{"user", http.StatusForbidden},
+		} {
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/topics/1", strings.NewReader(tt.body))
+			r.SetPathValue("id", "1")
+			if auth.token != "" {
+				r.Header.Set("Authorization", "Bearer "+auth.token)
+			}
+			w := httptest.NewRecorder()
+
+			tt.handler(w, r)
+
+			assert.Equal(t, auth.want, w.Code, "%s with token %q", tt.name, auth.token)
+		}
+	}
+}
+
+func TestTopicHandler_Move_Admin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	topics := mocks.NewMockTopicUseCase(ctrl)
+	parentID := 3
+	topics.EXPECT().Move(gomock.Any(), 1, &parentID).Return(nil)
+	h := NewTopicHandler(topics, clienttest.NewAuthClient(t, testUsers))
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/topics/1/move", strings.NewReader(`{"parent_id":3}`))
+	r.SetPathValue("id", "1")
+	r.Header.Set("Authorization", "Bearer admin")
+	w := httptest.NewRecorder()
+
+	h.Move(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"golangforum/internal/client"
+	"golangforum/internal/model"
+	"golangforum/internal/usecase"
+)
+
+type TrashHandler struct {
+	UseCase    usecase.TrashUseCase
+	AuthClient *client.AuthClient
+}
+
+func NewTrashHandler(uc usecase.TrashUseCase, authClient *client.AuthClient) *TrashHandler {
+	return &TrashHandler{UseCase: uc, AuthClient: authClient}
+}
+
+// List godoc
+// @Summary Просмотреть корзину
+// @Description Возвращает страницу удаленных тем, постов или комментариев, начиная с удаленных последними. Общее количество передается в заголовке X-Total-Count. Доступно только администраторам
+// @Tags Корзина
+// @Accept json
+// @Produce json
+// @Param type query string true "Тип объектов: topic, post или comment"
+// @Param limit query int false "Количество записей (по умолчанию 20, не более 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.TrashItem "Удаленные объекты"
+// @Header 200 {integer} X-Total-Count "Общее количество объектов в корзине"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Недостаточно прав"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /trash [get]
+func (h *TrashHandler) List(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(h.AuthClient, w, r) {
+		return
+	}
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		writeBadRequest(w, r, "invalid query parameters", err)
+		return
+	}
+	items, total, err := h.UseCase.List(r.Context(), r.URL.Query().Get("type"), limit, offset)
+	if err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	json.NewEncoder(w).Encode(items)
+}
+
+// Restore godoc
+// @Summary Восстановить из корзины
+// @Description Восстанавливает тему, пост или комментарий вместе с вложенными объектами, удаленными вместе с ним. Объект, родитель которого удален, восстановить нельзя — сначала нужно восстановить родителя. Доступно только администраторам
+// @Tags Корзина
+// @Accept json
+// @Produce json
+// @Param item body model.TrashRestoreRequest true "Тип и ID объекта"
+// @Success 200 {object} map[string]string "Объект восстановлен"
+// @Failure 400 {object} model.ErrorResponse "Неверный запрос"
+// @Failure 401 {object} model.ErrorResponse "Не авторизован"
+// @Failure 403 {object} model.ErrorResponse "Недостаточно прав"
+// @Failure 404 {object} model.ErrorResponse "Объекта нет в корзине"
+// @Failure 409 {object} model.ErrorResponse "Родитель объекта удален"
+// @Failure 500 {object} model.ErrorResponse "Ошибка сервера"
+// @Router /trash/restore [post]
+func (h *TrashHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(h.AuthClient, w, r) {
+		return
+	}
+	var req model.TrashRestoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, r, "invalid request", nil)
+		return
+	}
+	defer r.Body.Close()
+	if req.ID <= 0 {
+		writeBadRequest(w, r, "invalid id", nil)
+		return
+	}
+	if err := h.UseCase.Restore(r.Context(), req.Type, req.ID); err != nil {
+		writeUseCaseError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "restored"})
+}
@@ -0,0 +1,179 @@
+// Package health отвечает на проверки оркестратора: /healthz — процесс жив, /readyz — сервис готов
+// принимать запросы, то есть запущен, не останавливается и все зависимости доступны
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Состояния сервиса и проверок в ответе /readyz
+const (
+	StatusOK          = "ok"
+	StatusFailed      = "failed"
+	StatusStarting    = "starting"
+	StatusDraining    = "draining"
+	StatusUnavailable = "unavailable"
+)
+
+// Причины недоступности зависимости в ответе /readyz. /readyz доступен без авторизации, поэтому исходная
+// ошибка в ответ не попадает, а пишется в журнал
+const (
+	ErrorUnreachable = "unreachable"
+	ErrorTimeout     = "timeout"
+)
+
+// Check проверяет одну зависимость; nil — зависимость доступна
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Result — итог проверки одной зависимости
+type Result struct {
+	Status   string  `json:"status"`
+	Error    string  `json:"error,omitempty"`
+	Duration float64 `json:"duration_ms"`
+}
+
+// Report — ответ /readyz
+type Report struct {
+	Status    string            `json:"status"`
+	Checks    map[string]Result `json:"checks,omitempty"`
+	CheckedAt time.Time         `json:"checked_at,omitzero"`
+}
+
+// Health хранит проверки зависимостей и состояние сервиса. Результаты проверок кешируются на ttl, чтобы
+// частые запросы /readyz от нескольких оркестраторов не нагружали базу и сервис авторизации
+type Health struct {
+	ttl     time.Duration
+	timeout time.Duration
+	checks  []namedCheck
+
+	ready    atomic.Bool
+	draining atomic.Bool
+
+	mu     sync.Mutex
+	cached Report
+}
+
+// New создает Health без проверок. Каждая проверка ограничена timeout, результаты живут ttl
+func New(ttl, timeout time.Duration) *Health {
+	return &Health{ttl: ttl, timeout: timeout}
+}
+
+// Add регистрирует проверку зависимости name. Вызывается до начала обработки запросов
+func (h *Health) Add(name string, check Check) {
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// SetReady отмечает, что сервис запущен; до этого /readyz отвечает 503 starting
+func (h *Health) SetReady() {
+	h.ready.Store(true)
+}
+
+// SetDraining отмечает начало остановки: /readyz отвечает 503 draining, чтобы балансировщик
+// перестал направлять новые запросы, пока текущие завершаются
+func (h *Health) SetDraining() {
+	h.draining.Store(true)
+}
+
+// Live отвечает 200, пока процесс способен обрабатывать запросы
+func (h *Health) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+// Ready отвечает 200, если сервис запущен, не останавливается и все проверки прошли, иначе 503.
+// В ответе — результат каждой проверки
+func (h *Health) Ready(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case h.draining.Load():
+		writeJSON(w, http.StatusServiceUnavailable, Report{Status: StatusDraining})
+		return
+	case !h.ready.Load():
+		writeJSON(w, http.StatusServiceUnavailable, Report{Status: StatusStarting})
+		return
+	}
+	report := h.Check(r.Context())
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
+}
+
+// Check возвращает результаты проверок: из кеша, если они моложе ttl, иначе выполняет все проверки
+// параллельно. Одновременные запросы ждут одного прогона
+func (h *Health) Check(ctx context.Context) Report {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.cached.CheckedAt.IsZero() && time.Since(h.cached.CheckedAt) < h.ttl {
+		return h.cached
+	}
+
+	results := make([]Result, len(h.checks))
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, c.name, c.check, h.timeout)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(h.checks)), CheckedAt: time.Now()}
+	for i, c := range h.checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+	// прерванная клиентом проверка ничего не говорит о зависимостях и не кешируется
+	if ctx.Err() == nil {
+		h.cached = report
+	}
+	return report
+}
+
+func run(ctx context.Context, name string, check Check, timeout time.Duration) Result {
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	err := check(checkCtx)
+	res := Result{Status: StatusOK, Duration: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		res.Status = StatusFailed
+		res.Error = reason(checkCtx, err)
+		log.Ctx(ctx).Warn().Err(err).Str("check", name).Msg("Readiness check failed")
+	}
+	return res
+}
+
+// reason сводит ошибку проверки к короткой причине для ответа /readyz
+func reason(ctx context.Context, err error) string {
+	var schemaErr *SchemaError
+	switch {
+	case errors.As(err, &schemaErr):
+		return schemaErr.Reason
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ErrorTimeout
+	}
+	return ErrorUnreachable
+}
+
+func writeJSON(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golangforum/internal/schema"
+)
+
+func ready(t *testing.T, h *Health) (int, Report) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.Ready(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var report Report
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	return w.Code, report
+}
+
+func TestLive(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	New(time.Second, time.Second).Live(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestReady_Lifecycle(t *testing.T) {
+	h := New(time.Second, time.Second)
+	h.Add("database", func(context.Context) error { return nil })
+
+	code, report := ready(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusStarting, report.Status)
+
+	h.SetReady()
+	code, report = ready(t, h)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+
+	h.SetDraining()
+	code, report = ready(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusDraining, report.Status)
+}
+
+func TestReady_FailedDependency(t *testing.T) {
+	h := New(time.Second, 50*time.Millisecond)
+	h.Add("database", func(context.Context) error { return nil })
+	h.Add("auth", func(ctx context.Context) error {
+		<-ctx.Done()
+		return errors.New("auth service unreachable")
+	})
+	h.SetReady()
+
+	code, report := ready(t, h)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, Result{Status: StatusFailed, Error: ErrorTimeout, Duration: report.Checks["auth"].Duration}, report.Checks["auth"])
+	assert.GreaterOrEqual(t, report.Checks["auth"].Duration, float64(50))
+}
+
+func TestReady_HidesErrorDetails(t *testing.T) {
+	h := New(time.Second, time.Second)
+	h.Add("database", func(context.Context) error {
+		return errors.New(`dial tcp 10.0.0.5:5432: password authentication failed for user "forum"`)
+	})
+	h.Add("migrations", func(context.Context) error { return checkStatus(schema.Status{Version: 4, Latest: 5, Pending: 1}) })
+	h.Add("schema", func(context.Context) error { return checkStatus(schema.Status{Version: 5, Dirty: true, Latest: 5}) })
+	h.SetReady()
+
+	w := httptest.NewRecorder()
+	h.Ready(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, ErrorUnreachable, report.Checks["database"].Error)
+	assert.Equal(t, ErrorPendingMigrations, report.Checks["migrations"].Error)
+	assert.Equal(t, ErrorDirtySchema, report.Checks["schema"].Error)
+	assert.NotContains(t, w.Body.String(), "10.0.0.5")
+	assert.NotContains(t, w.Body.String(), "forum")
+}
+
+func TestCheck_Cached(t *testing.T) {
+	calls := 0
+	h := New(time.Hour, time.Second)
+	h.Add("database", func(context.Context) error {
+		calls++
+		return nil
+	})
+
+	first := h.Check(context.Background())
+	second := h.Check(context.Background())
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, first, second)
+
+	h.ttl = 0
+	h.Check(context.Background())
+	assert.Equal(t, 2, calls)
+}
+
+func TestCheckStatus(t *testing.T) {
+	assert.NoError(t, checkStatus(schema.Status{Version: 5, Latest: 5}))
+	assert.EqualError(t, checkStatus(schema.Status{Version: 3, Latest: 5, Pending: 2}), "2 pending migrations: schema at 3, latest is 5")
+	assert.EqualError(t, checkStatus(schema.Status{Version: 5, Dirty: true, Latest: 5}), "migration 5 failed and left the schema dirty")
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"golangforum/internal/schema"
+)
+
+// Причины, по которым схема базы не готова
+const (
+	ErrorPendingMigrations = "pending migrations"
+	ErrorDirtySchema       = "dirty schema"
+)
+
+// SchemaError — схема базы не соответствует встроенным миграциям. Reason попадает в ответ /readyz
+type SchemaError struct {
+	Reason string
+	Status schema.Status
+}
+
+func (e *SchemaError) Error() string {
+	if e.Reason == ErrorDirtySchema {
+		return fmt.Sprintf("migration %d failed and left the schema dirty", e.Status.Version)
+	}
+	return fmt.Sprintf("%d %s: schema at %d, latest is %d", e.Status.Pending, e.Reason, e.Status.Version, e.Status.Latest)
+}
+
+// Migrations проверяет, что к базе применены все миграции versions (номера встроенных миграций, см. schema.Embedded)
+// и последняя из них не прервана. Версию хранит таблица schema_migrations, которую ведет golang-migrate
+func Migrations(db *sql.DB, versions []uint) Check {
+	return func(ctx context.Context) error {
+		var version uint
+		var dirty bool
+		err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		// без строки в schema_migrations не применено ни одной миграции
+		return checkStatus(schema.StatusOf(versions, version, dirty))
+	}
+}
+
+func checkStatus(s schema.Status) error {
+	switch {
+	case s.Dirty:
+		return &SchemaError{Reason: ErrorDirtySchema, Status: s}
+	case s.Pending > 0:
+		return &SchemaError{Reason: ErrorPendingMigrations, Status: s}
+	}
+	return nil
+}
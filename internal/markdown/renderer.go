@@ -0,0 +1,105 @@
+package markdown
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"regexp"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// DefaultCacheSize — количество отрендеренных текстов, хранимых в кэше по умолчанию
+const DefaultCacheSize = 1024
+
+// Renderer преобразует Markdown (CommonMark, таблицы GFM, блоки кода) в безопасный HTML.
+// Результат кэшируется по хэшу исходного текста, поэтому каждая ревизия текста рендерится один раз.
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[[sha256.Size]byte]*list.Element
+}
+
+type cacheEntry struct {
+	key  [sha256.Size]byte
+	html string
+}
+
+func NewRenderer(cacheSize int) *Renderer {
+	if cacheSize <= 0 {
+		cacheSize = DefaultCacheSize
+	}
+	return &Renderer{
+		md: goldmark.New(goldmark.WithExtensions(
+			extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+			extension.Strikethrough,
+		)),
+		policy: newPolicy(),
+		size:   cacheSize,
+		order:  list.New(),
+		items:  make(map[[sha256.Size]byte]*list.Element, cacheSize),
+	}
+}
+
+// Render возвращает очищенный HTML для исходного текста. При ошибке разбора возвращается пустая строка
+func (r *Renderer) Render(source string) string {
+	key := sha256.Sum256([]byte(source))
+	r.mu.Lock()
+	if el, ok := r.items[key]; ok {
+		r.order.MoveToFront(el)
+		html := el.Value.(*cacheEntry).html
+		r.mu.Unlock()
+		return html
+	}
+	r.mu.Unlock()
+
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(source), &buf); err != nil {
+		return ""
+	}
+	html := r.policy.Sanitize(buf.String())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if el, ok := r.items[key]; ok {
+		r.order.MoveToFront(el)
+		return html
+	}
+	r.items[key] = r.order.PushFront(&cacheEntry{key: key, html: html})
+	if r.order.Len() > r.size {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.items, oldest.Value.(*cacheEntry).key)
+	}
+	return html
+}
+
+// newPolicy описывает список разрешенных элементов и атрибутов; все остальное удаляется
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements(
+		"p", "br", "hr", "blockquote", "pre",
+		"h1", "h2", "h3", "h4", "h5", "h6",
+		"em", "strong", "del", "code",
+		"ul", "ol", "li",
+		"table", "thead", "tbody", "tr", "th", "td",
+	)
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+	p.AllowAttrs("href", "title").OnElements("a")
+	p.AllowAttrs("src", "alt", "title").OnElements("img")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.AllowRelativeURLs(true)
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
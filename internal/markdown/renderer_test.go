@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	r := NewRenderer(0)
+
+	html := r.Render("# Заголовок\n\n**жирный** и *курсив*, [ссылка](https://example.com)")
+
+	assert.Contains(t, html, "<h1>Заголовок</h1>")
+	assert.Contains(t, html, "<strong>жирный</strong>")
+	assert.Contains(t, html, "<em>курсив</em>")
+	assert.Contains(t, html, `href="https://example.com"`)
+	assert.Contains(t, html, `rel="nofollow noopener"`)
+}
+
+func TestRenderer_Render_FencedCode(t *testing.T) {
+	r := NewRenderer(0)
+
+	html := r.Render("```go\nfmt.Println(\"<b>hi</b>\")\n```")
+
+	assert.Contains(t, html, `<pre><code class="language-go">`)
+	assert.Contains(t, html, "&lt;b&gt;hi&lt;/b&gt;")
+}
+
+func TestRenderer_Render_Table(t *testing.T) {
+	r := NewRenderer(0)
+
+	html := r.Render("| a | b |\n|:--|--:|\n| 1 | 2 |")
+
+	assert.Contains(t, html, "<table>")
+	assert.Contains(t, html, "<th")
+	assert.Contains(t, html, "<td")
+	assert.Contains(t, html, `<th align="left">a</th>`)
+	assert.Contains(t, html, `<td align="right">2</td>`)
+}
+
+func TestRenderer_Render_Malicious(t *testing.T) {
+	r := NewRenderer(0)
+
+	cases := map[string]string{
+		"script tag":         "<script>alert(1)</script>",
+		"inline script":      "text <script>alert(1)</script> text",
+		"javascript link":    "[click](javascript:alert(1))",
+		"javascript mixed":   "[click](JaVaScRiPt:alert(1))",
+		"javascript encoded": "[click](&#106;avascript:alert(1))",
+		"javascript autolnk": "<javascript:alert(1)>",
+		"data uri image":     "![x](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)",
+		"event handler":      `<img src="x.png" onerror="alert(1)">`,
+		"event handler html": `<a href="https://example.com" onclick="alert(1)">x</a>`,
+		"iframe":             `<iframe src="https://evil.example"></iframe>`,
+		"style attribute":    `<p style="background:url(javascript:alert(1))">x</p>`,
+		"svg onload":         `<svg onload="alert(1)"></svg>`,
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			html := strings.ToLower(r.Render(src))
+
+			assert.NotContains(t, html, "<script")
+			assert.NotContains(t, html, `="javascript:`)
+			assert.NotContains(t, html, `="data:`)
+			assert.NotContains(t, html, "onerror")
+			assert.NotContains(t, html, "onclick")
+			assert.NotContains(t, html, "onload")
+			assert.NotContains(t, html, "<iframe")
+			assert.NotContains(t, html, "<svg")
+			assert.NotContains(t, html, "style=")
+		})
+	}
+}
+
+func TestRenderer_Render_Cache(t *testing.T) {
+	r := NewRenderer(2)
+
+	first := r.Render("*a*")
+	assert.Equal(t, first, r.Render("*a*"))
+	assert.Equal(t, 1, r.order.Len())
+
+	r.Render("*b*")
+	r.Render("*c*")
+	assert.Equal(t, 2, r.order.Len())
+	assert.Equal(t, "<p><em>a</em></p>\n", r.Render("*a*"))
+}
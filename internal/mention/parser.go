@@ -0,0 +1,59 @@
+package mention
+
+import (
+	"strings"
+	"unicode"
+
+	"golangforum/internal/model"
+)
+
+// MaxUsernameLength ограничивает длину имени в упоминании; более длинные последовательности не считаются упоминаниями
+const MaxUsernameLength = 32
+
+// Parse находит в тексте упоминания вида @username и возвращает их позиции в символах.
+// Символ @ внутри слова (например, в адресе почты) упоминанием не считается,
+// завершающие точки и дефисы к имени не относятся.
+func Parse(text string) []model.MentionRange {
+	runes := []rune(text)
+	var res []model.MentionRange
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' {
+			continue
+		}
+		if i > 0 && (isNameRune(runes[i-1]) || runes[i-1] == '@') {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isNameRune(runes[end]) {
+			end++
+		}
+		next := end
+		for end > i+1 && (runes[end-1] == '.' || runes[end-1] == '-') {
+			end--
+		}
+		if name := runes[i+1 : end]; len(name) > 0 && len(name) <= MaxUsernameLength {
+			res = append(res, model.MentionRange{Username: string(name), Start: i, End: end})
+		}
+		i = next - 1
+	}
+	return res
+}
+
+// Usernames возвращает уникальные имена из найденных упоминаний в порядке их появления, без учета регистра
+func Usernames(ranges []model.MentionRange) []string {
+	seen := make(map[string]struct{}, len(ranges))
+	var res []string
+	for _, r := range ranges {
+		key := strings.ToLower(r.Username)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		res = append(res, r.Username)
+	}
+	return res
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
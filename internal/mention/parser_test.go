@@ -0,0 +1,33 @@
+package mention
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golangforum/internal/model"
+)
+
+func TestParse(t *testing.T) {
+	ranges := Parse("@alice привет, @боб_1! Почта bob@example.com, конец @carol.")
+
+	assert.Equal(t, []model.MentionRange{
+		{Username: "alice", Start: 0, End: 6},
+		{Username: "боб_1", Start: 15, End: 21},
+		{Username: "carol", Start: 52, End: 58},
+	}, ranges)
+}
+
+func TestParse_NoMentions(t *testing.T) {
+	assert.Empty(t, Parse("просто текст, @ и @@ и mail@host"))
+}
+
+func TestParse_TooLongUsername(t *testing.T) {
+	assert.Empty(t, Parse("@"+strings.Repeat("a", MaxUsernameLength+1)))
+}
+
+func TestUsernames(t *testing.T) {
+	names := Usernames(Parse("@Alice @bob @alice @BOB @carol"))
+
+	assert.Equal(t, []string{"Alice", "bob", "carol"}, names)
+}
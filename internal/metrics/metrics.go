@@ -0,0 +1,165 @@
+// Package metrics регистрирует метрики Prometheus сервиса форума и отдает их по /metrics.
+// Metrics передается в обработчики, use case и клиент авторизации; nil отключает учет, поэтому
+// в тестах вместо метрик можно передавать nil
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "forum"
+
+// Metrics хранит метрики сервиса в собственном реестре, чтобы тесты и несколько экземпляров не конфликтовали
+// с глобальным prometheus.DefaultRegisterer
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	wsConnections prometheus.Gauge
+	chatMessages  *prometheus.CounterVec
+
+	authDuration *prometheus.HistogramVec
+
+	postsCreated    prometheus.Counter
+	commentsCreated prometheus.Counter
+}
+
+// New создает и регистрирует метрики, включая метрики среды выполнения Go и процесса
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Количество обработанных HTTP-запросов по маршруту и статусу",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Время обработки HTTP-запросов",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		wsConnections: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "websocket_connections",
+			Help:      "Открытые WebSocket-соединения чата",
+		}),
+		chatMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "chat_messages_total",
+			Help:      "Сообщения чата: in — получены от клиентов, out — отправлены клиентам",
+		}, []string{"direction"}),
+		authDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "auth_request_duration_seconds",
+			Help:      "Время проверки токена в сервисе авторизации по результату",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"result"}),
+		postsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "posts_created_total",
+			Help:      "Созданные посты",
+		}),
+		commentsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "comments_created_total",
+			Help:      "Созданные комментарии",
+		}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.wsConnections,
+		m.chatMessages,
+		m.authDuration,
+		m.postsCreated,
+		m.commentsCreated,
+	)
+	return m
+}
+
+// Handler отдает метрики в формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB добавляет статистику пула соединений из sql.DB.Stats
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveHTTP учитывает обработанный запрос. route — шаблон маршрута из ServeMux, а не путь,
+// чтобы число рядов не зависело от ID в адресах
+func (m *Metrics) ObserveHTTP(method, route string, status int, d time.Duration) {
+	if m == nil {
+		return
+	}
+	if route == "" {
+		route = "unmatched"
+	}
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+// WebSocketOpened и WebSocketClosed отслеживают число открытых соединений чата
+func (m *Metrics) WebSocketOpened() {
+	if m != nil {
+		m.wsConnections.Inc()
+	}
+}
+
+func (m *Metrics) WebSocketClosed() {
+	if m != nil {
+		m.wsConnections.Dec()
+	}
+}
+
+// ChatMessageReceived учитывает сообщение, полученное от клиента чата
+func (m *Metrics) ChatMessageReceived() {
+	if m != nil {
+		m.chatMessages.WithLabelValues("in").Inc()
+	}
+}
+
+// ChatMessagesSent учитывает n сообщений, разосланных клиентам чата
+func (m *Metrics) ChatMessagesSent(n int) {
+	if m != nil {
+		m.chatMessages.WithLabelValues("out").Add(float64(n))
+	}
+}
+
+// ObserveAuth учитывает запрос к сервису авторизации; ошибка и отказ в токене считаются как error
+func (m *Metrics) ObserveAuth(d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.authDuration.WithLabelValues(result).Observe(d.Seconds())
+}
+
+func (m *Metrics) PostCreated() {
+	if m != nil {
+		m.postsCreated.Inc()
+	}
+}
+
+func (m *Metrics) CommentCreated() {
+	if m != nil {
+		m.commentsCreated.Inc()
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_Exposition(t *testing.T) {
+	m := New()
+
+	m.ObserveHTTP(http.MethodGet, "GET /api/v1/topics/{topic_id}", http.StatusOK, 20*time.Millisecond)
+	m.ObserveHTTP(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+	m.WebSocketOpened()
+	m.WebSocketOpened()
+	m.WebSocketClosed()
+	m.ChatMessageReceived()
+	m.ChatMessagesSent(3)
+	m.ObserveAuth(10*time.Millisecond, nil)
+	m.ObserveAuth(time.Second, errors.New("unavailable"))
+	m.PostCreated()
+	m.CommentCreated()
+	m.CommentCreated()
+
+	body := scrape(t, m)
+	for _, line := range []string{
+		`forum_http_requests_total{method="GET",route="GET /api/v1/topics/{topic_id}",status="200"} 1`,
+		`forum_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`forum_http_request_duration_seconds_count{method="GET",route="GET /api/v1/topics/{topic_id}",status="200"} 1`,
+		`forum_websocket_connections 1`,
+		`forum_chat_messages_total{direction="in"} 1`,
+		`forum_chat_messages_total{direction="out"} 3`,
+		`forum_auth_request_duration_seconds_count{result="ok"} 1`,
+		`forum_auth_request_duration_seconds_count{result="error"} 1`,
+		`forum_posts_created_total 1`,
+		`forum_comments_created_total 2`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, body, line)
+	}
+}
+
+func TestMetrics_NilIsNoop(t *testing.T) {
+	var m *Metrics
+
+	assert.NotPanics(t, func() {
+		m.ObserveHTTP(http.MethodGet, "/", http.StatusOK, time.Millisecond)
+		m.WebSocketOpened()
+		m.WebSocketClosed()
+		m.ChatMessageReceived()
+		m.ChatMessagesSent(1)
+		m.ObserveAuth(time.Millisecond, nil)
+		m.PostCreated()
+		m.CommentCreated()
+	})
+}
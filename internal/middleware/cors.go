@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	corsMethods       = strings.Join([]string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, ",")
+	corsHeaders       = strings.Join([]string{"Content-Type", "Authorization"}, ",")
+	corsExposeHeaders = strings.Join([]string{"Location", "X-Total-Count", "Deprecation", "Link"}, ",")
+)
+
+// CORS разрешает браузерам запросы с источников из origins. Разрешенный источник возвращается в
+// Access-Control-Allow-Origin (или *, если разрешены все), поэтому ответ помечается Vary: Origin.
+// Предварительный запрос OPTIONS обрабатывается здесь же: браузер может кешировать его ответ maxAge;
+// с неразрешенного источника он отклоняется с 403
+func CORS(next http.Handler, origins *Origins, maxAge time.Duration) http.Handler {
+	maxAgeSeconds := strconv.Itoa(int(maxAge.Seconds()))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		origin := r.Header.Get("Origin")
+		if !origins.Any() {
+			h.Add("Vary", "Origin")
+		}
+		allowed := origins.Allowed(origin)
+		if allowed {
+			if origins.Any() {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			h.Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		}
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		if !allowed {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		h.Set("Access-Control-Allow-Methods", corsMethods)
+		h.Set("Access-Control-Allow-Headers", corsHeaders)
+		h.Set("Access-Control-Max-Age", maxAgeSeconds)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader — заголовок, в котором клиент или прокси передает идентификатор запроса, а сервер его возвращает
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength ограничивает чужой идентификатор, чтобы он не раздувал логи
+const maxRequestIDLength = 128
+
+type requestInfoKey struct{}
+
+// requestInfo — данные запроса, которые дополняются по ходу обработки и попадают в итоговую запись лога
+type requestInfo struct {
+	id     string
+	logger *zerolog.Logger
+	route  string
+}
+
+func info(ctx context.Context) *requestInfo {
+	ri, _ := ctx.Value(requestInfoKey{}).(*requestInfo)
+	return ri
+}
+
+// Logging присваивает запросу идентификатор (берет его из X-Request-ID, если он задан и допустим) и кладет
+// в контекст логгер с этим идентификатором: его возвращает zerolog.Ctx(ctx) в обработчиках и use case.
+// После обработки пишет одну запись о запросе со статусом, длительностью, размером ответа, маршрутом
+// и пользователем
+func Logging(next http.Handler, base zerolog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		lc := base.With().Str("request_id", id)
+		if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+			lc = lc.Str("trace_id", sc.TraceID().String())
+		}
+		// WithContext сохраняет копию логгера; UpdateLogger меняет именно ее, поэтому запоминается указатель из контекста
+		ctx := lc.Logger().WithContext(r.Context())
+		logger := zerolog.Ctx(ctx)
+		ri := &requestInfo{id: id, logger: logger}
+		ctx = context.WithValue(ctx, requestInfoKey{}, ri)
+
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		status := rw.statusCode()
+		var event *zerolog.Event
+		switch {
+		case status >= http.StatusInternalServerError:
+			event = logger.Error()
+		case status >= http.StatusBadRequest:
+			event = logger.Warn()
+		default:
+			event = logger.Info()
+		}
+		event.
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Str("route", ri.route).
+			Int("status", status).
+			Int64("bytes", rw.bytes).
+			Dur("duration", time.Since(start)).
+			Str("remote_addr", r.RemoteAddr).
+			Str("user_agent", r.UserAgent()).
+			Msg("request completed")
+	})
+}
+
+// Route запоминает шаблон маршрута, выбранный mux, для записи о запросе и называет им span запроса.
+// Оборачивает сам mux: только ему достается запрос, в котором ServeMux заполняет Pattern
+func Route(mux http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		if ri := info(r.Context()); ri != nil {
+			ri.route = r.Pattern
+		}
+		if span := trace.SpanFromContext(r.Context()); span.IsRecording() && r.Pattern != "" {
+			span.SetName(r.Pattern)
+			span.SetAttributes(attribute.String("http.route", r.Pattern))
+		}
+	})
+}
+
+// RequestID возвращает идентификатор запроса, присвоенный Logging, или пустую строку
+func RequestID(ctx context.Context) string {
+	if ri := info(ctx); ri != nil {
+		return ri.id
+	}
+	return ""
+}
+
+// UpdateLogger добавляет поля к логгеру запроса; они попадут во все последующие записи, включая итоговую.
+// Вне Logging ничего не делает
+func UpdateLogger(ctx context.Context, update func(zerolog.Context) zerolog.Context) {
+	if ri := info(ctx); ri != nil {
+		ri.logger.UpdateContext(update)
+	}
+}
+
+// SetUserID отмечает в логе запроса пользователя, от имени которого он выполняется
+func SetUserID(ctx context.Context, userID int) {
+	UpdateLogger(ctx, func(c zerolog.Context) zerolog.Context {
+		return c.Int("user_id", userID)
+	})
+}
+
+// validRequestID пропускает только короткие идентификаторы из букв, цифр и знаков -_.:
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// responseWriter запоминает статус и размер ответа. Hijack нужен для перехода на WebSocket
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// statusCode возвращает отправленный статус; если обработчик ничего не записал, net/http ответит 200
+func (w *responseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking is not supported")
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
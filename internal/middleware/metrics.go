@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"golangforum/internal/metrics"
+)
+
+// Metrics учитывает запросы в метриках по методу, шаблону маршрута и статусу. Маршрут берется из Route,
+// поэтому обертка ставится внутри Logging
+func Metrics(next http.Handler, m *metrics.Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		var route string
+		if ri := info(r.Context()); ri != nil {
+			route = ri.route
+		}
+		m.ObserveHTTP(metricMethod(r.Method), route, rw.statusCode(), time.Since(start))
+	})
+}
+
+// metricMethod возвращает метод для метки method. Метод запроса задает клиент, поэтому все нестандартные
+// методы учитываются как "other", иначе каждый из них порождал бы новые временные ряды
+func metricMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return "other"
+}
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golangforum/internal/metrics"
+)
+
+var ok = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	h := CORS(ok, NewOrigins([]string{"https://forum.example"}), 10*time.Minute)
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/topics", nil)
+	r.Header.Set("Origin", "https://forum.example")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://forum.example", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+}
+
+func TestCORS_ForeignOrigin(t *testing.T) {
+	h := CORS(ok, NewOrigins([]string{"https://forum.example"}), 10*time.Minute)
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/topics", nil)
+	r.Header.Set("Origin", "https://evil.example")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, []string{"Origin"}, w.Header().Values("Vary"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	h := CORS(ok, NewOrigins([]string{"https://forum.example"}), 10*time.Minute)
+	r := httptest.NewRequest(http.MethodOptions, "/api/v1/posts", nil)
+	r.Header.Set("Origin", "https://forum.example")
+	r.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://forum.example", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Content-Type,Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+	assert.Contains(t, w.Header().Values("Vary"), "Access-Control-Request-Method")
+}
+
+func TestCORS_PreflightForeignOrigin(t *testing.T) {
+	h := CORS(ok, NewOrigins(nil), 10*time.Minute)
+	r := httptest.NewRequest(http.MethodOptions, "/api/v1/posts", nil)
+	r.Header.Set("Origin", "https://evil.example")
+	r.Header.Set("Access-Control-Request-Method", "DELETE")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	h := CORS(ok, NewOrigins([]string{"*"}), time.Minute)
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/topics", nil)
+	r.Header.Set("Origin", "https://any.example")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Values("Vary"))
+}
+
+func TestOrigins_CheckOrigin(t *testing.T) {
+	origins := NewOrigins([]string{"https://forum.example"})
+	cases := []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{"no origin", "", true},
+		{"same host", "http://api.example", true},
+		{"allowed", "https://Forum.example", true},
+		{"foreign", "https://evil.example", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.example/api/v1/chat/ws", nil)
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+			assert.Equal(t, tc.want, origins.CheckOrigin(r))
+		})
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	opts := SecurityOptions{
+		ContentSecurityPolicy: "default-src 'none'",
+		SwaggerPolicy:         "default-src 'self'",
+		SwaggerPrefix:         "/swagger/",
+		FrameOptions:          "DENY",
+		HSTSMaxAge:            time.Hour,
+	}
+	h := SecurityHeaders(ok, opts)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/topics", nil))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil))
+	assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/topics", nil)
+	r.TLS = &tls.ConnectionState{}
+	h.ServeHTTP(w, r)
+	assert.Equal(t, "max-age=3600; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+}
+
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(line, &m))
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestLogging_AccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/topics/{topic_id}", func(w http.ResponseWriter, r *http.Request) {
+		SetUserID(r.Context(), 42)
+		zerolog.Ctx(r.Context()).Info().Msg("handler")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("missing"))
+	})
+	h := Logging(Route(mux), zerolog.New(&buf))
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/topics/7", nil)
+	r.Header.Set(RequestIDHeader, "req-1")
+	w := httptest.NewRecorder()
+
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, "req-1", w.Header().Get(RequestIDHeader))
+	lines := logLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, float64(42), lines[0]["user_id"])
+	access := lines[1]
+	assert.Equal(t, "warn", access["level"])
+	assert.Equal(t, "req-1", access["request_id"])
+	assert.Equal(t, float64(42), access["user_id"])
+	assert.Equal(t, "GET /api/v1/topics/{topic_id}", access["route"])
+	assert.Equal(t, float64(http.StatusNotFound), access["status"])
+	assert.Equal(t, float64(len("missing")), access["bytes"])
+	assert.Contains(t, access, "duration")
+}
+
+func TestLogging_GeneratesRequestID(t *testing.T) {
+	var seen string
+	h := Logging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r.Context())
+	}), zerolog.Nop())
+
+	for _, incoming := range []string{"", "bad id\nwith newline", string(make([]byte, 200))} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if incoming != "" {
+			r.Header.Set(RequestIDHeader, incoming)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		assert.NotEmpty(t, seen)
+		assert.NotEqual(t, incoming, seen)
+		assert.Equal(t, seen, w.Header().Get(RequestIDHeader))
+	}
+}
+
+func TestMetrics_NonStandardMethod(t *testing.T) {
+	m := metrics.New()
+	h := Metrics(ok, m)
+	for _, method := range []string{http.MethodGet, "PROPFIND", "X-RANDOM-1", "X-RANDOM-2"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/", nil))
+	}
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	assert.Contains(t, body, `forum_http_requests_total{method="GET",route="unmatched",status="200"} 1`)
+	assert.Contains(t, body, `forum_http_requests_total{method="other",route="unmatched",status="200"} 3`)
+	assert.NotContains(t, body, "PROPFIND")
+}
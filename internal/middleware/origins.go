@@ -0,0 +1,56 @@
+// Package middleware содержит обертки над http.Handler, общие для всех маршрутов: журнал запросов, метрики, CORS
+// и заголовки безопасности
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Origins — список источников, которым разрешены запросы из браузера. Один и тот же список проверяют
+// CORS и рукопожатие WebSocket, чтобы чат был доступен ровно тем сайтам, что и API
+type Origins struct {
+	any     bool
+	allowed map[string]bool
+}
+
+// NewOrigins создает список из источников вида https://example.com; "*" разрешает любой источник.
+// Пустой список запрещает запросы с чужих сайтов
+func NewOrigins(list []string) *Origins {
+	o := &Origins{allowed: make(map[string]bool, len(list))}
+	for _, origin := range list {
+		if origin == "*" {
+			o.any = true
+			continue
+		}
+		o.allowed[strings.ToLower(origin)] = true
+	}
+	return o
+}
+
+// Any сообщает, разрешен ли любой источник
+func (o *Origins) Any() bool {
+	return o.any
+}
+
+// Allowed сообщает, разрешен ли источник из заголовка Origin
+func (o *Origins) Allowed(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	return o.any || o.allowed[strings.ToLower(origin)]
+}
+
+// CheckOrigin подходит для websocket.Upgrader: пропускает клиентов без заголовка Origin (не браузеры),
+// страницы с того же хоста и источники из списка
+func (o *Origins) CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return o.Allowed(origin)
+}
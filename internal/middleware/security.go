@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SecurityOptions — заголовки безопасности, которые добавляются к каждому ответу
+type SecurityOptions struct {
+	// ContentSecurityPolicy — политика для ответов API; пустая строка отключает заголовок
+	ContentSecurityPolicy string
+	// SwaggerPolicy — политика для страниц под SwaggerPrefix: документации нужны собственные скрипты и стили
+	SwaggerPolicy string
+	SwaggerPrefix string
+	// FrameOptions — значение X-Frame-Options (DENY или SAMEORIGIN); пустая строка отключает заголовок
+	FrameOptions string
+	// HSTSMaxAge — срок Strict-Transport-Security; заголовок отправляется только по TLS, 0 отключает его
+	HSTSMaxAge time.Duration
+}
+
+// SecurityHeaders добавляет к ответам nosniff, Referrer-Policy, X-Frame-Options, Content-Security-Policy
+// и, для соединений по TLS, Strict-Transport-Security
+func SecurityHeaders(next http.Handler, opts SecurityOptions) http.Handler {
+	hsts := "max-age=" + strconv.Itoa(int(opts.HSTSMaxAge.Seconds())) + "; includeSubDomains"
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("Referrer-Policy", "no-referrer")
+		if opts.FrameOptions != "" {
+			h.Set("X-Frame-Options", opts.FrameOptions)
+		}
+		policy := opts.ContentSecurityPolicy
+		if opts.SwaggerPrefix != "" && strings.HasPrefix(r.URL.Path, opts.SwaggerPrefix) {
+			policy = opts.SwaggerPolicy
+		}
+		if policy != "" {
+			h.Set("Content-Security-Policy", policy)
+		}
+		if r.TLS != nil && opts.HSTSMaxAge > 0 {
+			h.Set("Strict-Transport-Security", hsts)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
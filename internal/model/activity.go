@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Типы записей в ленте активности
+const (
+	ActivityPost    = "post"
+	ActivityComment = "comment"
+	ActivityMessage = "message"
+)
+
+// Activity представляет собой запись ленты последней активности
+// @Description Пост, комментарий или сообщение чата с автором и временем создания
+type Activity struct {
+	Type      string    `json:"type"`               // Тип записи: post, comment или message
+	ID        int       `json:"id"`                 // ID поста, комментария или сообщения
+	TopicID   *int      `json:"topic_id,omitempty"` // ID темы поста или комментария
+	PostID    *int      `json:"post_id,omitempty"`  // ID поста комментария
+	UserID    int       `json:"user_id"`            // ID автора
+	Username  string    `json:"username"`           // Имя автора
+	Text      string    `json:"text"`               // Заголовок поста, начало текста комментария или сообщения
+	Timestamp time.Time `json:"timestamp"`          // Время создания
+}
+
+// UserContent представляет собой количество объектов пользователя, которые стирает очистка его контента
+// @Description Удаленные в корзину объекты тоже учитываются
+type UserContent struct {
+	UserID   int `json:"user_id"`  // ID пользователя
+	Posts    int `json:"posts"`    // Посты пользователя
+	Comments int `json:"comments"` // Комментарии пользователя
+	Replies  int `json:"replies"`  // Комментарии других пользователей к его постам, стираемые вместе с постами
+	Messages int `json:"messages"` // Сообщения чата
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Attachment представляет собой файл, прикрепленный к посту или комментарию
+// @Description Метаданные загруженного файла. Пока файл не привязан к посту или комментарию, post_id и comment_id отсутствуют
+type Attachment struct {
+	ID           int       `json:"id"`                   // ID вложения
+	PostID       *int      `json:"post_id,omitempty"`    // ID поста, к которому прикреплен файл
+	CommentID    *int      `json:"comment_id,omitempty"` // ID комментария, к которому прикреплен файл
+	UserID       int       `json:"user_id"`              // ID пользователя, загрузившего файл
+	Username     string    `json:"username"`             // Имя пользователя
+	Filename     string    `json:"filename"`             // Исходное имя файла
+	ContentType  string    `json:"content_type"`         // MIME-тип, определенный по содержимому
+	Size         int64     `json:"size"`                 // Размер файла в байтах
+	HasThumbnail bool      `json:"has_thumbnail"`        // Есть ли у файла миниатюра
+	Timestamp    time.Time `json:"timestamp"`            // Время загрузки
+	StorageKey   string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+}
@@ -1,28 +1,33 @@
 package model
 
-import (
-	"errors"
-	"strings"
-	"time"
-)
+import "time"
 
 // Comment представляет собой комментарий на посте
 // @Description Структура комментария с необходимыми полями для хранения данных о комментарии
 type Comment struct {
-	ID        int       `json:"id"`        // ID комментария
-	PostID    int       `json:"post_id"`   // ID поста, к которому относится комментарий
-	UserID    int       `json:"user_id"`   // ID пользователя, оставившего комментарий
-	Username  string    `json:"username"`  // Имя пользователя
-	Content   string    `json:"content"`   // Текст комментария
-	Timestamp time.Time `json:"timestamp"` // Время создания комментария
+	ID            int             `json:"id"`                       // ID комментария
+	PostID        int             `json:"post_id"`                  // ID поста, к которому относится комментарий
+	UserID        int             `json:"user_id"`                  // ID пользователя, оставившего комментарий
+	Username      string          `json:"username"`                 // Имя пользователя
+	Content       string          `json:"content"`                  // Текст комментария
+	ContentHTML   string          `json:"content_html"`             // Текст, отрендеренный из Markdown в безопасный HTML
+	Timestamp     time.Time       `json:"timestamp"`                // Время создания комментария
+	Mentions      []MentionRange  `json:"mentions,omitempty"`       // Упоминания пользователей в тексте
+	AttachmentIDs []int           `json:"attachment_ids,omitempty"` // ID ранее загруженных файлов, которые нужно прикрепить при создании
+	Attachments   []Attachment    `json:"attachments,omitempty"`    // Прикрепленные файлы
+	Score         int             `json:"score"`                    // Рейтинг: разница голосов за и против
+	Upvotes       int             `json:"upvotes"`                  // Количество голосов за
+	Downvotes     int             `json:"downvotes"`                // Количество голосов против
+	Reactions     []ReactionCount `json:"reactions,omitempty"`      // Реакции
+	MyVote        int             `json:"my_vote,omitempty"`        // Голос текущего пользователя, если он авторизован
 }
 
+// Validate нормализует текст комментария и возвращает *ValidationError со всеми некорректными полями
 func (c *Comment) Validate() error {
+	var v validator
 	if c.PostID <= 0 {
-		return errors.New("post_id must be positive")
+		v.add("post_id", "must be positive")
 	}
-	if strings.TrimSpace(c.Content) == "" {
-		return errors.New("content cannot be empty")
-	}
-	return nil
+	v.text("content", &c.Content, limits.Comment, true)
+	return v.err()
 }
@@ -0,0 +1,36 @@
+package model
+
+import "strings"
+
+// ErrorResponse представляет собой тело ответа с ошибкой
+// @Description Единый формат ошибок API: code — машиночитаемый код, message — описание для человека
+type ErrorResponse struct {
+	Code      string       `json:"code"`                 // Машиночитаемый код ошибки
+	Message   string       `json:"message"`              // Описание ошибки
+	Details   []FieldError `json:"details,omitempty"`    // Ошибки в отдельных полях запроса
+	RequestID string       `json:"request_id,omitempty"` // ID запроса для поиска в журналах
+}
+
+// FieldError описывает ошибку в одном поле запроса
+type FieldError struct {
+	Field   string `json:"field"`   // Имя поля JSON или параметра строки запроса
+	Message string `json:"message"` // Что не так со значением
+}
+
+// ValidationError перечисляет поля, не прошедшие проверку
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.Field + ": " + f.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// InvalidField возвращает ошибку проверки одного поля
+func InvalidField(field, message string) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: message}}}
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+const (
+	MentionSourcePost    = "post"
+	MentionSourceComment = "comment"
+	MentionSourceMessage = "message"
+)
+
+// Mention представляет собой упоминание пользователя в посте, комментарии или сообщении чата
+// @Description Структура упоминания с источником и упомянутым пользователем
+type Mention struct {
+	ID         int       `json:"id"`          // ID упоминания
+	SourceType string    `json:"source_type"` // Тип источника: post, comment или message
+	SourceID   int       `json:"source_id"`   // ID источника упоминания
+	Username   string    `json:"username"`    // Имя упомянутого пользователя
+	Author     string    `json:"author"`      // Имя автора упоминания
+	Timestamp  time.Time `json:"timestamp"`   // Время создания упоминания
+}
+
+// MentionRange представляет собой позицию упоминания в тексте
+// @Description Диапазон символов [start, end), занимаемый упоминанием @username
+type MentionRange struct {
+	Username string `json:"username"` // Имя упомянутого пользователя
+	Start    int    `json:"start"`    // Позиция символа @ (в символах, не в байтах)
+	End      int    `json:"end"`      // Позиция, следующая за последним символом имени
+}
@@ -1,27 +1,22 @@
 package model
 
-import (
-	"errors"
-	"strings"
-	"time"
-)
+import "time"
 
 // Message представляет собой сообщение от пользователя
 // @Description Структура сообщения с необходимыми полями для хранения данных о сообщении
 type Message struct {
-	ID        int       `json:"id"`        // ID сообщения
-	UserID    int       `json:"user_id"`   // ID пользователя, отправившего сообщение
-	Username  string    `json:"username"`  // Имя пользователя
-	Content   string    `json:"content"`   // Текст сообщения
-	Timestamp time.Time `json:"timestamp"` // Время отправки сообщения
+	ID        int            `json:"id"`                 // ID сообщения
+	UserID    int            `json:"user_id"`            // ID пользователя, отправившего сообщение
+	Username  string         `json:"username"`           // Имя пользователя
+	Content   string         `json:"content"`            // Текст сообщения
+	Timestamp time.Time      `json:"timestamp"`          // Время отправки сообщения
+	Mentions  []MentionRange `json:"mentions,omitempty"` // Упоминания пользователей в тексте
 }
 
+// Validate нормализует имя пользователя и текст сообщения и возвращает *ValidationError со всеми некорректными полями
 func (m *Message) Validate() error {
-	if strings.TrimSpace(m.Username) == "" {
-		return errors.New("username cannot be empty")
-	}
-	if strings.TrimSpace(m.Content) == "" {
-		return errors.New("content cannot be empty")
-	}
-	return nil
+	var v validator
+	v.text("username", &m.Username, limits.Username, false)
+	v.text("content", &m.Content, limits.Message, true)
+	return v.err()
 }
@@ -1,32 +1,49 @@
 package model
 
-import (
-	"errors"
-	"strings"
-	"time"
-)
+import "time"
 
 // Post представляет собой пост на форуме
 // @Description Структура поста с необходимыми полями для хранения данных о посте
 type Post struct {
-	ID        int       `json:"id"`        // ID поста
-	TopicID   int       `json:"topic_id"`  // ID темы, к которой относится пост
-	Title     string    `json:"title"`     // Заголовок поста
-	Content   string    `json:"content"`   // Текст поста
-	UserID    int       `json:"user_id"`   // ID пользователя, создавшего пост
-	Username  string    `json:"username"`  // Имя пользователя
-	Timestamp time.Time `json:"timestamp"` // Время создания поста
+	ID             int             `json:"id"`                       // ID поста
+	TopicID        int             `json:"topic_id"`                 // ID темы, к которой относится пост
+	Title          string          `json:"title"`                    // Заголовок поста
+	Content        string          `json:"content"`                  // Текст поста
+	ContentHTML    string          `json:"content_html"`             // Текст, отрендеренный из Markdown в безопасный HTML
+	UserID         int             `json:"user_id"`                  // ID пользователя, создавшего пост
+	Username       string          `json:"username"`                 // Имя пользователя
+	Timestamp      time.Time       `json:"timestamp"`                // Время создания поста
+	Mentions       []MentionRange  `json:"mentions,omitempty"`       // Упоминания пользователей в тексте
+	AttachmentIDs  []int           `json:"attachment_ids,omitempty"` // ID ранее загруженных файлов, которые нужно прикрепить при создании
+	Attachments    []Attachment    `json:"attachments,omitempty"`    // Прикрепленные файлы
+	Score          int             `json:"score"`                    // Рейтинг: разница голосов за и против
+	Upvotes        int             `json:"upvotes"`                  // Количество голосов за
+	Downvotes      int             `json:"downvotes"`                // Количество голосов против
+	Reactions      []ReactionCount `json:"reactions,omitempty"`      // Реакции
+	MyVote         int             `json:"my_vote,omitempty"`        // Голос текущего пользователя, если он авторизован
+	Tags           []string        `json:"tags,omitempty"`           // Теги поста
+	Breadcrumbs    []TopicRef      `json:"breadcrumbs,omitempty"`    // Путь от темы верхнего уровня до темы поста
+	CommentCount   int             `json:"comment_count"`            // Количество комментариев
+	LastActivityAt time.Time       `json:"last_activity_at"`         // Время создания поста или последнего комментария к нему
+	Comments       []Comment       `json:"comments,omitempty"`       // Комментарии, если они запрошены вместе с постом
 }
 
+// PostUpdateRequest представляет собой запрос на изменение поста
+// @Description Отсутствующие поля не изменяются; переданный tags заменяет теги поста целиком, пустой список удаляет их
+type PostUpdateRequest struct {
+	ID      int       `json:"id"`                // ID поста (в /api/v1 берется из пути)
+	Title   *string   `json:"title,omitempty"`   // Новый заголовок
+	Content *string   `json:"content,omitempty"` // Новый текст
+	Tags    *[]string `json:"tags,omitempty"`    // Новый список тегов
+}
+
+// Validate нормализует заголовок и текст поста и возвращает *ValidationError со всеми некорректными полями
 func (p *Post) Validate() error {
+	var v validator
 	if p.TopicID <= 0 {
-		return errors.New("topic_id must be positive")
-	}
-	if strings.TrimSpace(p.Title) == "" {
-		return errors.New("title cannot be empty")
-	}
-	if strings.TrimSpace(p.Content) == "" {
-		return errors.New("content cannot be empty")
+		v.add("topic_id", "must be positive")
 	}
-	return nil
+	v.text("title", &p.Title, limits.Title, false)
+	v.text("content", &p.Content, limits.Post, true)
+	return v.err()
 }
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+)
+
+// Варианты сортировки списков постов
+const (
+	SortNewest   = "newest"
+	SortOldest   = "oldest"
+	SortComments = "comments"
+	SortActivity = "activity"
+)
+
+// PostFilter описывает параметры выборки списка постов
+type PostFilter struct {
+	TopicID     int        // ID темы; 0 — посты всех тем
+	Author      string     // Имя автора без учета регистра
+	From        *time.Time // Посты, созданные не раньше этого момента
+	To          *time.Time // Посты, созданные раньше этого момента
+	HasComments *bool      // true — только посты с комментариями, false — только без них
+	Tags        []string   // Теги
+	TagMode     string     // all — посты со всеми тегами из Tags (по умолчанию), any — хотя бы с одним
+	Sort        string     // Сортировка: newest, oldest, comments, activity, top
+	Limit       int
+	Offset      int
+}
+
+func (f *PostFilter) Validate() error {
+	switch f.Sort {
+	case "", SortNewest, SortOldest, SortComments, SortActivity, SortTop:
+	default:
+		return InvalidField("sort", "unknown sort")
+	}
+	if f.TagMode != "" && f.TagMode != TagModeAll && f.TagMode != TagModeAny {
+		return InvalidField("tag_mode", "unknown tag mode")
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return InvalidField("from", "must be before to")
+	}
+	if f.Limit < 0 {
+		return InvalidField("limit", "cannot be negative")
+	}
+	if f.Offset < 0 {
+		return InvalidField("offset", "cannot be negative")
+	}
+	return nil
+}
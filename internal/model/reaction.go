@@ -0,0 +1,61 @@
+package model
+
+const (
+	TargetPost    = "post"
+	TargetComment = "comment"
+)
+
+// SortTop — сортировка по рейтингу (сумме голосов) по убыванию
+const SortTop = "top"
+
+// AllowedReactions — эмодзи, которые можно поставить в качестве реакции
+var AllowedReactions = map[string]bool{
+	"👍":  true,
+	"👎":  true,
+	"❤️": true,
+	"😂":  true,
+	"😮":  true,
+	"😢":  true,
+	"🎉":  true,
+	"🚀":  true,
+	"👀":  true,
+}
+
+// VoteRequest представляет собой голос за пост или комментарий
+// @Description Голос +1 или -1. Повторный такой же голос отменяет его, противоположный — заменяет
+type VoteRequest struct {
+	TargetType string `json:"target_type"` // Тип объекта: post или comment
+	TargetID   int    `json:"target_id"`   // ID поста или комментария
+	Value      int    `json:"value"`       // 1 — за, -1 — против
+}
+
+// VoteResult представляет собой рейтинг объекта после голосования
+// @Description Итоговые счетчики голосов и голос текущего пользователя (0, если голос отменен)
+type VoteResult struct {
+	Score     int `json:"score"`     // Рейтинг: разница голосов за и против
+	Upvotes   int `json:"upvotes"`   // Количество голосов за
+	Downvotes int `json:"downvotes"` // Количество голосов против
+	MyVote    int `json:"my_vote"`   // Голос текущего пользователя
+}
+
+// ReactionRequest представляет собой реакцию на пост или комментарий
+// @Description Повторная такая же реакция от того же пользователя снимает ее
+type ReactionRequest struct {
+	TargetType string `json:"target_type"` // Тип объекта: post или comment
+	TargetID   int    `json:"target_id"`   // ID поста или комментария
+	Emoji      string `json:"emoji"`       // Эмодзи реакции
+}
+
+// ReactionCount представляет собой количество одинаковых реакций
+// @Description Количество реакций с одним эмодзи и отметка, поставил ли ее текущий пользователь
+type ReactionCount struct {
+	Emoji   string `json:"emoji"`             // Эмодзи реакции
+	Count   int    `json:"count"`             // Количество реакций
+	Reacted bool   `json:"reacted,omitempty"` // Поставил ли реакцию текущий пользователь
+}
+
+// ReactionSummary — реакции на пост или комментарий и голос текущего пользователя
+type ReactionSummary struct {
+	Reactions []ReactionCount
+	MyVote    int
+}
@@ -0,0 +1,29 @@
+package model
+
+// Режимы отбора постов по нескольким тегам
+const (
+	TagModeAll = "all"
+	TagModeAny = "any"
+)
+
+// Tag представляет собой тег постов
+// @Description Тег и количество постов, отмеченных им
+type Tag struct {
+	ID        int    `json:"id"`         // ID тега
+	Name      string `json:"name"`       // Нормализованное имя тега
+	PostCount int    `json:"post_count"` // Количество постов с тегом
+}
+
+// TagRenameRequest представляет собой запрос на переименование тега
+// @Description Новое имя нормализуется так же, как теги постов
+type TagRenameRequest struct {
+	ID   int    `json:"id"`   // ID тега (в /api/v1 берется из пути)
+	Name string `json:"name"` // Новое имя
+}
+
+// TagMergeRequest представляет собой запрос на слияние тегов
+// @Description Посты с тегом source_id получают тег target_id, после чего тег source_id удаляется
+type TagMergeRequest struct {
+	SourceID int `json:"source_id"` // ID тега, который поглощается
+	TargetID int `json:"target_id"` // ID тега, который остается
+}
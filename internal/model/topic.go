@@ -1,26 +1,80 @@
 package model
 
-import (
-	"errors"
-	"strings"
-	"time"
-)
+import "time"
 
 // Topic представляет собой тему форума
-// @Description Структура темы с необходимыми полями для хранения данных о теме
+// @Description Структура темы с необходимыми полями для хранения данных о теме. Темы образуют дерево: у подфорума указан parent_id
 type Topic struct {
-	ID          int       `json:"id"`          // ID темы
-	Title       string    `json:"title"`       // Заголовок темы
-	Description string    `json:"description"` // Описание темы
-	CreatedAt   time.Time `json:"created_at"`  // Дата и время создания темы
+	ID          int       `json:"id"`                  // ID темы
+	ParentID    *int      `json:"parent_id,omitempty"` // ID родительской темы; отсутствует у тем верхнего уровня
+	Position    int       `json:"position"`            // Порядок отображения среди тем одного уровня
+	Title       string    `json:"title"`               // Заголовок темы
+	Description string    `json:"description"`         // Описание темы
+	CreatedAt   time.Time `json:"created_at"`          // Дата и время создания темы
+	Archived    bool      `json:"archived"`            // Тема в архиве: доступна только для чтения и скрыта из списка по умолчанию
+	Locked      bool      `json:"locked"`              // Тема закрыта: новые посты запрещены
+	Children    []Topic   `json:"children,omitempty"`  // Вложенные темы (только в дереве тем)
+
+	PostCount      int            `json:"post_count"`                 // Количество постов в теме (без подтем)
+	CommentCount   int            `json:"comment_count"`              // Количество комментариев к постам темы
+	LastPost       *TopicLastPost `json:"last_post,omitempty"`        // Последний пост темы
+	LastActivityAt *time.Time     `json:"last_activity_at,omitempty"` // Время последнего поста или комментария
+}
+
+// TopicLastPost представляет собой сводку последнего поста темы
+// @Description ID, заголовок, автор и время создания поста
+type TopicLastPost struct {
+	ID        int       `json:"id"`        // ID поста
+	Title     string    `json:"title"`     // Заголовок поста
+	Username  string    `json:"username"`  // Имя автора
+	Timestamp time.Time `json:"timestamp"` // Дата и время создания поста
+}
+
+// TopicRef представляет собой ссылку на тему в навигационной цепочке
+// @Description ID и заголовок темы
+type TopicRef struct {
+	ID    int    `json:"id"`    // ID темы
+	Title string `json:"title"` // Заголовок темы
+}
+
+// StatsRecount представляет собой итог пересчета денормализованной статистики
+// @Description Количество тем и постов, счетчики которых расходились с фактическими
+type StatsRecount struct {
+	Topics int `json:"topics"` // Исправленные темы
+	Posts  int `json:"posts"`  // Исправленные посты
 }
 
+// TopicMoveRequest представляет собой запрос на перенос темы
+// @Description Без parent_id тема переносится на верхний уровень
+type TopicMoveRequest struct {
+	ID       int  `json:"id"`                  // ID переносимой темы (в /api/v1 берется из пути)
+	ParentID *int `json:"parent_id,omitempty"` // ID новой родительской темы
+}
+
+// TopicUpdateRequest представляет собой запрос на изменение темы
+// @Description Отсутствующие поля не изменяются
+type TopicUpdateRequest struct {
+	ID          int     `json:"id"`                    // ID темы (в /api/v1 берется из пути)
+	Title       *string `json:"title,omitempty"`       // Новый заголовок
+	Description *string `json:"description,omitempty"` // Новое описание
+	Position    *int    `json:"position,omitempty"`    // Новый порядок отображения среди тем одного уровня
+}
+
+// TopicStateRequest представляет собой запрос на архивацию или закрытие темы
+// @Description Отсутствующие поля не изменяются
+type TopicStateRequest struct {
+	ID       int   `json:"id"`                 // ID темы (в /api/v1 берется из пути)
+	Archived *bool `json:"archived,omitempty"` // Поместить тему в архив или вернуть из архива
+	Locked   *bool `json:"locked,omitempty"`   // Закрыть тему для новых постов или открыть ее
+}
+
+// Validate нормализует заголовок и описание темы и возвращает *ValidationError со всеми некорректными полями
 func (t *Topic) Validate() error {
-	if strings.TrimSpace(t.Title) == "" {
-		return errors.New("title cannot be empty")
-	}
-	if strings.TrimSpace(t.Description) == "" {
-		return errors.New("description cannot be empty")
+	var v validator
+	v.text("title", &t.Title, limits.Title, false)
+	v.text("description", &t.Description, limits.Description, true)
+	if t.ParentID != nil && *t.ParentID <= 0 {
+		v.add("parent_id", "must be positive")
 	}
-	return nil
+	return v.err()
 }
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Типы удаленных объектов в корзине
+const (
+	TrashTopic   = "topic"
+	TrashPost    = "post"
+	TrashComment = "comment"
+)
+
+// TrashItem представляет собой удаленный объект в корзине
+// @Description Тема, пост или комментарий, удаленные, но еще не стертые окончательно
+type TrashItem struct {
+	Type      string    `json:"type"`                 // Тип объекта: topic, post или comment
+	ID        int       `json:"id"`                   // ID объекта
+	ParentID  *int      `json:"parent_id,omitempty"`  // ID темы поста или поста комментария; у темы — ID родительской темы
+	Title     string    `json:"title"`                // Заголовок темы или поста, начало текста комментария
+	Username  string    `json:"username,omitempty"`   // Автор поста или комментария
+	DeletedAt time.Time `json:"deleted_at"`           // Время удаления
+	DeletedBy *int      `json:"deleted_by,omitempty"` // ID удалившего пользователя
+}
+
+// TrashRestoreRequest представляет собой запрос на восстановление объекта из корзины
+// @Description Вместе с объектом восстанавливаются вложенные объекты, удаленные вместе с ним
+type TrashRestoreRequest struct {
+	Type string `json:"type"` // Тип объекта: topic, post или comment
+	ID   int    `json:"id"`   // ID объекта
+}
+
+// ValidTrashType сообщает, может ли объект такого типа находиться в корзине
+func ValidTrashType(t string) bool {
+	return t == TrashTopic || t == TrashPost || t == TrashComment
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Limits задает максимальную длину текстовых полей в символах; 0 — без ограничения
+type Limits struct {
+	Title       int // Заголовок поста или темы
+	Description int // Описание темы
+	Post        int // Текст поста
+	Comment     int // Текст комментария
+	Message     int // Сообщение чата
+	Username    int // Имя пользователя в чате
+}
+
+// DefaultLimits — ограничения длины, действующие, пока не вызван SetLimits
+var DefaultLimits = Limits{
+	Title:       200,
+	Description: 1000,
+	Post:        50000,
+	Comment:     10000,
+	Message:     2000,
+	Username:    64,
+}
+
+var limits = DefaultLimits
+
+// SetLimits заменяет ограничения длины, которые проверяют методы Validate. Вызывается один раз при запуске,
+// до обработки запросов
+func SetLimits(l Limits) {
+	limits = l
+}
+
+// validator собирает ошибки всех полей, чтобы клиент получил их одним ответом
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) add(field, message string) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: message})
+}
+
+// text приводит строку к форме NFC, обрезает пробелы по краям и проверяет, что она непустая, не длиннее maxLen
+// символов и не содержит управляющих символов. В многострочном тексте допустимы переводы строк и табуляция
+func (v *validator) text(field string, s *string, maxLen int, multiline bool) {
+	if !utf8.ValidString(*s) {
+		v.add(field, "must be valid UTF-8")
+		return
+	}
+	*s = strings.TrimSpace(norm.NFC.String(*s))
+	if *s == "" {
+		v.add(field, "cannot be empty")
+		return
+	}
+	for _, r := range *s {
+		if unicode.IsControl(r) && !(multiline && (r == '\n' || r == '\r' || r == '\t')) {
+			v.add(field, "must not contain control characters")
+			return
+		}
+	}
+	if maxLen > 0 && utf8.RuneCountInString(*s) > maxLen {
+		v.add(field, fmt.Sprintf("must be at most %d characters", maxLen))
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldErrors(t *testing.T, err error) []FieldError {
+	t.Helper()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	return verr.Fields
+}
+
+func TestPost_Validate_CollectsAllFields(t *testing.T) {
+	p := &Post{Title: "  ", Content: ""}
+
+	fields := fieldErrors(t, p.Validate())
+
+	assert.Equal(t, []FieldError{
+		{Field: "topic_id", Message: "must be positive"},
+		{Field: "title", Message: "cannot be empty"},
+		{Field: "content", Message: "cannot be empty"},
+	}, fields)
+}
+
+func TestPost_Validate_Normalizes(t *testing.T) {
+	p := &Post{TopicID: 1, Title: "  Cafe\u0301 ", Content: "\tline 1\nline 2\n"}
+
+	assert.NoError(t, p.Validate())
+	assert.Equal(t, "Caf\u00e9", p.Title)
+	assert.Equal(t, "line 1\nline 2", p.Content)
+}
+
+func TestTopic_Validate_ControlCharacters(t *testing.T) {
+	tp := &Topic{Title: "Go\nnews", Description: "multi\nline\x00"}
+
+	fields := fieldErrors(t, tp.Validate())
+
+	assert.Equal(t, []FieldError{
+		{Field: "title", Message: "must not contain control characters"},
+		{Field: "description", Message: "must not contain control characters"},
+	}, fields)
+}
+
+func TestMessage_Validate_InvalidUTF8(t *testing.T) {
+	m := &Message{Username: "user", Content: "bad \xff byte"}
+
+	fields := fieldErrors(t, m.Validate())
+
+	assert.Equal(t, []FieldError{{Field: "content", Message: "must be valid UTF-8"}}, fields)
+}
+
+func TestComment_Validate_MaxLength(t *testing.T) {
+	defer SetLimits(DefaultLimits)
+	SetLimits(Limits{Comment: 5})
+
+	assert.NoError(t, (&Comment{PostID: 1, Content: "приве"}).Validate())
+
+	fields := fieldErrors(t, (&Comment{PostID: 1, Content: strings.Repeat("я", 6)}).Validate())
+	assert.Equal(t, []FieldError{{Field: "content", Message: "must be at most 5 characters"}}, fields)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"golangforum/internal/model"
+)
+
+type ActivityRepository interface {
+	Recent(ctx context.Context, userID, limit int) ([]model.Activity, error)
+	CountByUser(ctx context.Context, userID int) (*model.UserContent, error)
+	PurgeUser(ctx context.Context, userID int) (*model.UserContent, error)
+}
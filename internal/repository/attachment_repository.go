@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"golangforum/internal/model"
+	"time"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, a *model.Attachment) error
+	GetByID(ctx context.Context, id int) (*model.Attachment, error)
+	GetByPosts(ctx context.Context, postIDs []int) ([]model.Attachment, error)
+	GetByComments(ctx context.Context, commentIDs []int) ([]model.Attachment, error)
+	GetOrphans(ctx context.Context, olderThan time.Time) ([]model.Attachment, error)
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore хранит содержимое загруженных файлов по непрозрачному ключу
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+}
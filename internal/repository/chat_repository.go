@@ -1,12 +1,14 @@
 package repository
 
 import (
+	"context"
 	"golangforum/internal/model"
 	"time"
 )
 
 type ChatRepository interface {
-	GetAllMessages() ([]model.Message, error)
-	SaveMessage(*model.Message) error
-	DeleteMessagesOlderThan(time.Time) error
+	GetAllMessages(ctx context.Context) ([]model.Message, error)
+	SaveMessage(ctx context.Context, m *model.Message) error
+	DeleteMessagesOlderThan(ctx context.Context, t time.Time) (int, error)
+	CountMessagesOlderThan(ctx context.Context, t time.Time) (int, error)
 }
@@ -1,11 +1,13 @@
 package repository
 
 import (
+	"context"
 	"golangforum/internal/model"
 )
 
 type CommentRepository interface {
-	Create(c *model.Comment) error
-	GetByPost(postID int) ([]model.Comment, error)
-	Delete(id int) error
+	Create(ctx context.Context, c *model.Comment) error
+	GetByID(ctx context.Context, id int) (*model.Comment, error)
+	GetByPost(ctx context.Context, postID int, sort string) ([]model.Comment, error)
+	Delete(ctx context.Context, id, deletedBy int) error
 }
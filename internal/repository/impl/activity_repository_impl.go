@@ -0,0 +1,135 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+
+	"golangforum/internal/model"
+)
+
+// activityExcerpt — сколько символов текста комментария или сообщения попадает в ленту активности
+const activityExcerpt = 100
+
+type ActivityRepository struct {
+	DB *sql.DB
+}
+
+func NewActivityRepository(db *sql.DB) *ActivityRepository {
+	return &ActivityRepository{DB: db}
+}
+
+// Recent возвращает limit последних неудаленных постов, комментариев и сообщений чата, новые первыми.
+// userID = 0 — всех пользователей
+func (r *ActivityRepository) Recent(ctx context.Context, userID, limit int) (_ []model.Activity, err error) {
+	defer logFailure(ctx, "ActivityRepository.Recent", &err)
+	rows, err := r.DB.QueryContext(ctx,
+		`(SELECT 'post', p.id, p.topic_id, NULL::int, p.user_id, p.username, p.title, p.timestamp
+			FROM posts p
+			WHERE p.deleted_at IS NULL AND ($1 = 0 OR p.user_id = $1)
+			ORDER BY p.timestamp DESC LIMIT $2)
+		UNION ALL
+		(SELECT 'comment', c.id, p.topic_id, c.post_id, c.user_id, c.username, LEFT(c.content, $3), c.timestamp
+			FROM comments c JOIN posts p ON p.id = c.post_id
+			WHERE c.deleted_at IS NULL AND p.deleted_at IS NULL AND ($1 = 0 OR c.user_id = $1)
+			ORDER BY c.timestamp DESC LIMIT $2)
+		UNION ALL
+		(SELECT 'message', m.id, NULL, NULL, m.user_id, m.username, LEFT(m.content, $3), m.timestamp
+			FROM messages m
+			WHERE $1 = 0 OR m.user_id = $1
+			ORDER BY m.timestamp DESC LIMIT $2)
+		ORDER BY 8 DESC, 2 DESC
+		LIMIT $2`,
+		userID, limit, activityExcerpt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []model.Activity
+	for rows.Next() {
+		var (
+			a       model.Activity
+			topicID sql.NullInt64
+			postID  sql.NullInt64
+		)
+		if err := rows.Scan(&a.Type, &a.ID, &topicID, &postID, &a.UserID, &a.Username, &a.Text, &a.Timestamp); err != nil {
+			return nil, err
+		}
+		a.TopicID = nullIntPtr(topicID)
+		a.PostID = nullIntPtr(postID)
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
+
+// CountByUser возвращает количество объектов, которые PurgeUser сотрет для userID
+func (r *ActivityRepository) CountByUser(ctx context.Context, userID int) (_ *model.UserContent, err error) {
+	defer logFailure(ctx, "ActivityRepository.CountByUser", &err)
+	c := model.UserContent{UserID: userID}
+	err = r.DB.QueryRowContext(ctx,
+		`SELECT
+			(SELECT COUNT(*) FROM posts WHERE user_id = $1),
+			(SELECT COUNT(*) FROM comments WHERE user_id = $1),
+			(SELECT COUNT(*) FROM comments c JOIN posts p ON p.id = c.post_id WHERE p.user_id = $1 AND c.user_id <> $1),
+			(SELECT COUNT(*) FROM messages WHERE user_id = $1)`,
+		userID,
+	).Scan(&c.Posts, &c.Comments, &c.Replies, &c.Messages)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// PurgeUser окончательно стирает посты, комментарии и сообщения чата пользователя, в том числе лежащие в корзине,
+// вместе с комментариями других пользователей к его постам. Счетчики комментариев чужих постов уменьшаются
+// в той же транзакции; статистику тем после очистки нужно пересчитать. Вложения постов и комментариев
+// открепляются и стираются сборщиком неприкрепленных файлов
+func (r *ActivityRepository) PurgeUser(ctx context.Context, userID int) (_ *model.UserContent, err error) {
+	defer logFailure(ctx, "ActivityRepository.PurgeUser", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	c := model.UserContent{UserID: userID}
+	// удаленные в корзину комментарии уже вычтены из счетчиков поста
+	err = tx.QueryRowContext(ctx,
+		`WITH gone AS (
+			DELETE FROM comments WHERE user_id = $1 RETURNING post_id, deleted_at
+		), counts AS (
+			UPDATE posts p SET comment_count = p.comment_count - g.n
+			FROM (SELECT post_id, COUNT(*) AS n FROM gone WHERE deleted_at IS NULL GROUP BY post_id) g
+			WHERE p.id = g.post_id
+		)
+		SELECT COUNT(*) FROM gone`,
+		userID,
+	).Scan(&c.Comments)
+	if err != nil {
+		return nil, err
+	}
+	steps := []struct {
+		query string
+		count *int
+	}{
+		{"DELETE FROM comments WHERE post_id IN (SELECT id FROM posts WHERE user_id = $1)", &c.Replies},
+		{"DELETE FROM posts WHERE user_id = $1", &c.Posts},
+		{"DELETE FROM messages WHERE user_id = $1", &c.Messages},
+	}
+	for _, s := range steps {
+		res, err := tx.ExecContext(ctx, s.query, userID)
+		if err != nil {
+			return nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		*s.count = int(n)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,133 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+)
+
+const attachmentColumns = "id, post_id, comment_id, user_id, username, filename, content_type, size, storage_key, thumbnail_key, timestamp"
+
+type AttachmentRepository struct {
+	DB *sql.DB
+}
+
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{DB: db}
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, a *model.Attachment) (err error) {
+	defer logFailure(ctx, "AttachmentRepository.Create", &err)
+	return r.DB.QueryRowContext(ctx,
+		"INSERT INTO attachments (post_id, comment_id, user_id, username, filename, content_type, size, storage_key, thumbnail_key, timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id",
+		a.PostID, a.CommentID, a.UserID, a.Username, a.Filename, a.ContentType, a.Size, a.StorageKey, sql.NullString{String: a.ThumbnailKey, Valid: a.ThumbnailKey != ""}, a.Timestamp,
+	).Scan(&a.ID)
+}
+
+func (r *AttachmentRepository) GetByID(ctx context.Context, id int) (_ *model.Attachment, err error) {
+	defer logFailure(ctx, "AttachmentRepository.GetByID", &err)
+	rows, err := r.DB.QueryContext(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := scanAttachments(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+func (r *AttachmentRepository) GetByPosts(ctx context.Context, postIDs []int) (_ []model.Attachment, err error) {
+	defer logFailure(ctx, "AttachmentRepository.GetByPosts", &err)
+	rows, err := r.DB.QueryContext(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE post_id = ANY($1) ORDER BY id", pq.Array(postIDs))
+	if err != nil {
+		return nil, err
+	}
+	return scanAttachments(rows)
+}
+
+func (r *AttachmentRepository) GetByComments(ctx context.Context, commentIDs []int) (_ []model.Attachment, err error) {
+	defer logFailure(ctx, "AttachmentRepository.GetByComments", &err)
+	rows, err := r.DB.QueryContext(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE comment_id = ANY($1) ORDER BY id", pq.Array(commentIDs))
+	if err != nil {
+		return nil, err
+	}
+	return scanAttachments(rows)
+}
+
+// linkAttachments прикрепляет свободные вложения ids пользователя userID к посту или комментарию targetID
+// (column — post_id или comment_id) в транзакции его создания. Если какое-то вложение исчезло или уже
+// прикреплено, возвращает repository.ErrConflict
+func linkAttachments(ctx context.Context, tx *sql.Tx, column string, ids []int, userID, targetID int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	res, err := tx.ExecContext(ctx,
+		"UPDATE attachments SET "+column+" = $1 WHERE id = ANY($2) AND user_id = $3 AND post_id IS NULL AND comment_id IS NULL",
+		targetID, pq.Array(ids), userID,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(n) != len(ids) {
+		return repository.ErrConflict
+	}
+	return nil
+}
+
+func (r *AttachmentRepository) GetOrphans(ctx context.Context, olderThan time.Time) (_ []model.Attachment, err error) {
+	defer logFailure(ctx, "AttachmentRepository.GetOrphans", &err)
+	rows, err := r.DB.QueryContext(ctx,
+		"SELECT "+attachmentColumns+" FROM attachments WHERE post_id IS NULL AND comment_id IS NULL AND timestamp < $1",
+		olderThan,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanAttachments(rows)
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id int) (err error) {
+	defer logFailure(ctx, "AttachmentRepository.Delete", &err)
+	_, err = r.DB.ExecContext(ctx, "DELETE FROM attachments WHERE id = $1", id)
+	return err
+}
+
+func scanAttachments(rows *sql.Rows) ([]model.Attachment, error) {
+	defer rows.Close()
+
+	var res []model.Attachment
+	for rows.Next() {
+		var (
+			a                 model.Attachment
+			postID, commentID sql.NullInt64
+			thumbnailKey      sql.NullString
+		)
+		if err := rows.Scan(&a.ID, &postID, &commentID, &a.UserID, &a.Username, &a.Filename, &a.ContentType, &a.Size, &a.StorageKey, &thumbnailKey, &a.Timestamp); err != nil {
+			return nil, err
+		}
+		if postID.Valid {
+			id := int(postID.Int64)
+			a.PostID = &id
+		}
+		if commentID.Valid {
+			id := int(commentID.Int64)
+			a.CommentID = &id
+		}
+		a.ThumbnailKey = thumbnailKey.String
+		a.HasThumbnail = thumbnailKey.Valid
+		res = append(res, a)
+	}
+	return res, rows.Err()
+}
@@ -1,6 +1,7 @@
 package impl
 
 import (
+	"context"
 	"database/sql"
 	"golangforum/internal/model"
 	"time"
@@ -14,21 +15,34 @@ func NewChatRepository(db *sql.DB) *ChatRepositoryImpl {
 	return &ChatRepositoryImpl{DB: db}
 }
 
-func (r *ChatRepositoryImpl) SaveMessage(m *model.Message) error {
-	_, err := r.DB.Exec(
-		"INSERT INTO messages (user_id, username, content, timestamp) VALUES ($1, $2, $3, $4)",
+func (r *ChatRepositoryImpl) SaveMessage(ctx context.Context, m *model.Message) (err error) {
+	defer logFailure(ctx, "ChatRepository.SaveMessage", &err)
+	return r.DB.QueryRowContext(ctx,
+		"INSERT INTO messages (user_id, username, content, timestamp) VALUES ($1, $2, $3, $4) RETURNING id",
 		m.UserID, m.Username, m.Content, m.Timestamp,
-	)
-	return err
+	).Scan(&m.ID)
 }
 
-func (r *ChatRepositoryImpl) DeleteMessagesOlderThan(t time.Time) error {
-	_, err := r.DB.Exec("DELETE FROM messages WHERE timestamp < $1", t)
-	return err
+func (r *ChatRepositoryImpl) DeleteMessagesOlderThan(ctx context.Context, t time.Time) (_ int, err error) {
+	defer logFailure(ctx, "ChatRepository.DeleteMessagesOlderThan", &err)
+	res, err := r.DB.ExecContext(ctx, "DELETE FROM messages WHERE timestamp < $1", t)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *ChatRepositoryImpl) CountMessagesOlderThan(ctx context.Context, t time.Time) (_ int, err error) {
+	defer logFailure(ctx, "ChatRepository.CountMessagesOlderThan", &err)
+	var n int
+	err = r.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM messages WHERE timestamp < $1", t).Scan(&n)
+	return n, err
 }
 
-func (r *ChatRepositoryImpl) GetAllMessages() ([]model.Message, error) {
-	rows, err := r.DB.Query("SELECT id, user_id, username, content, timestamp FROM messages")
+func (r *ChatRepositoryImpl) GetAllMessages(ctx context.Context) (_ []model.Message, err error) {
+	defer logFailure(ctx, "ChatRepository.GetAllMessages", &err)
+	rows, err := r.DB.QueryContext(ctx, "SELECT id, user_id, username, content, timestamp FROM messages")
 	if err != nil {
 		return nil, err
 	}
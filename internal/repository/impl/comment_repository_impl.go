@@ -1,8 +1,13 @@
 package impl
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"time"
+
 	"golangforum/internal/model"
+	"golangforum/internal/repository"
 )
 
 type CommentRepository struct {
@@ -13,17 +18,65 @@ func NewCommentRepository(db *sql.DB) *CommentRepository {
 	return &CommentRepository{db: db}
 }
 
-func (r *CommentRepository) Create(c *model.Comment) error {
-	_, err := r.db.Exec(
-		"INSERT INTO comments (post_id, user_id, username, content, timestamp) VALUES ($1, $2, $3, $4, $5)",
+// Create сохраняет комментарий и в той же транзакции прикрепляет к нему вложения c.AttachmentIDs и обновляет
+// счетчики комментариев и время активности поста и темы
+func (r *CommentRepository) Create(ctx context.Context, c *model.Comment) (err error) {
+	defer logFailure(ctx, "CommentRepository.Create", &err)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO comments (post_id, user_id, username, content, timestamp) VALUES ($1, $2, $3, $4, $5) RETURNING id",
 		c.PostID, c.UserID, c.Username, c.Content, c.Timestamp,
+	).Scan(&c.ID)
+	if err != nil {
+		return mapPQError(err)
+	}
+	if err := linkAttachments(ctx, tx, "comment_id", c.AttachmentIDs, c.UserID, c.ID); err != nil {
+		return err
+	}
+	var topicID int
+	err = tx.QueryRowContext(ctx,
+		"UPDATE posts SET comment_count = comment_count + 1, last_activity_at = GREATEST(last_activity_at, $2) WHERE id = $1 RETURNING topic_id",
+		c.PostID, c.Timestamp,
+	).Scan(&topicID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		"UPDATE topics SET comment_count = comment_count + 1, last_activity_at = GREATEST(last_activity_at, $2) WHERE id = $1",
+		topicID, c.Timestamp,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const commentColumns = "id, post_id, user_id, username, content, timestamp, score, upvotes, downvotes"
+
+func (r *CommentRepository) GetByID(ctx context.Context, id int) (_ *model.Comment, err error) {
+	defer logFailure(ctx, "CommentRepository.GetByID", &err)
+	var c model.Comment
+	err = r.db.QueryRowContext(ctx, "SELECT "+commentColumns+" FROM comments WHERE id = $1 AND deleted_at IS NULL", id).Scan(
+		&c.ID, &c.PostID, &c.UserID, &c.Username, &c.Content, &c.Timestamp, &c.Score, &c.Upvotes, &c.Downvotes,
 	)
-	return err
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
 }
 
-func (r *CommentRepository) GetByPost(postID int) ([]model.Comment, error) {
-	rows, err := r.db.Query(
-		"SELECT id, post_id, user_id, username, content, timestamp FROM comments WHERE post_id = $1",
+func (r *CommentRepository) GetByPost(ctx context.Context, postID int, sort string) (_ []model.Comment, err error) {
+	defer logFailure(ctx, "CommentRepository.GetByPost", &err)
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+commentColumns+" FROM comments WHERE post_id = $1 AND deleted_at IS NULL ORDER BY "+commentOrder(sort),
 		postID,
 	)
 	if err != nil {
@@ -34,15 +87,50 @@ func (r *CommentRepository) GetByPost(postID int) ([]model.Comment, error) {
 	var res []model.Comment
 	for rows.Next() {
 		var c model.Comment
-		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.Username, &c.Content, &c.Timestamp); err != nil {
+		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.Username, &c.Content, &c.Timestamp, &c.Score, &c.Upvotes, &c.Downvotes); err != nil {
 			return nil, err
 		}
 		res = append(res, c)
 	}
-	return res, nil
+	return res, rows.Err()
 }
 
-func (r *CommentRepository) Delete(id int) error {
-	_, err := r.db.Exec("DELETE FROM comments WHERE id = $1", id)
-	return err
+// Delete помечает комментарий удаленным и в той же транзакции уменьшает счетчики комментариев поста и темы.
+// deletedBy = 0 — удаливший пользователь неизвестен
+func (r *CommentRepository) Delete(ctx context.Context, id, deletedBy int) (err error) {
+	defer logFailure(ctx, "CommentRepository.Delete", &err)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var postID int
+	err = tx.QueryRowContext(ctx,
+		"UPDATE comments SET deleted_at = $2, deleted_by = $3 WHERE id = $1 AND deleted_at IS NULL RETURNING post_id",
+		id, time.Now(), nullableID(deletedBy),
+	).Scan(&postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	var topicID int
+	if err := tx.QueryRowContext(ctx, "UPDATE posts SET comment_count = comment_count - 1 WHERE id = $1 RETURNING topic_id", postID).Scan(&topicID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE topics SET comment_count = comment_count - 1 WHERE id = $1", topicID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// commentOrder переводит значение сортировки в выражение ORDER BY; неизвестные значения дают порядок создания,
+// поэтому в запрос никогда не попадает пользовательский ввод
+func commentOrder(sort string) string {
+	if sort == model.SortTop {
+		return "score DESC, id DESC"
+	}
+	return "id"
 }
@@ -0,0 +1,25 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"golangforum/internal/repository"
+)
+
+// logFailure пишет в журнал запроса ошибку *err метода репозитория op; вызывается через defer.
+// Ожидаемые исходы — отсутствие строки, конфликты и отмена запроса — вызывающий обрабатывает сам, они не пишутся
+func logFailure(ctx context.Context, op string, err *error) {
+	switch {
+	case *err == nil,
+		errors.Is(*err, sql.ErrNoRows),
+		errors.Is(*err, repository.ErrNotFound),
+		errors.Is(*err, repository.ErrConflict),
+		errors.Is(*err, repository.ErrForeignKey),
+		errors.Is(*err, context.Canceled):
+		return
+	}
+	log.Ctx(ctx).Error().Err(*err).Str("op", op).Msg("Repository query failed")
+}
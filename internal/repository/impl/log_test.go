@@ -0,0 +1,37 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"golangforum/internal/repository"
+)
+
+func TestLogFailure(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := zerolog.New(&buf).With().Str("request_id", "req-1").Logger().WithContext(context.Background())
+
+	for _, err := range []error{
+		nil,
+		sql.ErrNoRows,
+		repository.ErrNotFound,
+		fmt.Errorf("%w: duplicate", repository.ErrConflict),
+		repository.ErrForeignKey,
+		context.Canceled,
+	} {
+		logFailure(ctx, "PostRepository.GetByID", &err)
+	}
+	assert.Empty(t, buf.String())
+
+	err := errors.New("connection reset")
+	logFailure(ctx, "PostRepository.GetByID", &err)
+	assert.Contains(t, buf.String(), `"request_id":"req-1"`)
+	assert.Contains(t, buf.String(), `"op":"PostRepository.GetByID"`)
+	assert.Contains(t, buf.String(), `"error":"connection reset"`)
+}
@@ -0,0 +1,94 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"golangforum/internal/model"
+)
+
+const mentionColumns = `m.id,
+	CASE WHEN m.post_id IS NOT NULL THEN 'post' WHEN m.comment_id IS NOT NULL THEN 'comment' ELSE 'message' END,
+	COALESCE(m.post_id, m.comment_id, m.message_id), m.username, m.author, m.timestamp`
+
+// userMentions — упоминания пользователя $1. Упоминания в удаленных постах и комментариях скрыты, пока их
+// не восстановят из корзины; удаление поста удаляет и его комментарии, поэтому достаточно проверить сам источник
+const userMentions = `FROM mentions m
+	LEFT JOIN posts p ON p.id = m.post_id
+	LEFT JOIN comments c ON c.id = m.comment_id
+	WHERE LOWER(m.username) = LOWER($1) AND p.deleted_at IS NULL AND c.deleted_at IS NULL`
+
+type MentionRepository struct {
+	DB *sql.DB
+}
+
+func NewMentionRepository(db *sql.DB) *MentionRepository {
+	return &MentionRepository{DB: db}
+}
+
+func (r *MentionRepository) Create(ctx context.Context, m *model.Mention) (err error) {
+	defer logFailure(ctx, "MentionRepository.Create", &err)
+	column, err := mentionSourceColumn(m.SourceType)
+	if err != nil {
+		return err
+	}
+	err = r.DB.QueryRowContext(ctx,
+		"INSERT INTO mentions ("+column+", username, author, timestamp) VALUES ($1, $2, $3, $4) RETURNING id",
+		m.SourceID, m.Username, m.Author, m.Timestamp,
+	).Scan(&m.ID)
+	return mapPQError(err)
+}
+
+func (r *MentionRepository) GetByUsername(ctx context.Context, username string, limit, offset int) (_ []model.Mention, err error) {
+	defer logFailure(ctx, "MentionRepository.GetByUsername", &err)
+	rows, err := r.DB.QueryContext(ctx,
+		"SELECT "+mentionColumns+" "+userMentions+" ORDER BY m.timestamp DESC, m.id DESC LIMIT $2 OFFSET $3",
+		username, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mentions []model.Mention
+	for rows.Next() {
+		var m model.Mention
+		if err := rows.Scan(&m.ID, &m.SourceType, &m.SourceID, &m.Username, &m.Author, &m.Timestamp); err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, m)
+	}
+	return mentions, rows.Err()
+}
+
+func (r *MentionRepository) CountByUsername(ctx context.Context, username string) (_ int, err error) {
+	defer logFailure(ctx, "MentionRepository.CountByUsername", &err)
+	var n int
+	err = r.DB.QueryRowContext(ctx, "SELECT COUNT(*) "+userMentions, username).Scan(&n)
+	return n, err
+}
+
+// DeleteBySource удаляет все упоминания из поста, комментария или сообщения sourceID
+func (r *MentionRepository) DeleteBySource(ctx context.Context, sourceType string, sourceID int) (err error) {
+	defer logFailure(ctx, "MentionRepository.DeleteBySource", &err)
+	column, err := mentionSourceColumn(sourceType)
+	if err != nil {
+		return err
+	}
+	_, err = r.DB.ExecContext(ctx, "DELETE FROM mentions WHERE "+column+" = $1", sourceID)
+	return err
+}
+
+// mentionSourceColumn возвращает столбец mentions, ссылающийся на источник типа sourceType
+func mentionSourceColumn(sourceType string) (string, error) {
+	switch sourceType {
+	case model.MentionSourcePost:
+		return "post_id", nil
+	case model.MentionSourceComment:
+		return "comment_id", nil
+	case model.MentionSourceMessage:
+		return "message_id", nil
+	}
+	return "", fmt.Errorf("unknown mention source type %q", sourceType)
+}
@@ -0,0 +1,97 @@
+package impl
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"golangforum/internal/model"
+)
+
+const postColumns = "id, topic_id, title, content, user_id, username, timestamp, score, upvotes, downvotes, comment_count, last_activity_at"
+
+// postSorts — допустимые варианты сортировки постов. В ORDER BY попадают только значения из этой таблицы,
+// id в конце делает порядок однозначным при равных значениях и стабильным между страницами
+var postSorts = map[string]string{
+	"":                 "timestamp DESC, id DESC",
+	model.SortNewest:   "timestamp DESC, id DESC",
+	model.SortOldest:   "timestamp, id",
+	model.SortComments: "comment_count DESC, id DESC",
+	model.SortActivity: "last_activity_at DESC, id DESC",
+	model.SortTop:      "score DESC, id DESC",
+}
+
+// postQuery собирает условия WHERE выборки постов; значения фильтра передаются только через плейсхолдеры.
+// Удаленные посты в выборку не попадают
+type postQuery struct {
+	conds []string
+	args  []interface{}
+}
+
+func newPostQuery(f model.PostFilter) *postQuery {
+	q := &postQuery{conds: []string{"deleted_at IS NULL"}}
+	if f.TopicID > 0 {
+		q.where("topic_id = ?", f.TopicID)
+	}
+	if f.Author != "" {
+		q.where("LOWER(username) = LOWER(?)", f.Author)
+	}
+	if f.From != nil {
+		q.where("timestamp >= ?", *f.From)
+	}
+	if f.To != nil {
+		q.where("timestamp < ?", *f.To)
+	}
+	if len(f.Tags) > 0 {
+		if f.TagMode == model.TagModeAny {
+			q.where("id IN (SELECT pt.post_id FROM post_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name = ANY(?))", pq.Array(f.Tags))
+		} else {
+			q.where("id IN (SELECT pt.post_id FROM post_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name = ANY(?) GROUP BY pt.post_id HAVING COUNT(*) = ?)",
+				pq.Array(f.Tags), len(f.Tags))
+		}
+	}
+	if f.HasComments != nil {
+		if *f.HasComments {
+			q.conds = append(q.conds, "comment_count > 0")
+		} else {
+			q.conds = append(q.conds, "comment_count = 0")
+		}
+	}
+	return q
+}
+
+// where добавляет условие, заменяя каждый ? на очередной номер параметра
+func (q *postQuery) where(cond string, args ...interface{}) {
+	for _, arg := range args {
+		q.args = append(q.args, arg)
+		cond = strings.Replace(cond, "?", "$"+strconv.Itoa(len(q.args)), 1)
+	}
+	q.conds = append(q.conds, cond)
+}
+
+func (q *postQuery) whereSQL() string {
+	return " WHERE " + strings.Join(q.conds, " AND ")
+}
+
+// List возвращает запрос страницы постов с сортировкой; неизвестная сортировка заменяется сортировкой по умолчанию
+func (q *postQuery) List(sort string, limit, offset int) (string, []interface{}) {
+	order, ok := postSorts[sort]
+	if !ok {
+		order = postSorts[""]
+	}
+	query := "SELECT " + postColumns + " FROM posts" + q.whereSQL() + " ORDER BY " + order
+	args := q.args
+	if limit > 0 {
+		args = append(args, limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+	if offset > 0 {
+		args = append(args, offset)
+		query += " OFFSET $" + strconv.Itoa(len(args))
+	}
+	return query, args
+}
+
+func (q *postQuery) Count() (string, []interface{}) {
+	return "SELECT COUNT(*) FROM posts" + q.whereSQL(), q.args
+}
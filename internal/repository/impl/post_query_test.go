@@ -0,0 +1,75 @@
+package impl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"golangforum/internal/model"
+)
+
+func TestPostQuery_List(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	noComments := false
+
+	tests := []struct {
+		name  string
+		f     model.PostFilter
+		query string
+		args  []interface{}
+	}{
+		{
+			name:  "без фильтров",
+			f:     model.PostFilter{},
+			query: "SELECT " + postColumns + " FROM posts WHERE deleted_at IS NULL ORDER BY timestamp DESC, id DESC",
+		},
+		{
+			name:  "тема и страница",
+			f:     model.PostFilter{TopicID: 3, Sort: model.SortActivity, Limit: 20, Offset: 40},
+			query: "SELECT " + postColumns + " FROM posts WHERE deleted_at IS NULL AND topic_id = $1 ORDER BY last_activity_at DESC, id DESC LIMIT $2 OFFSET $3",
+			args:  []interface{}{3, 20, 40},
+		},
+		{
+			name: "все фильтры",
+			f:    model.PostFilter{Author: "Alice", From: &from, To: &to, HasComments: &noComments, Sort: model.SortComments, Limit: 10},
+			query: "SELECT " + postColumns + " FROM posts WHERE deleted_at IS NULL AND LOWER(username) = LOWER($1) AND timestamp >= $2 AND timestamp < $3 AND comment_count = 0" +
+				" ORDER BY comment_count DESC, id DESC LIMIT $4",
+			args: []interface{}{"Alice", from, to, 10},
+		},
+		{
+			name:  "значение автора не попадает в текст запроса",
+			f:     model.PostFilter{Author: "x'; DROP TABLE posts; --", Sort: "id; DROP TABLE posts"},
+			query: "SELECT " + postColumns + " FROM posts WHERE deleted_at IS NULL AND LOWER(username) = LOWER($1) ORDER BY timestamp DESC, id DESC",
+			args:  []interface{}{"x'; DROP TABLE posts; --"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := newPostQuery(tt.f).List(tt.f.Sort, tt.f.Limit, tt.f.Offset)
+			assert.Equal(t, tt.query, query)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestPostQuery_Tags(t *testing.T) {
+	query, args := newPostQuery(model.PostFilter{Tags: []string{"go", "sql"}}).Count()
+	assert.Equal(t, "SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL AND id IN (SELECT pt.post_id FROM post_tags pt JOIN tags t ON t.id = pt.tag_id"+
+		" WHERE t.name = ANY($1) GROUP BY pt.post_id HAVING COUNT(*) = $2)", query)
+	assert.Equal(t, []interface{}{pq.Array([]string{"go", "sql"}), 2}, args)
+
+	query, args = newPostQuery(model.PostFilter{TopicID: 1, Tags: []string{"go", "sql"}, TagMode: model.TagModeAny}).Count()
+	assert.Equal(t, "SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL AND topic_id = $1 AND id IN (SELECT pt.post_id FROM post_tags pt JOIN tags t ON t.id = pt.tag_id"+
+		" WHERE t.name = ANY($2))", query)
+	assert.Equal(t, []interface{}{1, pq.Array([]string{"go", "sql"})}, args)
+}
+
+func TestPostQuery_Count(t *testing.T) {
+	hasComments := true
+	query, args := newPostQuery(model.PostFilter{TopicID: 2, HasComments: &hasComments, Limit: 20}).Count()
+
+	assert.Equal(t, "SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL AND topic_id = $1 AND comment_count > 0", query)
+	assert.Equal(t, []interface{}{2}, args)
+}
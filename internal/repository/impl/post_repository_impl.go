@@ -1,9 +1,14 @@
 package impl
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"time"
 
+	"github.com/lib/pq"
 	"golangforum/internal/model"
+	"golangforum/internal/repository"
 )
 
 type PostRepository struct {
@@ -14,19 +19,90 @@ func NewPostRepository(db *sql.DB) *PostRepository {
 	return &PostRepository{DB: db}
 }
 
-func (r *PostRepository) Create(post *model.Post) error {
-	_, err := r.DB.Exec(
-		"INSERT INTO posts (topic_id, title, content, user_id, username, timestamp) VALUES ($1, $2, $3, $4, $5, $6)",
+// Create сохраняет пост вместе с тегами; отсутствующие теги создаются. Вложения post.AttachmentIDs прикрепляются
+// к посту, а статистика темы обновляется в той же транзакции
+func (r *PostRepository) Create(ctx context.Context, post *model.Post) (err error) {
+	defer logFailure(ctx, "PostRepository.Create", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	post.LastActivityAt = post.Timestamp
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO posts (topic_id, title, content, user_id, username, timestamp, last_activity_at) VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING id",
 		post.TopicID, post.Title, post.Content, post.UserID, post.Username, post.Timestamp,
+	).Scan(&post.ID)
+	if err != nil {
+		return mapPQError(err)
+	}
+	if err := setPostTags(ctx, tx, post.ID, post.Tags); err != nil {
+		return err
+	}
+	if err := linkAttachments(ctx, tx, "post_id", post.AttachmentIDs, post.UserID, post.ID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE topics SET
+			post_count = post_count + 1,
+			last_post_id = $2,
+			last_activity_at = GREATEST(last_activity_at, $3)
+		WHERE id = $1`,
+		post.TopicID, post.ID, post.Timestamp,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *PostRepository) GetByTopic(topicID int) ([]model.Post, error) {
-	rows, err := r.DB.Query(
-		"SELECT id, topic_id, title, content, user_id, username, timestamp FROM posts WHERE topic_id = $1",
-		topicID,
+func (r *PostRepository) GetByID(ctx context.Context, id int) (_ *model.Post, err error) {
+	defer logFailure(ctx, "PostRepository.GetByID", &err)
+	var p model.Post
+	err = r.DB.QueryRowContext(ctx, "SELECT "+postColumns+" FROM posts WHERE id = $1 AND deleted_at IS NULL", id).Scan(
+		&p.ID, &p.TopicID, &p.Title, &p.Content, &p.UserID, &p.Username, &p.Timestamp,
+		&p.Score, &p.Upvotes, &p.Downvotes, &p.CommentCount, &p.LastActivityAt,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Update изменяет заголовок, текст и теги поста
+func (r *PostRepository) Update(ctx context.Context, post *model.Post) (err error) {
+	defer logFailure(ctx, "PostRepository.Update", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, "UPDATE posts SET title = $2, content = $3 WHERE id = $1 AND deleted_at IS NULL", post.ID, post.Title, post.Content)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrNotFound
+	}
+	if err := setPostTags(ctx, tx, post.ID, post.Tags); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostRepository) List(ctx context.Context, f model.PostFilter) (_ []model.Post, err error) {
+	defer logFailure(ctx, "PostRepository.List", &err)
+	query, args := newPostQuery(f).List(f.Sort, f.Limit, f.Offset)
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +111,10 @@ func (r *PostRepository) GetByTopic(topicID int) ([]model.Post, error) {
 	var posts []model.Post
 	for rows.Next() {
 		var p model.Post
-		if err := rows.Scan(&p.ID, &p.TopicID, &p.Title, &p.Content, &p.UserID, &p.Username, &p.Timestamp); err != nil {
+		if err := rows.Scan(
+			&p.ID, &p.TopicID, &p.Title, &p.Content, &p.UserID, &p.Username, &p.Timestamp,
+			&p.Score, &p.Upvotes, &p.Downvotes, &p.CommentCount, &p.LastActivityAt,
+		); err != nil {
 			return nil, err
 		}
 		posts = append(posts, p)
@@ -43,27 +122,149 @@ func (r *PostRepository) GetByTopic(topicID int) ([]model.Post, error) {
 	return posts, rows.Err()
 }
 
-func (r *PostRepository) GetAll() ([]model.Post, error) {
-	rows, err := r.DB.Query(
-		"SELECT id, topic_id, title, content, user_id, username, timestamp FROM posts",
+func (r *PostRepository) Count(ctx context.Context, f model.PostFilter) (_ int, err error) {
+	defer logFailure(ctx, "PostRepository.Count", &err)
+	query, args := newPostQuery(f).Count()
+	var n int
+	err = r.DB.QueryRowContext(ctx, query, args...).Scan(&n)
+	return n, err
+}
+
+// Delete помечает удаленными пост и его комментарии с одним временем удаления и в той же транзакции
+// вычитает их из статистики темы. deletedBy = 0 — удаливший пользователь неизвестен
+func (r *PostRepository) Delete(ctx context.Context, id, deletedBy int) (err error) {
+	defer logFailure(ctx, "PostRepository.Delete", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var topicID, comments int
+	err = tx.QueryRowContext(ctx,
+		"UPDATE posts SET deleted_at = $2, deleted_by = $3 WHERE id = $1 AND deleted_at IS NULL RETURNING topic_id, comment_count",
+		id, now, nullableID(deletedBy),
+	).Scan(&topicID, &comments)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		"UPDATE comments SET deleted_at = $2, deleted_by = $3 WHERE post_id = $1 AND deleted_at IS NULL",
+		id, now, nullableID(deletedBy),
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
+	_, err = tx.ExecContext(ctx,
+		"UPDATE topics SET post_count = post_count - 1, comment_count = comment_count - $2, last_post_id = "+topicLastPost+" WHERE id = $1",
+		topicID, comments,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
-	var posts []model.Post
+// Move переносит неудаленные посты ids в тему topicID вместе с комментариями и в той же транзакции переносит
+// их в статистике тем. Посты, уже находящиеся в topicID, пропускаются. Возвращает количество перенесенных постов
+func (r *PostRepository) Move(ctx context.Context, ids []int, topicID int) (_ int, err error) {
+	defer logFailure(ctx, "PostRepository.Move", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx,
+		`WITH moved AS (
+			SELECT id, topic_id, comment_count, last_activity_at FROM posts
+			WHERE id = ANY($1) AND deleted_at IS NULL AND topic_id <> $2
+			FOR UPDATE
+		), updated AS (
+			UPDATE posts p SET topic_id = $2 FROM moved m WHERE p.id = m.id
+		)
+		SELECT topic_id, COUNT(*), SUM(comment_count), MAX(last_activity_at) FROM moved GROUP BY topic_id`,
+		pq.Array(ids), topicID,
+	)
+	if err != nil {
+		return 0, mapPQError(err)
+	}
+	type source struct {
+		topicID, posts, comments int
+	}
+	var (
+		sources      []source
+		lastActivity time.Time
+	)
 	for rows.Next() {
-		var p model.Post
-		if err := rows.Scan(&p.ID, &p.TopicID, &p.Title, &p.Content, &p.UserID, &p.Username, &p.Timestamp); err != nil {
-			return nil, err
+		var s source
+		var last time.Time
+		if err := rows.Scan(&s.topicID, &s.posts, &s.comments, &last); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		sources = append(sources, s)
+		if last.After(lastActivity) {
+			lastActivity = last
 		}
-		posts = append(posts, p)
 	}
-	return posts, rows.Err()
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	moved, comments := 0, 0
+	for _, s := range sources {
+		_, err := tx.ExecContext(ctx,
+			"UPDATE topics SET post_count = post_count - $2, comment_count = comment_count - $3, last_post_id = "+topicLastPost+" WHERE id = $1",
+			s.topicID, s.posts, s.comments,
+		)
+		if err != nil {
+			return 0, err
+		}
+		moved += s.posts
+		comments += s.comments
+	}
+	if moved == 0 {
+		return 0, tx.Commit()
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE topics SET
+			post_count = post_count + $2,
+			comment_count = comment_count + $3,
+			last_post_id = `+topicLastPost+`,
+			last_activity_at = GREATEST(last_activity_at, $4)
+		WHERE id = $1`,
+		topicID, moved, comments, lastActivity,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return moved, tx.Commit()
 }
 
-func (r *PostRepository) Delete(id int) error {
-	_, err := r.DB.Exec("DELETE FROM posts WHERE id = $1", id)
+// topicLastPost — подзапрос последнего неудаленного поста темы для UPDATE topics
+const topicLastPost = "(SELECT p.id FROM posts p WHERE p.topic_id = topics.id AND p.deleted_at IS NULL ORDER BY p.timestamp DESC, p.id DESC LIMIT 1)"
+
+// setPostTags заменяет теги поста переданным списком уже нормализованных имен
+func setPostTags(ctx context.Context, tx *sql.Tx, postID int, tags []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM post_tags WHERE post_id = $1", postID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO tags (name, created_at) SELECT UNNEST($1::text[]), $2 ON CONFLICT (name) DO NOTHING",
+		pq.Array(tags), time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO post_tags (post_id, tag_id) SELECT $1, id FROM tags WHERE name = ANY($2)", postID, pq.Array(tags))
 	return err
 }
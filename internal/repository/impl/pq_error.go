@@ -0,0 +1,31 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"golangforum/internal/repository"
+)
+
+// Коды ошибок PostgreSQL, которые репозитории переводят в собственные ошибки
+const (
+	pqForeignKeyViolation = "23503"
+	pqUniqueViolation     = "23505"
+)
+
+// mapPQError переводит нарушение уникальности в ErrConflict, а нарушение внешнего ключа — в ErrForeignKey.
+// Исходная ошибка остается в цепочке, остальные ошибки возвращаются без изменений
+func mapPQError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		return fmt.Errorf("%w: %w", repository.ErrConflict, err)
+	case pqForeignKeyViolation:
+		return fmt.Errorf("%w: %w", repository.ErrForeignKey, err)
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"golangforum/internal/repository"
+)
+
+func TestMapPQError(t *testing.T) {
+	fk := &pq.Error{Code: pqForeignKeyViolation, Constraint: "posts_topic_id_fkey"}
+	err := mapPQError(fmt.Errorf("insert post: %w", fk))
+	assert.ErrorIs(t, err, repository.ErrForeignKey)
+	var pqErr *pq.Error
+	assert.True(t, errors.As(err, &pqErr))
+	assert.Equal(t, "posts_topic_id_fkey", pqErr.Constraint)
+
+	assert.ErrorIs(t, mapPQError(&pq.Error{Code: pqUniqueViolation}), repository.ErrConflict)
+
+	other := &pq.Error{Code: "23502"}
+	assert.Equal(t, error(other), mapPQError(other))
+	assert.Nil(t, mapPQError(nil))
+}
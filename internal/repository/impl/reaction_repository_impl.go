@@ -0,0 +1,206 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+)
+
+type ReactionRepository struct {
+	DB *sql.DB
+}
+
+func NewReactionRepository(db *sql.DB) *ReactionRepository {
+	return &ReactionRepository{DB: db}
+}
+
+// reactionTarget возвращает таблицу объекта и колонку ссылки на него в таблицах votes и reactions
+func reactionTarget(targetType string) (table, column string, err error) {
+	switch targetType {
+	case model.TargetPost:
+		return "posts", "post_id", nil
+	case model.TargetComment:
+		return "comments", "comment_id", nil
+	}
+	return "", "", fmt.Errorf("unknown reaction target type %q", targetType)
+}
+
+// Vote переключает голос пользователя: новый голос добавляется, повторный такой же — снимается,
+// противоположный — заменяет прежний. Счетчики объекта обновляются в той же транзакции
+func (r *ReactionRepository) Vote(ctx context.Context, targetType string, targetID, userID, value int) (_ *model.VoteResult, err error) {
+	defer logFailure(ctx, "ReactionRepository.Vote", &err)
+	table, column, err := reactionTarget(targetType)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockRow(ctx, tx, table, targetID); err != nil {
+		return nil, err
+	}
+
+	var prev int
+	err = tx.QueryRowContext(ctx, "SELECT value FROM votes WHERE "+column+" = $1 AND user_id = $2", targetID, userID).Scan(&prev)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	res := &model.VoteResult{MyVote: value}
+	switch {
+	case prev == 0:
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO votes ("+column+", user_id, value, timestamp) VALUES ($1, $2, $3, $4)",
+			targetID, userID, value, time.Now(),
+		)
+	case prev == value:
+		res.MyVote = 0
+		_, err = tx.ExecContext(ctx, "DELETE FROM votes WHERE "+column+" = $1 AND user_id = $2", targetID, userID)
+	default:
+		_, err = tx.ExecContext(ctx,
+			"UPDATE votes SET value = $3, timestamp = $4 WHERE "+column+" = $1 AND user_id = $2",
+			targetID, userID, value, time.Now(),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	up, down := voteCounts(res.MyVote)
+	prevUp, prevDown := voteCounts(prev)
+	err = tx.QueryRowContext(ctx,
+		"UPDATE "+table+" SET upvotes = upvotes + $2, downvotes = downvotes + $3, score = score + $4 WHERE id = $1 RETURNING score, upvotes, downvotes",
+		targetID, up-prevUp, down-prevDown, res.MyVote-prev,
+	).Scan(&res.Score, &res.Upvotes, &res.Downvotes)
+	if err != nil {
+		return nil, err
+	}
+	return res, tx.Commit()
+}
+
+// ToggleReaction ставит реакцию, если ее еще нет, и снимает уже поставленную
+func (r *ReactionRepository) ToggleReaction(ctx context.Context, targetType string, targetID, userID int, emoji string) (_ *model.ReactionCount, err error) {
+	defer logFailure(ctx, "ReactionRepository.ToggleReaction", &err)
+	table, column, err := reactionTarget(targetType)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockRow(ctx, tx, table, targetID); err != nil {
+		return nil, err
+	}
+
+	res := &model.ReactionCount{Emoji: emoji}
+	deleted, err := tx.ExecContext(ctx, "DELETE FROM reactions WHERE "+column+" = $1 AND user_id = $2 AND emoji = $3", targetID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+	n, err := deleted.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		res.Reacted = true
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO reactions ("+column+", user_id, emoji, timestamp) VALUES ($1, $2, $3, $4)",
+			targetID, userID, emoji, time.Now(),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM reactions WHERE "+column+" = $1 AND emoji = $2", targetID, emoji).Scan(&res.Count); err != nil {
+		return nil, err
+	}
+	return res, tx.Commit()
+}
+
+// GetSummaries возвращает реакции и голос пользователя userID для каждого объекта; userID = 0 — анонимный пользователь
+func (r *ReactionRepository) GetSummaries(ctx context.Context, targetType string, targetIDs []int, userID int) (_ map[int]model.ReactionSummary, err error) {
+	defer logFailure(ctx, "ReactionRepository.GetSummaries", &err)
+	_, column, err := reactionTarget(targetType)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int]model.ReactionSummary, len(targetIDs))
+
+	rows, err := r.DB.QueryContext(ctx,
+		"SELECT "+column+", emoji, COUNT(*), BOOL_OR(user_id = $2) FROM reactions WHERE "+column+" = ANY($1) GROUP BY "+column+", emoji ORDER BY COUNT(*) DESC, emoji",
+		pq.Array(targetIDs), userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id int
+			rc model.ReactionCount
+		)
+		if err := rows.Scan(&id, &rc.Emoji, &rc.Count, &rc.Reacted); err != nil {
+			return nil, err
+		}
+		s := res[id]
+		s.Reactions = append(s.Reactions, rc)
+		res[id] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if userID == 0 {
+		return res, nil
+	}
+
+	votes, err := r.DB.QueryContext(ctx,
+		"SELECT "+column+", value FROM votes WHERE "+column+" = ANY($1) AND user_id = $2",
+		pq.Array(targetIDs), userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer votes.Close()
+	for votes.Next() {
+		var id, value int
+		if err := votes.Scan(&id, &value); err != nil {
+			return nil, err
+		}
+		s := res[id]
+		s.MyVote = value
+		res[id] = s
+	}
+	return res, votes.Err()
+}
+
+// lockRow блокирует строку объекта до конца транзакции, чтобы голоса одного объекта применялись последовательно.
+// Удаленный объект считается отсутствующим
+func lockRow(ctx context.Context, tx *sql.Tx, table string, id int) error {
+	var locked int
+	err := tx.QueryRowContext(ctx, "SELECT id FROM "+table+" WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", id).Scan(&locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+func voteCounts(value int) (up, down int) {
+	switch value {
+	case 1:
+		return 1, 0
+	case -1:
+		return 0, 1
+	}
+	return 0, 0
+}
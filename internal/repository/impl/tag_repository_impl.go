@@ -0,0 +1,131 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+)
+
+type TagRepository struct {
+	DB *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{DB: db}
+}
+
+// GetByPosts возвращает имена тегов для каждого поста в алфавитном порядке
+func (r *TagRepository) GetByPosts(ctx context.Context, postIDs []int) (_ map[int][]string, err error) {
+	defer logFailure(ctx, "TagRepository.GetByPosts", &err)
+	rows, err := r.DB.QueryContext(ctx,
+		"SELECT pt.post_id, t.name FROM post_tags pt JOIN tags t ON t.id = pt.tag_id WHERE pt.post_id = ANY($1) ORDER BY pt.post_id, t.name",
+		pq.Array(postIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int][]string)
+	for rows.Next() {
+		var (
+			postID int
+			name   string
+		)
+		if err := rows.Scan(&postID, &name); err != nil {
+			return nil, err
+		}
+		res[postID] = append(res[postID], name)
+	}
+	return res, rows.Err()
+}
+
+// List возвращает теги, начинающиеся с prefix, от самых используемых к менее используемым; удаленные посты не учитываются
+func (r *TagRepository) List(ctx context.Context, prefix string, limit int) (_ []model.Tag, err error) {
+	defer logFailure(ctx, "TagRepository.List", &err)
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT t.id, t.name, COUNT(p.id) FROM tags t
+		LEFT JOIN post_tags pt ON pt.tag_id = t.id
+		LEFT JOIN posts p ON p.id = pt.post_id AND p.deleted_at IS NULL
+		WHERE t.name LIKE $1 GROUP BY t.id ORDER BY COUNT(p.id) DESC, t.name LIMIT $2`,
+		escapeLike(prefix)+"%", limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []model.Tag
+	for rows.Next() {
+		var t model.Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.PostCount); err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
+
+// Rename меняет имя тега; если тег с таким именем уже есть, возвращается ErrConflict — такие теги нужно сливать
+func (r *TagRepository) Rename(ctx context.Context, id int, name string) (_ *model.Tag, err error) {
+	defer logFailure(ctx, "TagRepository.Rename", &err)
+	t := model.Tag{ID: id, Name: name}
+	err = r.DB.QueryRowContext(ctx,
+		"UPDATE tags SET name = $2 WHERE id = $1 RETURNING (SELECT COUNT(*) FROM post_tags WHERE tag_id = $1)",
+		id, name,
+	).Scan(&t.PostCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, mapPQError(err)
+	}
+	return &t, nil
+}
+
+// Merge переносит тег sourceID на все его посты в виде targetID и удаляет sourceID
+func (r *TagRepository) Merge(ctx context.Context, sourceID, targetID int) (_ *model.Tag, err error) {
+	defer logFailure(ctx, "TagRepository.Merge", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var n int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM tags WHERE id IN ($1, $2)", sourceID, targetID).Scan(&n); err != nil {
+		return nil, err
+	}
+	if n != 2 {
+		return nil, repository.ErrNotFound
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO post_tags (post_id, tag_id) SELECT post_id, $2 FROM post_tags WHERE tag_id = $1 ON CONFLICT DO NOTHING",
+		sourceID, targetID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE id = $1", sourceID); err != nil {
+		return nil, err
+	}
+	t := model.Tag{ID: targetID}
+	err = tx.QueryRowContext(ctx,
+		"SELECT name, (SELECT COUNT(*) FROM post_tags WHERE tag_id = $1) FROM tags WHERE id = $1",
+		targetID,
+	).Scan(&t.Name, &t.PostCount)
+	if err != nil {
+		return nil, err
+	}
+	return &t, tx.Commit()
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE, чтобы префикс сравнивался буквально
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -1,11 +1,19 @@
 package impl
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"time"
 
+	"github.com/lib/pq"
 	"golangforum/internal/model"
+	"golangforum/internal/repository"
 )
 
+// maxTopicDepth ограничивает обход дерева тем на случай поврежденных данных
+const maxTopicDepth = 64
+
 type TopicRepository struct {
 	DB *sql.DB
 }
@@ -14,18 +22,71 @@ func NewTopicRepository(db *sql.DB) *TopicRepository {
 	return &TopicRepository{DB: db}
 }
 
-func (r *TopicRepository) Create(topic *model.Topic) error {
-	_, err := r.DB.Exec(
-		"INSERT INTO topics (title, description, created_at) VALUES ($1, $2, $3)",
-		topic.Title, topic.Description, topic.CreatedAt,
-	)
-	return err
+func (r *TopicRepository) Create(ctx context.Context, topic *model.Topic) (err error) {
+	defer logFailure(ctx, "TopicRepository.Create", &err)
+	err = r.DB.QueryRowContext(ctx,
+		"INSERT INTO topics (parent_id, position, title, description, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		topic.ParentID, topic.Position, topic.Title, topic.Description, topic.CreatedAt,
+	).Scan(&topic.ID)
+	return mapPQError(err)
 }
 
-func (r *TopicRepository) GetAll() ([]model.Topic, error) {
-	rows, err := r.DB.Query(
-		"SELECT id, title, description, created_at FROM topics",
+// topicSelect выбирает тему вместе со сводкой ее последнего поста
+const topicSelect = `SELECT t.id, t.parent_id, t.position, t.title, t.description, t.created_at, t.archived, t.locked,
+	t.post_count, t.comment_count, t.last_activity_at, lp.id, lp.title, lp.username, lp.timestamp
+	FROM topics t LEFT JOIN posts lp ON lp.id = t.last_post_id`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTopic(row rowScanner) (*model.Topic, error) {
+	var (
+		t            model.Topic
+		parentID     sql.NullInt64
+		lastActivity sql.NullTime
+		lastID       sql.NullInt64
+		lastTitle    sql.NullString
+		lastUsername sql.NullString
+		lastTime     sql.NullTime
 	)
+	if err := row.Scan(
+		&t.ID, &parentID, &t.Position, &t.Title, &t.Description, &t.CreatedAt, &t.Archived, &t.Locked,
+		&t.PostCount, &t.CommentCount, &lastActivity, &lastID, &lastTitle, &lastUsername, &lastTime,
+	); err != nil {
+		return nil, err
+	}
+	t.ParentID = nullIntPtr(parentID)
+	if lastActivity.Valid {
+		t.LastActivityAt = &lastActivity.Time
+	}
+	if lastID.Valid {
+		t.LastPost = &model.TopicLastPost{
+			ID:        int(lastID.Int64),
+			Title:     lastTitle.String,
+			Username:  lastUsername.String,
+			Timestamp: lastTime.Time,
+		}
+	}
+	return &t, nil
+}
+
+func (r *TopicRepository) GetByID(ctx context.Context, id int) (_ *model.Topic, err error) {
+	defer logFailure(ctx, "TopicRepository.GetByID", &err)
+	t, err := scanTopic(r.DB.QueryRowContext(ctx, topicSelect+" WHERE t.id = $1 AND t.deleted_at IS NULL", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetAll возвращает все неудаленные темы со статистикой в порядке отображения
+func (r *TopicRepository) GetAll(ctx context.Context) (_ []model.Topic, err error) {
+	defer logFailure(ctx, "TopicRepository.GetAll", &err)
+	rows, err := r.DB.QueryContext(ctx, topicSelect+" WHERE t.deleted_at IS NULL ORDER BY t.position, t.id")
 	if err != nil {
 		return nil, err
 	}
@@ -33,16 +94,299 @@ func (r *TopicRepository) GetAll() ([]model.Topic, error) {
 
 	var topics []model.Topic
 	for rows.Next() {
-		var t model.Topic
-		if err := rows.Scan(&t.ID, &t.Title, &t.Description, &t.CreatedAt); err != nil {
+		t, err := scanTopic(rows)
+		if err != nil {
 			return nil, err
 		}
-		topics = append(topics, t)
+		topics = append(topics, *t)
 	}
 	return topics, rows.Err()
 }
 
-func (r *TopicRepository) Delete(id int) error {
-	_, err := r.DB.Exec("DELETE FROM topics WHERE id = $1", id)
-	return err
+// GetPaths возвращает для каждой темы цепочку тем от верхнего уровня до нее самой
+func (r *TopicRepository) GetPaths(ctx context.Context, topicIDs []int) (_ map[int][]model.TopicRef, err error) {
+	defer logFailure(ctx, "TopicRepository.GetPaths", &err)
+	rows, err := r.DB.QueryContext(ctx,
+		`WITH RECURSIVE path AS (
+			SELECT id AS leaf_id, id, parent_id, title, 0 AS depth FROM topics WHERE id = ANY($1)
+			UNION ALL
+			SELECT p.leaf_id, t.id, t.parent_id, t.title, p.depth + 1 FROM topics t JOIN path p ON t.id = p.parent_id
+			WHERE p.depth < $2
+		)
+		SELECT leaf_id, id, title FROM path ORDER BY leaf_id, depth DESC`,
+		pq.Array(topicIDs), maxTopicDepth,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[int][]model.TopicRef)
+	for rows.Next() {
+		var (
+			leafID int
+			ref    model.TopicRef
+		)
+		if err := rows.Scan(&leafID, &ref.ID, &ref.Title); err != nil {
+			return nil, err
+		}
+		res[leafID] = append(res[leafID], ref)
+	}
+	return res, rows.Err()
+}
+
+// Update изменяет заголовок, описание и порядок отображения темы; родитель меняется только через Move
+func (r *TopicRepository) Update(ctx context.Context, topic *model.Topic) (err error) {
+	defer logFailure(ctx, "TopicRepository.Update", &err)
+	res, err := r.DB.ExecContext(ctx,
+		"UPDATE topics SET title = $2, description = $3, position = $4 WHERE id = $1 AND deleted_at IS NULL",
+		topic.ID, topic.Title, topic.Description, topic.Position,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+// SetState изменяет признаки архива и закрытия темы; nil оставляет признак без изменений
+func (r *TopicRepository) SetState(ctx context.Context, id int, archived, locked *bool) (err error) {
+	defer logFailure(ctx, "TopicRepository.SetState", &err)
+	res, err := r.DB.ExecContext(ctx,
+		"UPDATE topics SET archived = COALESCE($2, archived), locked = COALESCE($3, locked) WHERE id = $1 AND deleted_at IS NULL",
+		id, archived, locked,
+	)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res)
+}
+
+// GetByPost возвращает тему, в которой находится пост
+func (r *TopicRepository) GetByPost(ctx context.Context, postID int) (_ *model.Topic, err error) {
+	defer logFailure(ctx, "TopicRepository.GetByPost", &err)
+	t, err := scanTopic(r.DB.QueryRowContext(ctx,
+		topicSelect+" WHERE t.id = (SELECT topic_id FROM posts WHERE id = $1 AND deleted_at IS NULL) AND t.deleted_at IS NULL",
+		postID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Move переносит тему под parentID (nil — на верхний уровень). Если parentID совпадает с темой или
+// находится в ее поддереве, возвращается ErrConflict, если parentID не существует — ErrForeignKey. Таблица блокируется на время переноса,
+// чтобы два встречных переноса не образовали цикл
+func (r *TopicRepository) Move(ctx context.Context, id int, parentID *int) (err error) {
+	defer logFailure(ctx, "TopicRepository.Move", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "LOCK TABLE topics IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return err
+	}
+	if parentID != nil {
+		var cycle bool
+		err := tx.QueryRowContext(ctx,
+			`WITH RECURSIVE ancestors AS (
+				SELECT id, parent_id, 0 AS depth FROM topics WHERE id = $1
+				UNION ALL
+				SELECT t.id, t.parent_id, a.depth + 1 FROM topics t JOIN ancestors a ON t.id = a.parent_id
+				WHERE a.depth < $3
+			)
+			SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)`,
+			*parentID, id, maxTopicDepth,
+		).Scan(&cycle)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return repository.ErrConflict
+		}
+	}
+	res, err := tx.ExecContext(ctx, "UPDATE topics SET parent_id = $2 WHERE id = $1 AND deleted_at IS NULL", id, parentID)
+	if err != nil {
+		return mapPQError(err)
+	}
+	if err := checkAffected(res); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RecomputeStats в одной транзакции пересчитывает количество комментариев и время последней активности
+// неудаленных постов, а затем счетчики и последний пост неудаленных тем по неудаленным постам и комментариям.
+// Статистика удаленных тем и постов не меняется, чтобы после восстановления она совпала с восстановленными
+// объектами. Возвращает количество тем и постов, статистика которых расходилась с фактической
+func (r *TopicRepository) RecomputeStats(ctx context.Context) (_ model.StatsRecount, err error) {
+	defer logFailure(ctx, "TopicRepository.RecomputeStats", &err)
+	var n model.StatsRecount
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return n, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`WITH stats AS (
+			SELECT p.id,
+				COUNT(c.id) AS comment_count,
+				GREATEST(p.timestamp, MAX(c.timestamp)) AS last_activity_at
+			FROM posts p
+			LEFT JOIN comments c ON c.post_id = p.id AND c.deleted_at IS NULL
+			WHERE p.deleted_at IS NULL
+			GROUP BY p.id
+		)
+		UPDATE posts p SET
+			comment_count = s.comment_count,
+			last_activity_at = s.last_activity_at
+		FROM stats s
+		WHERE s.id = p.id AND (p.comment_count, p.last_activity_at) IS DISTINCT FROM (s.comment_count, s.last_activity_at)`,
+	)
+	if err != nil {
+		return n, err
+	}
+	posts, err := res.RowsAffected()
+	if err != nil {
+		return n, err
+	}
+	n.Posts = int(posts)
+
+	res, err = tx.ExecContext(ctx,
+		`WITH post_stats AS (
+			SELECT p.topic_id,
+				COUNT(*) AS post_count,
+				(ARRAY_AGG(p.id ORDER BY p.timestamp DESC, p.id DESC))[1] AS last_post_id,
+				MAX(p.timestamp) AS last_post_at
+			FROM posts p WHERE p.deleted_at IS NULL GROUP BY p.topic_id
+		), comment_stats AS (
+			SELECT p.topic_id, COUNT(*) AS comment_count, MAX(c.timestamp) AS last_comment_at
+			FROM comments c JOIN posts p ON p.id = c.post_id
+			WHERE c.deleted_at IS NULL AND p.deleted_at IS NULL GROUP BY p.topic_id
+		), stats AS (
+			SELECT t.id,
+				COALESCE(ps.post_count, 0) AS post_count,
+				COALESCE(cs.comment_count, 0) AS comment_count,
+				ps.last_post_id,
+				GREATEST(ps.last_post_at, cs.last_comment_at) AS last_activity_at
+			FROM topics t
+			LEFT JOIN post_stats ps ON ps.topic_id = t.id
+			LEFT JOIN comment_stats cs ON cs.topic_id = t.id
+			WHERE t.deleted_at IS NULL
+		)
+		UPDATE topics t SET
+			post_count = s.post_count,
+			comment_count = s.comment_count,
+			last_post_id = s.last_post_id,
+			last_activity_at = s.last_activity_at
+		FROM stats s
+		WHERE s.id = t.id AND (t.post_count, t.comment_count, t.last_post_id, t.last_activity_at)
+			IS DISTINCT FROM (s.post_count, s.comment_count, s.last_post_id, s.last_activity_at)`,
+	)
+	if err != nil {
+		return n, err
+	}
+	topics, err := res.RowsAffected()
+	if err != nil {
+		return n, err
+	}
+	n.Topics = int(topics)
+	return n, tx.Commit()
+}
+
+// Delete помечает удаленными тему, ее подтемы, их посты и комментарии. Все они получают одно время удаления,
+// по которому восстановление отличает объекты, удаленные вместе с темой, от удаленных раньше по отдельности.
+// deletedBy = 0 — удаливший пользователь неизвестен
+func (r *TopicRepository) Delete(ctx context.Context, id, deletedBy int) (err error) {
+	defer logFailure(ctx, "TopicRepository.Delete", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	rows, err := tx.QueryContext(ctx,
+		`WITH RECURSIVE subtree AS (
+			SELECT id, 0 AS depth FROM topics WHERE id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, s.depth + 1 FROM topics t JOIN subtree s ON t.parent_id = s.id
+			WHERE t.deleted_at IS NULL AND s.depth < $4
+		)
+		UPDATE topics SET deleted_at = $2, deleted_by = $3 WHERE id IN (SELECT id FROM subtree) RETURNING id`,
+		id, now, nullableID(deletedBy), maxTopicDepth,
+	)
+	if err != nil {
+		return err
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return repository.ErrNotFound
+	}
+	_, err = tx.ExecContext(ctx,
+		"UPDATE posts SET deleted_at = $2, deleted_by = $3 WHERE topic_id = ANY($1) AND deleted_at IS NULL",
+		pq.Array(ids), now, nullableID(deletedBy),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		"UPDATE comments SET deleted_at = $2, deleted_by = $3 WHERE deleted_at IS NULL AND post_id IN (SELECT id FROM posts WHERE topic_id = ANY($1))",
+		pq.Array(ids), now, nullableID(deletedBy),
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// checkAffected возвращает ErrNotFound, если запрос не изменил ни одной строки
+func checkAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// scanIDs читает и закрывает выборку из одной колонки с ID
+func scanIDs(rows *sql.Rows) ([]int, error) {
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// nullableID превращает нулевой ID в NULL
+func nullableID(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
 }
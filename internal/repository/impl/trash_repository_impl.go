@@ -0,0 +1,262 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+)
+
+type TrashRepository struct {
+	DB *sql.DB
+}
+
+func NewTrashRepository(db *sql.DB) *TrashRepository {
+	return &TrashRepository{DB: db}
+}
+
+// trashSelects — выборки удаленных объектов каждого типа в формате TrashItem
+var trashSelects = map[string]string{
+	model.TrashTopic:   "SELECT id, parent_id, title, '', deleted_at, deleted_by FROM topics WHERE deleted_at IS NOT NULL",
+	model.TrashPost:    "SELECT id, topic_id, title, username, deleted_at, deleted_by FROM posts WHERE deleted_at IS NOT NULL",
+	model.TrashComment: "SELECT id, post_id, LEFT(content, 100), username, deleted_at, deleted_by FROM comments WHERE deleted_at IS NOT NULL",
+}
+
+var trashTables = map[string]string{
+	model.TrashTopic:   "topics",
+	model.TrashPost:    "posts",
+	model.TrashComment: "comments",
+}
+
+// List возвращает удаленные объекты типа itemType, начиная с удаленных последними
+func (r *TrashRepository) List(ctx context.Context, itemType string, limit, offset int) (_ []model.TrashItem, err error) {
+	defer logFailure(ctx, "TrashRepository.List", &err)
+	query, ok := trashSelects[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unknown trash item type %q", itemType)
+	}
+	rows, err := r.DB.QueryContext(ctx, query+" ORDER BY deleted_at DESC, id DESC LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.TrashItem
+	for rows.Next() {
+		var (
+			item      = model.TrashItem{Type: itemType}
+			parentID  sql.NullInt64
+			deletedBy sql.NullInt64
+		)
+		if err := rows.Scan(&item.ID, &parentID, &item.Title, &item.Username, &item.DeletedAt, &deletedBy); err != nil {
+			return nil, err
+		}
+		item.ParentID = nullIntPtr(parentID)
+		item.DeletedBy = nullIntPtr(deletedBy)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (r *TrashRepository) Count(ctx context.Context, itemType string) (_ int, err error) {
+	defer logFailure(ctx, "TrashRepository.Count", &err)
+	table, ok := trashTables[itemType]
+	if !ok {
+		return 0, fmt.Errorf("unknown trash item type %q", itemType)
+	}
+	var n int
+	err = r.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE deleted_at IS NOT NULL").Scan(&n)
+	return n, err
+}
+
+// Restore восстанавливает объект вместе с вложенными объектами, удаленными в тот же момент. Объекты, удаленные
+// раньше по отдельности, остаются в корзине. Если удален родитель объекта, возвращается ErrConflict:
+// сначала нужно восстановить родителя
+func (r *TrashRepository) Restore(ctx context.Context, itemType string, id int) (err error) {
+	defer logFailure(ctx, "TrashRepository.Restore", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	switch itemType {
+	case model.TrashTopic:
+		err = restoreTopic(ctx, tx, id)
+	case model.TrashPost:
+		err = restorePost(ctx, tx, id)
+	case model.TrashComment:
+		err = restoreComment(ctx, tx, id)
+	default:
+		err = fmt.Errorf("unknown trash item type %q", itemType)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func restoreTopic(ctx context.Context, tx *sql.Tx, id int) error {
+	var (
+		deletedAt time.Time
+		parentID  sql.NullInt64
+	)
+	err := tx.QueryRowContext(ctx, "SELECT deleted_at, parent_id FROM topics WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE", id).Scan(&deletedAt, &parentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if parentID.Valid {
+		if err := checkParentAlive(ctx, tx, "topics", int(parentID.Int64)); err != nil {
+			return err
+		}
+	}
+	rows, err := tx.QueryContext(ctx,
+		`WITH RECURSIVE subtree AS (
+			SELECT id, 0 AS depth FROM topics WHERE id = $1
+			UNION ALL
+			SELECT t.id, s.depth + 1 FROM topics t JOIN subtree s ON t.parent_id = s.id
+			WHERE t.deleted_at = $2 AND s.depth < $3
+		)
+		UPDATE topics SET deleted_at = NULL, deleted_by = NULL WHERE id IN (SELECT id FROM subtree) RETURNING id`,
+		id, deletedAt, maxTopicDepth,
+	)
+	if err != nil {
+		return err
+	}
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE posts SET deleted_at = NULL, deleted_by = NULL WHERE topic_id = ANY($1) AND deleted_at = $2",
+		pq.Array(ids), deletedAt,
+	); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		"UPDATE comments SET deleted_at = NULL, deleted_by = NULL WHERE deleted_at = $2 AND post_id IN (SELECT id FROM posts WHERE topic_id = ANY($1))",
+		pq.Array(ids), deletedAt,
+	)
+	return err
+}
+
+func restorePost(ctx context.Context, tx *sql.Tx, id int) error {
+	var (
+		deletedAt time.Time
+		topicID   int
+	)
+	err := tx.QueryRowContext(ctx, "SELECT deleted_at, topic_id FROM posts WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE", id).Scan(&deletedAt, &topicID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkParentAlive(ctx, tx, "topics", topicID); err != nil {
+		return err
+	}
+	var (
+		comments     int
+		lastActivity time.Time
+	)
+	err = tx.QueryRowContext(ctx,
+		"UPDATE posts SET deleted_at = NULL, deleted_by = NULL WHERE id = $1 RETURNING comment_count, last_activity_at", id,
+	).Scan(&comments, &lastActivity)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE comments SET deleted_at = NULL, deleted_by = NULL WHERE post_id = $1 AND deleted_at = $2", id, deletedAt); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`UPDATE topics SET
+			post_count = post_count + 1,
+			comment_count = comment_count + $2,
+			last_post_id = `+topicLastPost+`,
+			last_activity_at = GREATEST(last_activity_at, $3)
+		WHERE id = $1`,
+		topicID, comments, lastActivity,
+	)
+	return err
+}
+
+func restoreComment(ctx context.Context, tx *sql.Tx, id int) error {
+	var postID int
+	err := tx.QueryRowContext(ctx, "SELECT post_id FROM comments WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE", id).Scan(&postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkParentAlive(ctx, tx, "posts", postID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE comments SET deleted_at = NULL, deleted_by = NULL WHERE id = $1", id); err != nil {
+		return err
+	}
+	var topicID int
+	if err := tx.QueryRowContext(ctx, "UPDATE posts SET comment_count = comment_count + 1 WHERE id = $1 RETURNING topic_id", postID).Scan(&topicID); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE topics SET comment_count = comment_count + 1 WHERE id = $1", topicID)
+	return err
+}
+
+// checkParentAlive блокирует родительскую строку и возвращает ErrConflict, если родитель удален
+func checkParentAlive(ctx context.Context, tx *sql.Tx, table string, id int) error {
+	var deleted bool
+	if err := tx.QueryRowContext(ctx, "SELECT deleted_at IS NOT NULL FROM "+table+" WHERE id = $1 FOR UPDATE", id).Scan(&deleted); err != nil {
+		return err
+	}
+	if deleted {
+		return repository.ErrConflict
+	}
+	return nil
+}
+
+// Purge окончательно стирает объекты, удаленные раньше before, и возвращает их количество.
+// Вложенные объекты удаляются не позже родителя, поэтому каскадное удаление по внешним ключам не задевает живые строки
+func (r *TrashRepository) Purge(ctx context.Context, before time.Time) (_ int, err error) {
+	defer logFailure(ctx, "TrashRepository.Purge", &err)
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	total := 0
+	for _, table := range []string{"comments", "posts", "topics"} {
+		res, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE deleted_at < $1", before)
+		if err != nil {
+			return 0, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		total += int(n)
+	}
+	return total, tx.Commit()
+}
+
+// CountPurgeable возвращает количество объектов, которые Purge с тем же before сотрет окончательно
+func (r *TrashRepository) CountPurgeable(ctx context.Context, before time.Time) (_ int, err error) {
+	defer logFailure(ctx, "TrashRepository.CountPurgeable", &err)
+	var n int
+	err = r.DB.QueryRowContext(ctx,
+		`SELECT (SELECT COUNT(*) FROM comments WHERE deleted_at < $1)
+			+ (SELECT COUNT(*) FROM posts WHERE deleted_at < $1)
+			+ (SELECT COUNT(*) FROM topics WHERE deleted_at < $1)`,
+		before,
+	).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"golangforum/internal/model"
+)
+
+type MentionRepository interface {
+	Create(ctx context.Context, m *model.Mention) error
+	GetByUsername(ctx context.Context, username string, limit, offset int) ([]model.Mention, error)
+	CountByUsername(ctx context.Context, username string) (int, error)
+	DeleteBySource(ctx context.Context, sourceType string, sourceID int) error
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/activity_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/activity_repository.go -destination=internal/repository/mocks/activity_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockActivityRepository is a mock of ActivityRepository interface.
+type MockActivityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityRepositoryMockRecorder is the mock recorder for MockActivityRepository.
+type MockActivityRepositoryMockRecorder struct {
+	mock *MockActivityRepository
+}
+
+// NewMockActivityRepository creates a new mock instance.
+func NewMockActivityRepository(ctrl *gomock.Controller) *MockActivityRepository {
+	mock := &MockActivityRepository{ctrl: ctrl}
+	mock.recorder = &MockActivityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityRepository) EXPECT() *MockActivityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByUser mocks base method.
+func (m *MockActivityRepository) CountByUser(ctx context.Context, userID int) (*model.UserContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUser", ctx, userID)
+	ret0, _ := ret[0].(*model.UserContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUser indicates an expected call of CountByUser.
+func (mr *MockActivityRepositoryMockRecorder) CountByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUser", reflect.TypeOf((*MockActivityRepository)(nil).CountByUser), ctx, userID)
+}
+
+// PurgeUser mocks base method.
+func (m *MockActivityRepository) PurgeUser(ctx context.Context, userID int) (*model.UserContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", ctx, userID)
+	ret0, _ := ret[0].(*model.UserContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockActivityRepositoryMockRecorder) PurgeUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockActivityRepository)(nil).PurgeUser), ctx, userID)
+}
+
+// Recent mocks base method.
+func (m *MockActivityRepository) Recent(ctx context.Context, userID, limit int) ([]model.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recent", ctx, userID, limit)
+	ret0, _ := ret[0].([]model.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recent indicates an expected call of Recent.
+func (mr *MockActivityRepositoryMockRecorder) Recent(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recent", reflect.TypeOf((*MockActivityRepository)(nil).Recent), ctx, userID, limit)
+}
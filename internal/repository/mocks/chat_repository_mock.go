@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	model "golangforum/internal/model"
 	reflect "reflect"
 	time "time"
@@ -41,45 +42,61 @@ func (m *MockChatRepository) EXPECT() *MockChatRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountMessagesOlderThan mocks base method.
+func (m *MockChatRepository) CountMessagesOlderThan(ctx context.Context, t time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMessagesOlderThan", ctx, t)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMessagesOlderThan indicates an expected call of CountMessagesOlderThan.
+func (mr *MockChatRepositoryMockRecorder) CountMessagesOlderThan(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMessagesOlderThan", reflect.TypeOf((*MockChatRepository)(nil).CountMessagesOlderThan), ctx, t)
+}
+
 // DeleteMessagesOlderThan mocks base method.
-func (m *MockChatRepository) DeleteMessagesOlderThan(arg0 time.Time) error {
+func (m *MockChatRepository) DeleteMessagesOlderThan(ctx context.Context, t time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMessagesOlderThan", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteMessagesOlderThan", ctx, t)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteMessagesOlderThan indicates an expected call of DeleteMessagesOlderThan.
-func (mr *MockChatRepositoryMockRecorder) DeleteMessagesOlderThan(arg0 any) *gomock.Call {
+func (mr *MockChatRepositoryMockRecorder) DeleteMessagesOlderThan(ctx, t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessagesOlderThan", reflect.TypeOf((*MockChatRepository)(nil).DeleteMessagesOlderThan), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessagesOlderThan", reflect.TypeOf((*MockChatRepository)(nil).DeleteMessagesOlderThan), ctx, t)
 }
 
 // GetAllMessages mocks base method.
-func (m *MockChatRepository) GetAllMessages() ([]model.Message, error) {
+func (m *MockChatRepository) GetAllMessages(ctx context.Context) ([]model.Message, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllMessages")
+	ret := m.ctrl.Call(m, "GetAllMessages", ctx)
 	ret0, _ := ret[0].([]model.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllMessages indicates an expected call of GetAllMessages.
-func (mr *MockChatRepositoryMockRecorder) GetAllMessages() *gomock.Call {
+func (mr *MockChatRepositoryMockRecorder) GetAllMessages(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllMessages", reflect.TypeOf((*MockChatRepository)(nil).GetAllMessages))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllMessages", reflect.TypeOf((*MockChatRepository)(nil).GetAllMessages), ctx)
 }
 
 // SaveMessage mocks base method.
-func (m *MockChatRepository) SaveMessage(arg0 *model.Message) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveMessage", arg0)
+func (m_2 *MockChatRepository) SaveMessage(ctx context.Context, m *model.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SaveMessage", ctx, m)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveMessage indicates an expected call of SaveMessage.
-func (mr *MockChatRepositoryMockRecorder) SaveMessage(arg0 any) *gomock.Call {
+func (mr *MockChatRepositoryMockRecorder) SaveMessage(ctx, m any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMessage", reflect.TypeOf((*MockChatRepository)(nil).SaveMessage), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMessage", reflect.TypeOf((*MockChatRepository)(nil).SaveMessage), ctx, m)
 }
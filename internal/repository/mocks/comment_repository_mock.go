@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	model "golangforum/internal/model"
 	reflect "reflect"
 
@@ -41,44 +42,59 @@ func (m *MockCommentRepository) EXPECT() *MockCommentRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockCommentRepository) Create(c *model.Comment) error {
+func (m *MockCommentRepository) Create(ctx context.Context, c *model.Comment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", c)
+	ret := m.ctrl.Call(m, "Create", ctx, c)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockCommentRepositoryMockRecorder) Create(c any) *gomock.Call {
+func (mr *MockCommentRepositoryMockRecorder) Create(ctx, c any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRepository)(nil).Create), c)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRepository)(nil).Create), ctx, c)
 }
 
 // Delete mocks base method.
-func (m *MockCommentRepository) Delete(id int) error {
+func (m *MockCommentRepository) Delete(ctx context.Context, id, deletedBy int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockCommentRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockCommentRepositoryMockRecorder) Delete(ctx, id, deletedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentRepository)(nil).Delete), ctx, id, deletedBy)
+}
+
+// GetByID mocks base method.
+func (m *MockCommentRepository) GetByID(ctx context.Context, id int) (*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCommentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCommentRepository)(nil).GetByID), ctx, id)
 }
 
 // GetByPost mocks base method.
-func (m *MockCommentRepository) GetByPost(postID int) ([]model.Comment, error) {
+func (m *MockCommentRepository) GetByPost(ctx context.Context, postID int, sort string) ([]model.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByPost", postID)
+	ret := m.ctrl.Call(m, "GetByPost", ctx, postID, sort)
 	ret0, _ := ret[0].([]model.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByPost indicates an expected call of GetByPost.
-func (mr *MockCommentRepositoryMockRecorder) GetByPost(postID any) *gomock.Call {
+func (mr *MockCommentRepositoryMockRecorder) GetByPost(ctx, postID, sort any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPost", reflect.TypeOf((*MockCommentRepository)(nil).GetByPost), postID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPost", reflect.TypeOf((*MockCommentRepository)(nil).GetByPost), ctx, postID, sort)
 }
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/mention_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/mention_repository.go -destination=internal/repository/mocks/mention_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMentionRepository is a mock of MentionRepository interface.
+type MockMentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMentionRepositoryMockRecorder is the mock recorder for MockMentionRepository.
+type MockMentionRepositoryMockRecorder struct {
+	mock *MockMentionRepository
+}
+
+// NewMockMentionRepository creates a new mock instance.
+func NewMockMentionRepository(ctrl *gomock.Controller) *MockMentionRepository {
+	mock := &MockMentionRepository{ctrl: ctrl}
+	mock.recorder = &MockMentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionRepository) EXPECT() *MockMentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByUsername mocks base method.
+func (m *MockMentionRepository) CountByUsername(ctx context.Context, username string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUsername", ctx, username)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUsername indicates an expected call of CountByUsername.
+func (mr *MockMentionRepositoryMockRecorder) CountByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUsername", reflect.TypeOf((*MockMentionRepository)(nil).CountByUsername), ctx, username)
+}
+
+// Create mocks base method.
+func (m_2 *MockMentionRepository) Create(ctx context.Context, m *model.Mention) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMentionRepositoryMockRecorder) Create(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMentionRepository)(nil).Create), ctx, m)
+}
+
+// DeleteBySource mocks base method.
+func (m *MockMentionRepository) DeleteBySource(ctx context.Context, sourceType string, sourceID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBySource", ctx, sourceType, sourceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBySource indicates an expected call of DeleteBySource.
+func (mr *MockMentionRepositoryMockRecorder) DeleteBySource(ctx, sourceType, sourceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySource", reflect.TypeOf((*MockMentionRepository)(nil).DeleteBySource), ctx, sourceType, sourceID)
+}
+
+// GetByUsername mocks base method.
+func (m *MockMentionRepository) GetByUsername(ctx context.Context, username string, limit, offset int) ([]model.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsername", ctx, username, limit, offset)
+	ret0, _ := ret[0].([]model.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUsername indicates an expected call of GetByUsername.
+func (mr *MockMentionRepositoryMockRecorder) GetByUsername(ctx, username, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockMentionRepository)(nil).GetByUsername), ctx, username, limit, offset)
+}
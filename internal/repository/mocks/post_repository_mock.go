@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	model "golangforum/internal/model"
 	reflect "reflect"
 
@@ -40,60 +41,104 @@ func (m *MockPostRepository) EXPECT() *MockPostRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockPostRepository) Count(ctx context.Context, f model.PostFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, f)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockPostRepositoryMockRecorder) Count(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPostRepository)(nil).Count), ctx, f)
+}
+
 // Create mocks base method.
-func (m *MockPostRepository) Create(post *model.Post) error {
+func (m *MockPostRepository) Create(ctx context.Context, post *model.Post) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", post)
+	ret := m.ctrl.Call(m, "Create", ctx, post)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockPostRepositoryMockRecorder) Create(post any) *gomock.Call {
+func (mr *MockPostRepositoryMockRecorder) Create(ctx, post any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPostRepository)(nil).Create), post)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPostRepository)(nil).Create), ctx, post)
 }
 
 // Delete mocks base method.
-func (m *MockPostRepository) Delete(id int) error {
+func (m *MockPostRepository) Delete(ctx context.Context, id, deletedBy int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockPostRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockPostRepositoryMockRecorder) Delete(ctx, id, deletedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPostRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPostRepository)(nil).Delete), ctx, id, deletedBy)
 }
 
-// GetAll mocks base method.
-func (m *MockPostRepository) GetAll() ([]model.Post, error) {
+// GetByID mocks base method.
+func (m *MockPostRepository) GetByID(ctx context.Context, id int) (*model.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].([]model.Post)
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAll indicates an expected call of GetAll.
-func (mr *MockPostRepositoryMockRecorder) GetAll() *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPostRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPostRepository)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPostRepository)(nil).GetByID), ctx, id)
 }
 
-// GetByTopic mocks base method.
-func (m *MockPostRepository) GetByTopic(topicID int) ([]model.Post, error) {
+// List mocks base method.
+func (m *MockPostRepository) List(ctx context.Context, f model.PostFilter) ([]model.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByTopic", topicID)
+	ret := m.ctrl.Call(m, "List", ctx, f)
 	ret0, _ := ret[0].([]model.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByTopic indicates an expected call of GetByTopic.
-func (mr *MockPostRepositoryMockRecorder) GetByTopic(topicID any) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockPostRepositoryMockRecorder) List(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPostRepository)(nil).List), ctx, f)
+}
+
+// Move mocks base method.
+func (m *MockPostRepository) Move(ctx context.Context, ids []int, topicID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, ids, topicID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockPostRepositoryMockRecorder) Move(ctx, ids, topicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockPostRepository)(nil).Move), ctx, ids, topicID)
+}
+
+// Update mocks base method.
+func (m *MockPostRepository) Update(ctx context.Context, post *model.Post) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, post)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPostRepositoryMockRecorder) Update(ctx, post any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTopic", reflect.TypeOf((*MockPostRepository)(nil).GetByTopic), topicID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPostRepository)(nil).Update), ctx, post)
 }
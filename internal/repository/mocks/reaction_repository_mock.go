@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/reaction_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/reaction_repository.go -destination=internal/repository/mocks/reaction_repository_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReactionRepository is a mock of ReactionRepository interface.
+type MockReactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReactionRepositoryMockRecorder is the mock recorder for MockReactionRepository.
+type MockReactionRepositoryMockRecorder struct {
+	mock *MockReactionRepository
+}
+
+// NewMockReactionRepository creates a new mock instance.
+func NewMockReactionRepository(ctrl *gomock.Controller) *MockReactionRepository {
+	mock := &MockReactionRepository{ctrl: ctrl}
+	mock.recorder = &MockReactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionRepository) EXPECT() *MockReactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSummaries mocks base method.
+func (m *MockReactionRepository) GetSummaries(ctx context.Context, targetType string, targetIDs []int, userID int) (map[int]model.ReactionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummaries", ctx, targetType, targetIDs, userID)
+	ret0, _ := ret[0].(map[int]model.ReactionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummaries indicates an expected call of GetSummaries.
+func (mr *MockReactionRepositoryMockRecorder) GetSummaries(ctx, targetType, targetIDs, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaries", reflect.TypeOf((*MockReactionRepository)(nil).GetSummaries), ctx, targetType, targetIDs, userID)
+}
+
+// ToggleReaction mocks base method.
+func (m *MockReactionRepository) ToggleReaction(ctx context.Context, targetType string, targetID, userID int, emoji string) (*model.ReactionCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleReaction", ctx, targetType, targetID, userID, emoji)
+	ret0, _ := ret[0].(*model.ReactionCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleReaction indicates an expected call of ToggleReaction.
+func (mr *MockReactionRepositoryMockRecorder) ToggleReaction(ctx, targetType, targetID, userID, emoji any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleReaction", reflect.TypeOf((*MockReactionRepository)(nil).ToggleReaction), ctx, targetType, targetID, userID, emoji)
+}
+
+// Vote mocks base method.
+func (m *MockReactionRepository) Vote(ctx context.Context, targetType string, targetID, userID, value int) (*model.VoteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", ctx, targetType, targetID, userID, value)
+	ret0, _ := ret[0].(*model.VoteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockReactionRepositoryMockRecorder) Vote(ctx, targetType, targetID, userID, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockReactionRepository)(nil).Vote), ctx, targetType, targetID, userID, value)
+}
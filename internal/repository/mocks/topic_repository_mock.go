@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	model "golangforum/internal/model"
 	reflect "reflect"
 
@@ -41,44 +42,146 @@ func (m *MockTopicRepository) EXPECT() *MockTopicRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockTopicRepository) Create(topic *model.Topic) error {
+func (m *MockTopicRepository) Create(ctx context.Context, topic *model.Topic) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", topic)
+	ret := m.ctrl.Call(m, "Create", ctx, topic)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTopicRepositoryMockRecorder) Create(topic any) *gomock.Call {
+func (mr *MockTopicRepositoryMockRecorder) Create(ctx, topic any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTopicRepository)(nil).Create), topic)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTopicRepository)(nil).Create), ctx, topic)
 }
 
 // Delete mocks base method.
-func (m *MockTopicRepository) Delete(id int) error {
+func (m *MockTopicRepository) Delete(ctx context.Context, id, deletedBy int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTopicRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockTopicRepositoryMockRecorder) Delete(ctx, id, deletedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTopicRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTopicRepository)(nil).Delete), ctx, id, deletedBy)
 }
 
 // GetAll mocks base method.
-func (m *MockTopicRepository) GetAll() ([]model.Topic, error) {
+func (m *MockTopicRepository) GetAll(ctx context.Context) ([]model.Topic, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", ctx)
 	ret0, _ := ret[0].([]model.Topic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTopicRepositoryMockRecorder) GetAll() *gomock.Call {
+func (mr *MockTopicRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTopicRepository)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTopicRepository)(nil).GetAll), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockTopicRepository) GetByID(ctx context.Context, id int) (*model.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTopicRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTopicRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByPost mocks base method.
+func (m *MockTopicRepository) GetByPost(ctx context.Context, postID int) (*model.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPost", ctx, postID)
+	ret0, _ := ret[0].(*model.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPost indicates an expected call of GetByPost.
+func (mr *MockTopicRepositoryMockRecorder) GetByPost(ctx, postID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPost", reflect.TypeOf((*MockTopicRepository)(nil).GetByPost), ctx, postID)
+}
+
+// GetPaths mocks base method.
+func (m *MockTopicRepository) GetPaths(ctx context.Context, topicIDs []int) (map[int][]model.TopicRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaths", ctx, topicIDs)
+	ret0, _ := ret[0].(map[int][]model.TopicRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaths indicates an expected call of GetPaths.
+func (mr *MockTopicRepositoryMockRecorder) GetPaths(ctx, topicIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaths", reflect.TypeOf((*MockTopicRepository)(nil).GetPaths), ctx, topicIDs)
+}
+
+// Move mocks base method.
+func (m *MockTopicRepository) Move(ctx context.Context, id int, parentID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, id, parentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTopicRepositoryMockRecorder) Move(ctx, id, parentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTopicRepository)(nil).Move), ctx, id, parentID)
+}
+
+// RecomputeStats mocks base method.
+func (m *MockTopicRepository) RecomputeStats(ctx context.Context) (model.StatsRecount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeStats", ctx)
+	ret0, _ := ret[0].(model.StatsRecount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeStats indicates an expected call of RecomputeStats.
+func (mr *MockTopicRepositoryMockRecorder) RecomputeStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeStats", reflect.TypeOf((*MockTopicRepository)(nil).RecomputeStats), ctx)
+}
+
+// SetState mocks base method.
+func (m *MockTopicRepository) SetState(ctx context.Context, id int, archived, locked *bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetState", ctx, id, archived, locked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetState indicates an expected call of SetState.
+func (mr *MockTopicRepositoryMockRecorder) SetState(ctx, id, archived, locked any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetState", reflect.TypeOf((*MockTopicRepository)(nil).SetState), ctx, id, archived, locked)
+}
+
+// Update mocks base method.
+func (m *MockTopicRepository) Update(ctx context.Context, topic *model.Topic) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, topic)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTopicRepositoryMockRecorder) Update(ctx, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTopicRepository)(nil).Update), ctx, topic)
 }
@@ -1,12 +1,16 @@
 package repository
 
 import (
+	"context"
 	"golangforum/internal/model"
 )
 
 type PostRepository interface {
-	Create(post *model.Post) error
-	GetByTopic(topicID int) ([]model.Post, error)
-	GetAll() ([]model.Post, error)
-	Delete(id int) error
+	Create(ctx context.Context, post *model.Post) error
+	GetByID(ctx context.Context, id int) (*model.Post, error)
+	List(ctx context.Context, f model.PostFilter) ([]model.Post, error)
+	Count(ctx context.Context, f model.PostFilter) (int, error)
+	Update(ctx context.Context, post *model.Post) error
+	Delete(ctx context.Context, id, deletedBy int) error
+	Move(ctx context.Context, ids []int, topicID int) (int, error)
 }
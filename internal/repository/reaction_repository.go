@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"golangforum/internal/model"
+)
+
+type ReactionRepository interface {
+	Vote(ctx context.Context, targetType string, targetID, userID, value int) (*model.VoteResult, error)
+	ToggleReaction(ctx context.Context, targetType string, targetID, userID int, emoji string) (*model.ReactionCount, error)
+	GetSummaries(ctx context.Context, targetType string, targetIDs []int, userID int) (map[int]model.ReactionSummary, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"golangforum/internal/model"
+)
+
+type TagRepository interface {
+	GetByPosts(ctx context.Context, postIDs []int) (map[int][]string, error)
+	List(ctx context.Context, prefix string, limit int) ([]model.Tag, error)
+	Rename(ctx context.Context, id int, name string) (*model.Tag, error)
+	Merge(ctx context.Context, sourceID, targetID int) (*model.Tag, error)
+}
@@ -1,11 +1,19 @@
 package repository
 
 import (
+	"context"
 	"golangforum/internal/model"
 )
 
 type TopicRepository interface {
-	Create(topic *model.Topic) error
-	GetAll() ([]model.Topic, error)
-	Delete(id int) error
+	Create(ctx context.Context, topic *model.Topic) error
+	GetByID(ctx context.Context, id int) (*model.Topic, error)
+	GetByPost(ctx context.Context, postID int) (*model.Topic, error)
+	GetAll(ctx context.Context) ([]model.Topic, error)
+	GetPaths(ctx context.Context, topicIDs []int) (map[int][]model.TopicRef, error)
+	Update(ctx context.Context, topic *model.Topic) error
+	Move(ctx context.Context, id int, parentID *int) error
+	SetState(ctx context.Context, id int, archived, locked *bool) error
+	RecomputeStats(ctx context.Context) (model.StatsRecount, error)
+	Delete(ctx context.Context, id, deletedBy int) error
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"golangforum/internal/model"
+)
+
+type TrashRepository interface {
+	List(ctx context.Context, itemType string, limit, offset int) ([]model.TrashItem, error)
+	Count(ctx context.Context, itemType string) (int, error)
+	Restore(ctx context.Context, itemType string, id int) error
+	Purge(ctx context.Context, before time.Time) (int, error)
+	CountPurgeable(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,162 @@
+// Package schema применяет к базе миграции, встроенные в бинарный файл из scripts/migrations, через golang-migrate.
+// Пока миграции выполняются, экземпляр держит advisory-блокировку PostgreSQL, поэтому несколько одновременно
+// запущенных серверов не применяют их наперегонки: остальные ждут и видят уже обновленную схему
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/rs/zerolog"
+	"golangforum/scripts/migrations"
+)
+
+// Status — состояние схемы базы
+type Status struct {
+	Version uint // последняя примененная миграция; 0 — ни одной
+	Dirty   bool // миграция Version прервана, схема требует ручного исправления и force
+	Latest  uint // последняя встроенная миграция
+	Pending int  // сколько встроенных миграций еще не применено
+}
+
+// Migrator применяет встроенные миграции к одной базе. Использует собственное соединение, а не пул сервера
+type Migrator struct {
+	m        *migrate.Migrate
+	versions []uint
+}
+
+// New подключается к базе по databaseURL (postgres://...). Блокировку миграций Migrator ждет не дольше lockTimeout
+func New(databaseURL string, lockTimeout time.Duration, logger zerolog.Logger) (*Migrator, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read embedded migrations: %w", err)
+	}
+	versions, err := sourceVersions(src)
+	if err != nil {
+		return nil, err
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", src, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("open migrations database: %w", err)
+	}
+	m.LockTimeout = lockTimeout
+	m.Log = migrateLogger{logger}
+	return &Migrator{m: m, versions: versions}, nil
+}
+
+// Up применяет все еще не примененные миграции. Если схема уже актуальна, ничего не делает
+func (m *Migrator) Up(ctx context.Context) error {
+	defer m.stopOnCancel(ctx)()
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Down откатывает n последних примененных миграций
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("number of migrations to roll back must be positive, got %d", n)
+	}
+	defer m.stopOnCancel(ctx)()
+	if err := m.m.Steps(-n); err != nil {
+		return fmt.Errorf("roll back %d migrations: %w", n, err)
+	}
+	return nil
+}
+
+// Force записывает version как примененную и снимает признак dirty, не выполняя миграций. Нужна после того,
+// как прерванную миграцию исправили вручную; -1 означает, что не применено ни одной
+func (m *Migrator) Force(version int) error {
+	if err := m.m.Force(version); err != nil {
+		return fmt.Errorf("force version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Status возвращает примененную и последнюю встроенную версии схемы
+func (m *Migrator) Status() (Status, error) {
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, fmt.Errorf("read schema version: %w", err)
+	}
+	return StatusOf(m.versions, version, dirty), nil
+}
+
+// Embedded возвращает номера встроенных миграций по возрастанию
+func Embedded() ([]uint, error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read embedded migrations: %w", err)
+	}
+	defer src.Close()
+	return sourceVersions(src)
+}
+
+// Close закрывает соединение с базой
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// stopOnCancel просит golang-migrate остановиться после текущей миграции, если ctx отменят.
+// Возвращает функцию, которую нужно вызвать по завершении операции
+func (m *Migrator) stopOnCancel(ctx context.Context) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			m.m.GracefulStop <- true
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
+}
+
+// StatusOf возвращает состояние схемы, в которой последней применена миграция version, относительно миграций
+// versions. Нужна тем, кто читает версию из schema_migrations сам, не открывая Migrator
+func StatusOf(versions []uint, version uint, dirty bool) Status {
+	s := Status{Version: version, Dirty: dirty}
+	for _, v := range versions {
+		if v > version {
+			s.Pending++
+		}
+		s.Latest = max(s.Latest, v)
+	}
+	return s
+}
+
+// sourceVersions возвращает номера всех миграций источника по возрастанию
+func sourceVersions(src source.Driver) ([]uint, error) {
+	var versions []uint
+	v, err := src.First()
+	for err == nil {
+		versions = append(versions, v)
+		v, err = src.Next(v)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read embedded migrations: %w", err)
+	}
+	return versions, nil
+}
+
+// migrateLogger пишет ход миграций golang-migrate в zerolog
+type migrateLogger struct {
+	logger zerolog.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...any) {
+	l.logger.Info().Msg(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrateLogger) Verbose() bool {
+	return true
+}
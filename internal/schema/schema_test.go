@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golangforum/scripts/migrations"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	src, err := iofs.New(migrations.FS, ".")
+	require.NoError(t, err)
+	defer src.Close()
+
+	versions, err := sourceVersions(src)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+	// номера идут подряд с 1, и у каждой миграции есть откат
+	for i, v := range versions {
+		assert.Equal(t, uint(i+1), v)
+		_, _, err := src.ReadDown(v)
+		assert.NoError(t, err, "migration %d has no down file", v)
+	}
+
+	embedded, err := Embedded()
+	require.NoError(t, err)
+	assert.Equal(t, versions, embedded)
+}
+
+func TestStatus(t *testing.T) {
+	versions := []uint{1, 2, 3, 4}
+
+	assert.Equal(t, Status{Latest: 4, Pending: 4}, StatusOf(versions, 0, false))
+	assert.Equal(t, Status{Version: 2, Dirty: true, Latest: 4, Pending: 2}, StatusOf(versions, 2, true))
+	assert.Equal(t, Status{Version: 4, Latest: 4}, StatusOf(versions, 4, false))
+}
@@ -0,0 +1,61 @@
+package tag
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTags — максимальное количество тегов у одного поста
+	MaxTags = 5
+	// MaxLength — максимальная длина тега в символах
+	MaxLength = 32
+)
+
+var (
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTooManyTags = errors.New("too many tags")
+)
+
+// Normalize приводит теги к каноническому виду и убирает повторы, сохраняя порядок.
+// Пустые после нормализации значения пропускаются
+func Normalize(raw []string) ([]string, error) {
+	var res []string
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		t, err := NormalizeOne(r)
+		if err != nil {
+			return nil, err
+		}
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		res = append(res, t)
+	}
+	if len(res) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+	return res, nil
+}
+
+// NormalizeOne приводит тег к нижнему регистру, убирает ведущий # и заменяет пробелы дефисом:
+// «  Go Modules » и «#go-modules» дают один и тот же тег go-modules
+func NormalizeOne(raw string) (string, error) {
+	t := strings.Join(strings.Fields(strings.ToLower(raw)), "-")
+	t = strings.TrimLeft(t, "#")
+	if t == "" {
+		return "", nil
+	}
+	if utf8.RuneCountInString(t) > MaxLength {
+		return "", ErrInvalidTag
+	}
+	for _, r := range t {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.+#", r) {
+			return "", ErrInvalidTag
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,35 @@
+package tag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	res, err := Normalize([]string{"  Go  Modules ", "#go-modules", "PostgreSQL", "", "c++", "C#", "Тестирование"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go-modules", "postgresql", "c++", "c#", "тестирование"}, res)
+}
+
+func TestNormalize_TooMany(t *testing.T) {
+	_, err := Normalize([]string{"a", "b", "c", "d", "e", "f"})
+
+	assert.ErrorIs(t, err, ErrTooManyTags)
+}
+
+func TestNormalize_DuplicatesDoNotCount(t *testing.T) {
+	res, err := Normalize([]string{"a", "A", "b", "c", "d", "e", " a "})
+
+	assert.NoError(t, err)
+	assert.Len(t, res, MaxTags)
+}
+
+func TestNormalizeOne_Invalid(t *testing.T) {
+	for _, raw := range []string{"<script>", "a/b", "tag,other", "100%", strings.Repeat("x", MaxLength+1)} {
+		_, err := NormalizeOne(raw)
+		assert.ErrorIs(t, err, ErrInvalidTag, raw)
+	}
+}
@@ -0,0 +1,51 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encode(t *testing.T, img image.Image) *bytes.Reader {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestGenerate(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 800; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+
+	data, err := Generate(encode(t, src), 256)
+	assert.NoError(t, err)
+
+	thumb, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 256, 128), thumb.Bounds())
+	assert.Equal(t, color.NRGBA{R: 200, G: 100, B: 50, A: 255}, color.NRGBAModel.Convert(thumb.At(100, 60)))
+}
+
+func TestGenerate_SmallImageIsNotUpscaled(t *testing.T) {
+	data, err := Generate(encode(t, image.NewGray(image.Rect(0, 0, 30, 20))), 256)
+	assert.NoError(t, err)
+
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 30, cfg.Width)
+	assert.Equal(t, 20, cfg.Height)
+}
+
+func TestGenerate_NotAnImage(t *testing.T) {
+	_, err := Generate(bytes.NewReader([]byte("just some log lines")), 256)
+	assert.Error(t, err)
+}
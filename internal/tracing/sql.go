@@ -0,0 +1,185 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WrapConnector оборачивает драйвер базы данных так, что каждый запрос QueryContext и ExecContext, в том числе
+// внутри транзакций, получает свой span. Span называется по методу из пакета callerPrefix
+// (например "golangforum/internal/repository/impl."), который выполнил запрос, — "PostRepository.GetByID",
+// и содержит текст запроса и число прочитанных или затронутых строк
+func WrapConnector(c driver.Connector, callerPrefix string) driver.Connector {
+	return &connector{Connector: c, prefix: callerPrefix}
+}
+
+type connector struct {
+	driver.Connector
+	prefix string
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: cn, prefix: c.prefix}, nil
+}
+
+// conn передает вызовы соединению драйвера; необязательные интерфейсы, которых у драйвера нет,
+// отвечают driver.ErrSkip, и database/sql выбирает обходной путь
+type conn struct {
+	driver.Conn
+	prefix string
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := c.start(ctx, query)
+	rows, err := q.QueryContext(ctx, query, args)
+	if err != nil {
+		End(span, skipErr(err))
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := c.start(ctx, query)
+	res, err := e.ExecContext(ctx, query, args)
+	if err == nil {
+		if n, rerr := res.RowsAffected(); rerr == nil {
+			span.SetAttributes(attribute.Int64("db.rows_affected", n))
+		}
+	}
+	End(span, skipErr(err))
+	return res, err
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if ch, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return ch.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// start открывает span запроса. Имя метода ищется по стеку только для записываемых span: запросы
+// из неотобранных трассировок обходятся без этого
+func (c *conn) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if parent.SpanContext().IsValid() && !parent.IsRecording() {
+		return ctx, noopSpan
+	}
+	return Start(ctx, c.statementName(query),
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.statement", query),
+	)
+}
+
+// statementName возвращает метод-источник запроса без пакета и указателя, например "PostRepository.GetByID";
+// если его нет в стеке — первое слово запроса
+func (c *conn) statementName(query string) string {
+	pcs := make([]uintptr, 32)
+	// пропускаются runtime.Callers, statementName, start и QueryContext или ExecContext
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs)])
+	for {
+		f, more := frames.Next()
+		if name, ok := strings.CutPrefix(f.Function, c.prefix); ok {
+			// замыкания называются Method.func1
+			if i := strings.Index(name, ".func"); i > 0 {
+				name = name[:i]
+			}
+			return strings.NewReplacer("(*", "", ")", "").Replace(name)
+		}
+		if !more {
+			break
+		}
+	}
+	if fields := strings.Fields(query); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
+
+// tracedRows считает прочитанные строки и завершает span, когда database/sql закрывает результат
+type tracedRows struct {
+	driver.Rows
+	span trace.Span
+	rows int
+	err  error
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	switch {
+	case err == nil:
+		r.rows++
+	case !errors.Is(err, io.EOF):
+		r.err = err
+	}
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	r.span.SetAttributes(attribute.Int("db.rows", r.rows))
+	End(r.span, r.err)
+	return err
+}
+
+// skipErr не считает ошибкой driver.ErrSkip: это просьба к database/sql выполнить запрос другим путем
+func skipErr(err error) error {
+	if errors.Is(err, driver.ErrSkip) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+// Package tracing настраивает трассировку OpenTelemetry. По умолчанию она выключена: глобальный
+// TracerProvider остается пустым, Start возвращает ничего не делающий span, а обертки для HTTP, gRPC
+// и базы данных не подключаются
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Экспортеры трассировок
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+const instrumentationName = "golangforum"
+
+// Options — настройки трассировки
+type Options struct {
+	Exporter    string  // ExporterNone, ExporterOTLP или ExporterStdout
+	Endpoint    string  // адрес OTLP/gRPC-коллектора
+	Insecure    bool    // подключаться к коллектору без TLS
+	SampleRatio float64 // доля трассируемых корневых запросов
+	ServiceName string
+}
+
+var enabled bool
+
+// Enabled сообщает, включена ли трассировка. Обертки, которые стоят заметных ресурсов даже с пустым
+// TracerProvider, подключаются только при включенной трассировке
+func Enabled() bool {
+	return enabled
+}
+
+// Setup включает трассировку и возвращает функцию, которая отправляет накопленные span при остановке.
+// С ExporterNone ничего не меняет
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch opts.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, clientOpts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s trace exporter: %w", opts.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	enabled = true
+	return provider.Shutdown, nil
+}
+
+// noopSpan возвращается, пока трассировка выключена
+var noopSpan = trace.SpanFromContext(context.Background())
+
+// Start открывает дочерний span с именем name, например "PostUseCase.Create". Пока трассировка выключена,
+// возвращает ctx без изменений и пустой span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !enabled {
+		return ctx, noopSpan
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End завершает span, отмечая в нем ошибку, если она есть
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,149 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// record включает трассировку в память на время теста
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	enabled = true
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		enabled = false
+	})
+	return rec
+}
+
+type fakeConnector struct{ rows int }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{rows: c.rows}, nil
+}
+func (c fakeConnector) Driver() driver.Driver { return nil }
+
+type fakeConn struct{ rows int }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query == "SELECT broken" {
+		return nil, errors.New("syntax error")
+	}
+	return &fakeRows{left: c.rows}, nil
+}
+
+func (c *fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(3), nil
+}
+
+type fakeRows struct{ left int }
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.left == 0 {
+		return io.EOF
+	}
+	r.left--
+	dest[0] = int64(r.left)
+	return nil
+}
+
+type fakeRepository struct{ db *sql.DB }
+
+func (r *fakeRepository) List(ctx context.Context) (int, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id FROM posts")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	return n, rows.Err()
+}
+
+func attr(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestStart_Disabled(t *testing.T) {
+	ctx := context.Background()
+
+	got, span := Start(ctx, "PostUseCase.Create")
+	span.End()
+
+	assert.Equal(t, ctx, got)
+	assert.False(t, span.IsRecording())
+}
+
+func TestWrapConnector_Query(t *testing.T) {
+	rec := record(t)
+	db := sql.OpenDB(WrapConnector(fakeConnector{rows: 2}, "golangforum/internal/tracing."))
+	defer db.Close()
+
+	ctx, parent := Start(context.Background(), "PostUseCase.List")
+	n, err := (&fakeRepository{db: db}).List(ctx)
+	parent.End()
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	spans := rec.Ended()
+	require.Len(t, spans, 2)
+	query := spans[0]
+	assert.Equal(t, "fakeRepository.List", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, "SELECT id FROM posts", attr(query, "db.statement").AsString())
+	assert.Equal(t, int64(2), attr(query, "db.rows").AsInt64())
+}
+
+func TestWrapConnector_ExecAndError(t *testing.T) {
+	rec := record(t)
+	db := sql.OpenDB(WrapConnector(fakeConnector{}, "golangforum/internal/repository/impl."))
+	defer db.Close()
+
+	_, err := db.ExecContext(context.Background(), "delete from posts")
+	require.NoError(t, err)
+	_, err = db.QueryContext(context.Background(), "SELECT broken")
+	require.Error(t, err)
+
+	spans := rec.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "DELETE", spans[0].Name())
+	assert.Equal(t, int64(3), attr(spans[0], "db.rows_affected").AsInt64())
+	assert.Equal(t, "SELECT", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestSetup_None(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{Exporter: ExporterNone})
+
+	require.NoError(t, err)
+	assert.False(t, Enabled())
+	assert.NoError(t, shutdown(context.Background()))
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"golangforum/internal/model"
+)
+
+type ActivityUseCase interface {
+	Recent(ctx context.Context, userID, limit int) ([]model.Activity, error)
+	UserContent(ctx context.Context, userID int) (*model.UserContent, error)
+	PurgeUser(ctx context.Context, userID int) (*model.UserContent, error)
+}
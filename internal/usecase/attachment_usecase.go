@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"golangforum/internal/model"
+	"io"
+	"time"
+)
+
+type AttachmentUseCase interface {
+	Upload(ctx context.Context, userID int, username, filename string, r io.Reader) (*model.Attachment, error)
+	Open(ctx context.Context, id int, thumbnail bool) (*model.Attachment, io.ReadSeekCloser, error)
+	CollectOrphans(ctx context.Context, olderThan time.Duration) (int, error)
+	CountOrphans(ctx context.Context, olderThan time.Duration) (int, error)
+}
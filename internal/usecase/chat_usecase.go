@@ -1,11 +1,14 @@
 package usecase
 
 import (
+	"context"
 	"github.com/gorilla/websocket"
 	"golangforum/internal/model"
 )
 
 type ChatUseCase interface {
-	GetAllMessages() ([]model.Message, error)
-	HandleConnection(conn *websocket.Conn, user string, id int, clients map[*websocket.Conn]struct{})
+	GetAllMessages(ctx context.Context) ([]model.Message, error)
+	CountExpired(ctx context.Context) (int, error)
+	DeleteExpired(ctx context.Context) (int, error)
+	HandleConnection(ctx context.Context, conn *websocket.Conn, user string, id int, clients map[*websocket.Conn]struct{})
 }
@@ -1,9 +1,14 @@
 package usecase
 
-import "golangforum/internal/model"
+import (
+	"context"
+
+	"golangforum/internal/model"
+)
 
 type CommentUseCase interface {
-	Create(username string, c *model.Comment) error
-	GetByPost(postID int) ([]model.Comment, error)
-	Delete(id int) error
+	Create(ctx context.Context, username string, c *model.Comment) error
+	GetByID(ctx context.Context, id, viewerID int) (*model.Comment, error)
+	GetByPost(ctx context.Context, postID, viewerID int, sort string) ([]model.Comment, error)
+	Delete(ctx context.Context, id, userID int) error
 }
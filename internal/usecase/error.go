@@ -8,12 +8,31 @@ var (
 	ErrInvalidUserID   = errors.New("invalid user_id")
 	ErrInvalidUsername = errors.New("invalid username")
 
-	ErrInvalidCommentData = errors.New("invalid comment data")
-	ErrCommentNotFound    = errors.New("comment not found")
+	ErrCommentNotFound = errors.New("comment not found")
 
-	ErrInvalidPostData = errors.New("invalid post data")
-	ErrPostNotFound    = errors.New("post not found")
+	ErrPostNotFound      = errors.New("post not found")
+	ErrInvalidPostFilter = errors.New("invalid post filter")
+	ErrForbidden         = errors.New("forbidden")
 
-	ErrInvalidTopicData = errors.New("invalid topic data")
-	ErrTopicNotFound    = errors.New("topic not found")
+	ErrTopicNotFound = errors.New("topic not found")
+	ErrTopicCycle    = errors.New("topic cannot be moved into its own subtree")
+	ErrTopicArchived = errors.New("topic is archived")
+	ErrTopicLocked   = errors.New("topic is locked")
+
+	ErrInvalidAttachment    = errors.New("invalid attachment")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrAttachmentTooLarge   = errors.New("attachment too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("tag already exists")
+
+	ErrInvalidTrashType = errors.New("invalid trash item type")
+	ErrTrashNotFound    = errors.New("trash item not found")
+	ErrParentDeleted    = errors.New("parent item is deleted")
+
+	ErrInvalidVote     = errors.New("invalid vote")
+	ErrInvalidReaction = errors.New("invalid reaction")
+	ErrInvalidSort     = errors.New("invalid sort")
 )
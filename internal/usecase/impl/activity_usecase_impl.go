@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/tracing"
+	"golangforum/internal/usecase"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ActivityUseCase struct {
+	Repo   repository.ActivityRepository
+	Topics repository.TopicRepository
+}
+
+func NewActivityUseCase(repo repository.ActivityRepository, topics repository.TopicRepository) *ActivityUseCase {
+	log.Info().Msg("ActivityUseCase initialized")
+	return &ActivityUseCase{Repo: repo, Topics: topics}
+}
+
+// Recent возвращает limit последних постов, комментариев и сообщений чата, новые первыми. userID = 0 — всех пользователей
+func (uc *ActivityUseCase) Recent(ctx context.Context, userID, limit int) ([]model.Activity, error) {
+	ctx, span := tracing.Start(ctx, "ActivityUseCase.Recent")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("userID", userID).
+		Int("limit", limit).
+		Msg("Fetching recent activity")
+	if userID < 0 {
+		return nil, usecase.ErrInvalidUserID
+	}
+	items, err := uc.Repo.Recent(ctx, userID, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch recent activity")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().Int("count", len(items)).Msg("Recent activity fetched")
+	return items, nil
+}
+
+// UserContent возвращает количество объектов, которые PurgeUser сотрет для userID
+func (uc *ActivityUseCase) UserContent(ctx context.Context, userID int) (*model.UserContent, error) {
+	ctx, span := tracing.Start(ctx, "ActivityUseCase.UserContent")
+	defer span.End()
+	if userID <= 0 {
+		return nil, usecase.ErrInvalidUserID
+	}
+	content, err := uc.Repo.CountByUser(ctx, userID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("userID", userID).Msg("Failed to count user content")
+		return nil, err
+	}
+	return content, nil
+}
+
+// PurgeUser окончательно стирает посты, комментарии и сообщения пользователя вместе с чужими комментариями
+// к его постам и пересчитывает статистику тем
+func (uc *ActivityUseCase) PurgeUser(ctx context.Context, userID int) (*model.UserContent, error) {
+	ctx, span := tracing.Start(ctx, "ActivityUseCase.PurgeUser")
+	defer span.End()
+	log.Ctx(ctx).Debug().Int("userID", userID).Msg("Purging user content")
+	if userID <= 0 {
+		return nil, usecase.ErrInvalidUserID
+	}
+	content, err := uc.Repo.PurgeUser(ctx, userID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Int("userID", userID).Msg("Failed to purge user content")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("userID", userID).
+		Int("posts", content.Posts).
+		Int("comments", content.Comments).
+		Int("replies", content.Replies).
+		Int("messages", content.Messages).
+		Msg("User content purged")
+	// контент уже стерт; расхождение счетчиков исправит повторный пересчет
+	if _, err := uc.Topics.RecomputeStats(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to recompute topic statistics after purge")
+		return content, err
+	}
+	return content, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func TestActivityUseCase_Recent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockActivityRepository(ctrl)
+	topicID := 2
+	items := []model.Activity{
+		{Type: model.ActivityComment, ID: 9, TopicID: &topicID, UserID: 3, Username: "bob", Text: "agreed", Timestamp: time.Now()},
+		{Type: model.ActivityMessage, ID: 4, UserID: 5, Username: "alice", Text: "hi", Timestamp: time.Now().Add(-time.Minute)},
+	}
+	mockRepo.EXPECT().Recent(gomock.Any(), 0, 20).Return(items, nil).Times(1)
+
+	uc := NewActivityUseCase(mockRepo, mocks.NewMockTopicRepository(ctrl))
+	got, err := uc.Recent(context.Background(), 0, 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, items, got)
+
+	_, err = uc.Recent(context.Background(), -1, 20)
+	assert.ErrorIs(t, err, usecase.ErrInvalidUserID)
+}
+
+func TestActivityUseCase_UserContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockActivityRepository(ctrl)
+	content := &model.UserContent{UserID: 7, Posts: 2, Comments: 5, Replies: 3, Messages: 10}
+	mockRepo.EXPECT().CountByUser(gomock.Any(), 7).Return(content, nil).Times(1)
+
+	uc := NewActivityUseCase(mockRepo, mocks.NewMockTopicRepository(ctrl))
+	got, err := uc.UserContent(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	_, err = uc.UserContent(context.Background(), 0)
+	assert.ErrorIs(t, err, usecase.ErrInvalidUserID)
+}
+
+func TestActivityUseCase_PurgeUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockActivityRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	content := &model.UserContent{UserID: 7, Posts: 2, Comments: 5}
+	gomock.InOrder(
+		mockRepo.EXPECT().PurgeUser(gomock.Any(), 7).Return(content, nil),
+		mockTopics.EXPECT().RecomputeStats(gomock.Any()).Return(model.StatsRecount{Topics: 3, Posts: 4}, nil),
+	)
+
+	uc := NewActivityUseCase(mockRepo, mockTopics)
+	got, err := uc.PurgeUser(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+}
+
+func TestActivityUseCase_PurgeUser_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockActivityRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().PurgeUser(gomock.Any(), 7).Return(nil, errors.New("purge error")).Times(1)
+
+	uc := NewActivityUseCase(mockRepo, mockTopics)
+	_, err := uc.PurgeUser(context.Background(), 7)
+
+	assert.Error(t, err)
+
+	_, err = uc.PurgeUser(context.Background(), -3)
+	assert.ErrorIs(t, err, usecase.ErrInvalidUserID)
+}
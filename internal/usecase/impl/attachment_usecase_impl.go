@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/thumbnail"
+	"golangforum/internal/tracing"
+	"golangforum/internal/usecase"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ThumbnailSize     = 256
+	maxFilenameLength = 255
+	sniffLength       = 512
+)
+
+// allowedContentTypes — типы, определяемые http.DetectContentType, которые разрешено загружать.
+// HTML, SVG и прочие форматы, способные исполнять скрипты в браузере, сюда не входят
+var allowedContentTypes = map[string]bool{
+	"image/png":                 true,
+	"image/jpeg":                true,
+	"image/gif":                 true,
+	"image/webp":                true,
+	"image/bmp":                 true,
+	"text/plain; charset=utf-8": true,
+	"application/pdf":           true,
+	"application/zip":           true,
+	"application/x-gzip":        true,
+	"application/octet-stream":  true,
+}
+
+var thumbnailContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+type AttachmentUseCase struct {
+	Repo    repository.AttachmentRepository
+	Store   repository.BlobStore
+	MaxSize int64
+}
+
+func NewAttachmentUseCase(repo repository.AttachmentRepository, store repository.BlobStore, maxSize int64) *AttachmentUseCase {
+	log.Info().Int64("maxSize", maxSize).Msg("AttachmentUseCase initialized")
+	return &AttachmentUseCase{Repo: repo, Store: store, MaxSize: maxSize}
+}
+
+func (uc *AttachmentUseCase) Upload(ctx context.Context, userID int, username, filename string, r io.Reader) (*model.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.Upload")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Str("username", username).
+		Str("filename", filename).
+		Msg("Uploading attachment")
+	filename = cleanFilename(filename)
+	if filename == "" {
+		log.Ctx(ctx).Warn().Msg("Attachment filename is empty")
+		return nil, usecase.ErrInvalidAttachment
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to read attachment")
+		return nil, err
+	}
+	if n == 0 {
+		log.Ctx(ctx).Warn().Msg("Attachment is empty")
+		return nil, usecase.ErrInvalidAttachment
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !allowedContentTypes[contentType] {
+		log.Ctx(ctx).Warn().Str("contentType", contentType).Msg("Attachment type is not allowed")
+		return nil, usecase.ErrUnsupportedMediaType
+	}
+
+	key, err := newBlobKey()
+	if err != nil {
+		return nil, err
+	}
+	size, err := uc.Store.Put(ctx, key, io.LimitReader(io.MultiReader(bytes.NewReader(head[:n]), r), uc.MaxSize+1))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to store attachment")
+		return nil, err
+	}
+	if size > uc.MaxSize {
+		uc.deleteBlob(ctx, key)
+		log.Ctx(ctx).Warn().Int64("maxSize", uc.MaxSize).Msg("Attachment exceeds size limit")
+		return nil, usecase.ErrAttachmentTooLarge
+	}
+
+	a := &model.Attachment{
+		UserID:      userID,
+		Username:    username,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+		Timestamp:   time.Now(),
+	}
+	if thumbnailContentTypes[contentType] {
+		a.ThumbnailKey = uc.createThumbnail(ctx, key)
+		a.HasThumbnail = a.ThumbnailKey != ""
+	}
+	if err := uc.Repo.Create(ctx, a); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to save attachment")
+		// файлы убираются и тогда, когда запрос отменен клиентом
+		cleanupCtx := context.WithoutCancel(ctx)
+		uc.deleteBlob(cleanupCtx, a.StorageKey)
+		uc.deleteBlob(cleanupCtx, a.ThumbnailKey)
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", a.ID).
+		Str("contentType", contentType).
+		Int64("size", size).
+		Msg("Attachment uploaded")
+	return a, nil
+}
+
+func (uc *AttachmentUseCase) Open(ctx context.Context, id int, thumb bool) (*model.Attachment, io.ReadSeekCloser, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.Open")
+	defer span.End()
+	log.Ctx(ctx).Debug().Int("id", id).Bool("thumbnail", thumb).Msg("Opening attachment")
+	a, err := uc.Repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, usecase.ErrAttachmentNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch attachment")
+		return nil, nil, err
+	}
+	key := a.StorageKey
+	if thumb {
+		if !a.HasThumbnail {
+			return nil, nil, usecase.ErrAttachmentNotFound
+		}
+		key = a.ThumbnailKey
+		a.ContentType = thumbnail.ContentType
+	}
+	rc, err := uc.Store.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Int("id", id).Msg("Attachment content is missing from storage")
+			return nil, nil, usecase.ErrAttachmentNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to open attachment content")
+		return nil, nil, err
+	}
+	return a, rc, nil
+}
+
+// CollectOrphans удаляет вложения, которые так и не были привязаны к посту или комментарию,
+// либо остались без владельца после удаления поста или комментария
+func (uc *AttachmentUseCase) CollectOrphans(ctx context.Context, olderThan time.Duration) (int, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.CollectOrphans")
+	defer span.End()
+	log.Ctx(ctx).Debug().Dur("olderThan", olderThan).Msg("Collecting orphaned attachments")
+	orphans, err := uc.Repo.GetOrphans(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch orphaned attachments")
+		return 0, err
+	}
+	removed := 0
+	for _, a := range orphans {
+		if err := uc.Repo.Delete(ctx, a.ID); err != nil {
+			log.Ctx(ctx).Error().Err(err).Int("id", a.ID).Msg("Failed to delete orphaned attachment")
+			continue
+		}
+		uc.deleteBlob(ctx, a.StorageKey)
+		uc.deleteBlob(ctx, a.ThumbnailKey)
+		removed++
+	}
+	log.Ctx(ctx).Info().Int("count", removed).Msg("Orphaned attachments collected")
+	return removed, nil
+}
+
+// CountOrphans возвращает количество вложений, которые CollectOrphans с тем же olderThan удалит
+func (uc *AttachmentUseCase) CountOrphans(ctx context.Context, olderThan time.Duration) (int, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.CountOrphans")
+	defer span.End()
+	orphans, err := uc.Repo.GetOrphans(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch orphaned attachments")
+		return 0, err
+	}
+	return len(orphans), nil
+}
+
+func (uc *AttachmentUseCase) createThumbnail(ctx context.Context, key string) string {
+	rc, err := uc.Store.Open(ctx, key)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to open attachment for thumbnail")
+		return ""
+	}
+	defer rc.Close()
+	data, err := thumbnail.Generate(rc, ThumbnailSize)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Failed to generate thumbnail")
+		return ""
+	}
+	thumbKey := key + "_thumb"
+	if _, err := uc.Store.Put(ctx, thumbKey, bytes.NewReader(data)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to store thumbnail")
+		return ""
+	}
+	return thumbKey
+}
+
+func (uc *AttachmentUseCase) deleteBlob(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+	if err := uc.Store.Delete(ctx, key); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("key", key).Msg("Failed to delete attachment content")
+	}
+}
+
+// checkAttachments проверяет, что вложения загружены этим же пользователем и еще ни к чему не привязаны
+func checkAttachments(ctx context.Context, repo repository.AttachmentRepository, ids []int, userID int) error {
+	seen := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return usecase.ErrInvalidAttachment
+		}
+		seen[id] = struct{}{}
+		a, err := repo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return usecase.ErrInvalidAttachment
+			}
+			return err
+		}
+		if a.UserID != userID || a.PostID != nil || a.CommentID != nil {
+			return usecase.ErrInvalidAttachment
+		}
+	}
+	return nil
+}
+
+func cleanFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "." || name == "/" || !utf8.ValidString(name) {
+		return ""
+	}
+	for len(name) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+func newBlobKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
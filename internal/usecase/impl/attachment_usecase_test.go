@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/repository/impl"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func newTestBlobStore(t *testing.T) *impl.LocalBlobStore {
+	store, err := impl.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestAttachmentUseCase_Upload_Image(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	store := newTestBlobStore(t)
+	var img bytes.Buffer
+	assert.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 600, 300))))
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a *model.Attachment) error {
+		a.ID = 7
+		return nil
+	}).Times(1)
+
+	uc := NewAttachmentUseCase(mockRepo, store, 1<<20)
+	a, err := uc.Upload(context.Background(), 3, "alice", "../../screen shot.png", bytes.NewReader(img.Bytes()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, a.ID)
+	assert.Equal(t, "screen shot.png", a.Filename)
+	assert.Equal(t, "image/png", a.ContentType)
+	assert.Equal(t, int64(img.Len()), a.Size)
+	assert.True(t, a.HasThumbnail)
+
+	rc, err := store.Open(context.Background(), a.StorageKey)
+	assert.NoError(t, err)
+	stored, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, img.Bytes(), stored)
+
+	rc, err = store.Open(context.Background(), a.ThumbnailKey)
+	assert.NoError(t, err)
+	cfg, err := png.DecodeConfig(rc)
+	rc.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, ThumbnailSize, cfg.Width)
+}
+
+func TestAttachmentUseCase_Upload_Text(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	uc := NewAttachmentUseCase(mockRepo, newTestBlobStore(t), 1<<20)
+	a, err := uc.Upload(context.Background(), 3, "alice", "server.log", strings.NewReader("2024-01-01 ERROR something broke\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", a.ContentType)
+	assert.False(t, a.HasThumbnail)
+}
+
+func TestAttachmentUseCase_Upload_UnsupportedType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+
+	uc := NewAttachmentUseCase(mockRepo, newTestBlobStore(t), 1<<20)
+	_, err := uc.Upload(context.Background(), 3, "alice", "page.png", strings.NewReader("<html><script>alert(1)</script></html>"))
+
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedMediaType)
+}
+
+func TestAttachmentUseCase_Upload_TooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockStore := mocks.NewMockBlobStore(ctrl)
+	mockStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string, r io.Reader) (int64, error) {
+		return io.Copy(io.Discard, r)
+	}).Times(1)
+	mockStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	uc := NewAttachmentUseCase(mockRepo, mockStore, 1024)
+	_, err := uc.Upload(context.Background(), 3, "alice", "big.log", strings.NewReader(strings.Repeat("x", 4096)))
+
+	assert.ErrorIs(t, err, usecase.ErrAttachmentTooLarge)
+}
+
+func TestAttachmentUseCase_Upload_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewAttachmentUseCase(mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockBlobStore(ctrl), 1024)
+	_, err := uc.Upload(context.Background(), 3, "alice", "empty.log", strings.NewReader(""))
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidAttachment)
+}
+
+func TestAttachmentUseCase_Open_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewAttachmentUseCase(mockRepo, mocks.NewMockBlobStore(ctrl), 1024)
+	_, _, err := uc.Open(context.Background(), 5, false)
+
+	assert.ErrorIs(t, err, usecase.ErrAttachmentNotFound)
+}
+
+func TestAttachmentUseCase_Open_MissingThumbnail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&model.Attachment{ID: 5, StorageKey: "abcdef"}, nil).Times(1)
+
+	uc := NewAttachmentUseCase(mockRepo, mocks.NewMockBlobStore(ctrl), 1024)
+	_, _, err := uc.Open(context.Background(), 5, true)
+
+	assert.ErrorIs(t, err, usecase.ErrAttachmentNotFound)
+}
+
+func TestAttachmentUseCase_CollectOrphans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAttachmentRepository(ctrl)
+	mockStore := mocks.NewMockBlobStore(ctrl)
+	orphans := []model.Attachment{
+		{ID: 1, StorageKey: "aaaa", ThumbnailKey: "aaaa_thumb", HasThumbnail: true},
+		{ID: 2, StorageKey: "bbbb"},
+		{ID: 3, StorageKey: "cccc"},
+	}
+	mockRepo.EXPECT().GetOrphans(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, olderThan time.Time) ([]model.Attachment, error) {
+		assert.WithinDuration(t, time.Now().Add(-time.Hour), olderThan, time.Minute)
+		return orphans, nil
+	}).Times(1)
+	mockRepo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), 2).Return(errors.New("database error"))
+	mockRepo.EXPECT().Delete(gomock.Any(), 3).Return(nil)
+	mockStore.EXPECT().Delete(gomock.Any(), "aaaa").Return(nil)
+	mockStore.EXPECT().Delete(gomock.Any(), "aaaa_thumb").Return(nil)
+	mockStore.EXPECT().Delete(gomock.Any(), "cccc").Return(nil)
+
+	uc := NewAttachmentUseCase(mockRepo, mockStore, 1024)
+	removed, err := uc.CollectOrphans(context.Background(), time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+}
@@ -1,9 +1,13 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
+	"golangforum/internal/mention"
+	"golangforum/internal/metrics"
 	"golangforum/internal/model"
 	"golangforum/internal/repository"
+	"golangforum/internal/tracing"
 	"sort"
 	"time"
 
@@ -12,73 +16,119 @@ import (
 )
 
 type ChatUseCase struct {
-	repo repository.ChatRepository
+	repo     repository.ChatRepository
+	mentions repository.MentionRepository
+	// retention — сколько хранить сообщения; более старые удаляются после каждого нового сообщения
+	retention time.Duration
+	metrics   *metrics.Metrics
 }
 
-func NewChatUseCase(repo repository.ChatRepository) *ChatUseCase {
+func NewChatUseCase(repo repository.ChatRepository, mentions repository.MentionRepository, retention time.Duration, m *metrics.Metrics) *ChatUseCase {
 	log.Info().Msg("Initializing ChatUseCase")
-	return &ChatUseCase{repo: repo}
+	return &ChatUseCase{repo: repo, mentions: mentions, retention: retention, metrics: m}
 }
 
-func (uc *ChatUseCase) GetAllMessages() ([]model.Message, error) {
-	log.Info().Msg("GetAllMessages called")
-	msgs, err := uc.repo.GetAllMessages()
+func (uc *ChatUseCase) GetAllMessages(ctx context.Context) ([]model.Message, error) {
+	ctx, span := tracing.Start(ctx, "ChatUseCase.GetAllMessages")
+	defer span.End()
+	log.Ctx(ctx).Info().Msg("GetAllMessages called")
+	msgs, err := uc.repo.GetAllMessages(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("Error fetching messages from repository")
+		log.Ctx(ctx).Error().Err(err).Msg("Error fetching messages from repository")
 		return nil, err
 	}
 	sort.Slice(msgs, func(i, j int) bool {
 		return msgs[i].Timestamp.Before(msgs[j].Timestamp)
 	})
-	log.Info().Int("count", len(msgs)).Msg("Messages sorted by timestamp")
+	for i := range msgs {
+		msgs[i].Mentions = mention.Parse(msgs[i].Content)
+	}
+	log.Ctx(ctx).Info().Int("count", len(msgs)).Msg("Messages sorted by timestamp")
 	return msgs, nil
 }
 
-func (uc *ChatUseCase) HandleConnection(conn *websocket.Conn, user string, id int, clients map[*websocket.Conn]struct{}) {
-	log.Info().Str("user", user).Int("userID", id).Msg("Handling new WebSocket connection")
+// CountExpired возвращает количество сообщений старше срока хранения
+func (uc *ChatUseCase) CountExpired(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "ChatUseCase.CountExpired")
+	defer span.End()
+	n, err := uc.repo.CountMessagesOlderThan(ctx, time.Now().Add(-uc.retention))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count old messages")
+		return 0, err
+	}
+	return n, nil
+}
+
+// DeleteExpired удаляет сообщения старше срока хранения и возвращает их количество. Обычно это происходит
+// после каждого нового сообщения, но в чат без новых сообщений старые остаются до ручного запуска
+func (uc *ChatUseCase) DeleteExpired(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "ChatUseCase.DeleteExpired")
+	defer span.End()
+	n, err := uc.repo.DeleteMessagesOlderThan(ctx, time.Now().Add(-uc.retention))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete old messages")
+		return 0, err
+	}
+	log.Ctx(ctx).Info().Int("count", n).Dur("retention", uc.retention).Msg("Old messages deleted")
+	return n, nil
+}
+
+func (uc *ChatUseCase) HandleConnection(ctx context.Context, conn *websocket.Conn, user string, id int, clients map[*websocket.Conn]struct{}) {
+	ctx, span := tracing.Start(ctx, "ChatUseCase.HandleConnection")
+	defer span.End()
+	log.Ctx(ctx).Info().Str("user", user).Int("userID", id).Msg("Handling new WebSocket connection")
 
 	for {
 		_, data, err := conn.ReadMessage()
 		if err != nil {
-			log.Warn().Err(err).Msg("ReadMessage error, terminating connection loop")
+			log.Ctx(ctx).Warn().Err(err).Msg("ReadMessage error, terminating connection loop")
 			return
 		}
 		if id == 0 {
-			log.Warn().Str("user", user).Int("userID", id).Msg("Received message from invalid user ID, skipping")
+			log.Ctx(ctx).Warn().Str("user", user).Int("userID", id).Msg("Received message from invalid user ID, skipping")
 			continue
 		}
+		uc.metrics.ChatMessageReceived()
 		text := string(data)
-		log.Debug().Str("user", user).Int("userID", id).Str("message", text).Msg("Received message")
+		log.Ctx(ctx).Debug().Str("user", user).Int("userID", id).Str("message", text).Msg("Received message")
 
 		m := &model.Message{UserID: id, Username: user, Content: text, Timestamp: time.Now()}
 		if err := m.Validate(); err != nil {
-			log.Warn().Str("user", user).Int("userID", id).Msg("Message validation failed")
+			log.Ctx(ctx).Warn().Str("user", user).Int("userID", id).Msg("Message validation failed")
 		} else {
-			if err := uc.repo.SaveMessage(m); err != nil {
-				log.Error().Err(err).Msg("Failed to save message")
+			if err := uc.repo.SaveMessage(ctx, m); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to save message")
 			} else {
-				log.Info().Str("user", user).Int("userID", id).Msg("Message saved to repository")
+				log.Ctx(ctx).Info().Str("user", user).Int("userID", id).Msg("Message saved to repository")
+				m.Mentions = saveMentions(ctx, uc.mentions, model.MentionSourceMessage, m.ID, user, m.Content, m.Timestamp)
 			}
-			if err := uc.repo.DeleteMessagesOlderThan(time.Now().Add(-24 * time.Hour)); err != nil {
-				log.Error().Err(err).Msg("Failed to delete old messages")
+			if _, err := uc.repo.DeleteMessagesOlderThan(ctx, time.Now().Add(-uc.retention)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("Failed to delete old messages")
 			} else {
-				log.Debug().Msg("Old messages cleanup completed")
+				log.Ctx(ctx).Debug().Msg("Old messages cleanup completed")
 			}
 		}
 
+		if m.Mentions == nil {
+			m.Mentions = mention.Parse(m.Content)
+		}
 		out, _ := json.Marshal(struct {
-			Username string `json:"username"`
-			Content  string `json:"content"`
-		}{user, text})
+			Username string               `json:"username"`
+			Content  string               `json:"content"`
+			Mentions []model.MentionRange `json:"mentions,omitempty"`
+		}{user, m.Content, m.Mentions})
 
+		sent := 0
 		for c := range clients {
 			if err := c.WriteMessage(websocket.TextMessage, out); err != nil {
-				log.Warn().Err(err).Msg("Error writing to client, removing connection")
+				log.Ctx(ctx).Warn().Err(err).Msg("Error writing to client, removing connection")
 				c.Close()
 				delete(clients, c)
 			} else {
-				log.Debug().Msg("Message broadcast to client")
+				sent++
+				log.Ctx(ctx).Debug().Msg("Message broadcast to client")
 			}
 		}
+		uc.metrics.ChatMessagesSent(sent)
 	}
 }
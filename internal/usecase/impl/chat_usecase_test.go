@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/mocks"
+)
+
+func TestChatUseCase_GetAllMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChatRepository(ctrl)
+	msgs := []model.Message{
+		{Timestamp: time.Now().Add(-2 * time.Hour)},
+		{Timestamp: time.Now().Add(-1 * time.Hour)},
+	}
+	mockRepo.EXPECT().GetAllMessages(gomock.Any()).Return(msgs, nil)
+
+	uc := NewChatUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), 24*time.Hour, nil)
+	result, err := uc.GetAllMessages(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.True(t, result[0].Timestamp.Before(result[1].Timestamp))
+}
+
+func TestChatUseCase_GetAllMessages_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChatRepository(ctrl)
+	mockRepo.EXPECT().GetAllMessages(gomock.Any()).Return(nil, errors.New("fail"))
+
+	uc := NewChatUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), 24*time.Hour, nil)
+	result, err := uc.GetAllMessages(context.Background())
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestChatUseCase_HandleConnection_ValidMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChatRepository(ctrl)
+	mockRepo.EXPECT().SaveMessage(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockRepo.EXPECT().DeleteMessagesOlderThan(gomock.Any(), gomock.Any()).Return(0, nil).Times(1)
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		uc := NewChatUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), 24*time.Hour, nil)
+		uc.HandleConnection(context.Background(), conn, "testUser", 1, map[*websocket.Conn]struct{}{conn: {}})
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	wsURL := "ws" + server.URL[4:]
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatal("Dial:", err)
+	}
+	defer conn.Close()
+
+	err = conn.WriteMessage(websocket.TextMessage, []byte("Hello"))
+	assert.NoError(t, err)
+
+	_, msg, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	assert.Contains(t, string(msg), "Hello")
+}
+
+func TestChatUseCase_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockChatRepository(ctrl)
+	retention := 24 * time.Hour
+	checkCutoff := func(before time.Time) {
+		assert.WithinDuration(t, time.Now().Add(-retention), before, time.Minute)
+	}
+	mockRepo.EXPECT().CountMessagesOlderThan(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		checkCutoff(before)
+		return 12, nil
+	}).Times(1)
+	mockRepo.EXPECT().DeleteMessagesOlderThan(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		checkCutoff(before)
+		return 11, nil
+	}).Times(1)
+
+	uc := NewChatUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), retention, nil)
+	n, err := uc.CountExpired(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, n)
+	n, err = uc.DeleteExpired(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 11, n)
+}
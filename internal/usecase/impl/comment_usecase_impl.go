@@ -1,65 +1,196 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"golangforum/internal/markdown"
+	"golangforum/internal/mention"
 	"golangforum/internal/repository"
+	"golangforum/internal/tracing"
 	"golangforum/internal/usecase"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"golangforum/internal/metrics"
 	"golangforum/internal/model"
 )
 
 type CommentUseCase struct {
-	repo repository.CommentRepository
+	repo        repository.CommentRepository
+	mentions    repository.MentionRepository
+	attachments repository.AttachmentRepository
+	reactions   repository.ReactionRepository
+	topics      repository.TopicRepository
+	renderer    *markdown.Renderer
+	metrics     *metrics.Metrics
 }
 
-func NewCommentUseCase(repo repository.CommentRepository) *CommentUseCase {
+func NewCommentUseCase(
+	repo repository.CommentRepository,
+	mentions repository.MentionRepository,
+	attachments repository.AttachmentRepository,
+	reactions repository.ReactionRepository,
+	topics repository.TopicRepository,
+	renderer *markdown.Renderer,
+	m *metrics.Metrics,
+) *CommentUseCase {
 	log.Info().Msg("CommentUseCase initialized")
-	return &CommentUseCase{repo: repo}
+	return &CommentUseCase{repo: repo, mentions: mentions, attachments: attachments, reactions: reactions, topics: topics, renderer: renderer, metrics: m}
 }
 
-func (uc *CommentUseCase) Create(username string, c *model.Comment) error {
-	log.Debug().Str("username", username).Msg("Creating comment")
+func (uc *CommentUseCase) Create(ctx context.Context, username string, c *model.Comment) error {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.Create")
+	defer span.End()
+	log.Ctx(ctx).Debug().Str("username", username).Msg("Creating comment")
 	c.Username = username
 	c.Timestamp = time.Now()
 	if err := c.Validate(); err != nil {
-		log.Warn().Err(err).Msg("Comment validation failed")
-		return usecase.ErrInvalidCommentData
+		log.Ctx(ctx).Warn().Err(err).Msg("Comment validation failed")
+		return err
+	}
+	topic, err := uc.topics.GetByPost(ctx, c.PostID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Int("postID", c.PostID).Msg("Comment post not found")
+			return usecase.ErrPostNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch comment topic")
+		return err
 	}
-	if err := uc.repo.Create(c); err != nil {
-		log.Error().Err(err).Msg("Failed to save comment")
+	if topic.Archived {
+		log.Ctx(ctx).Warn().Int("topicID", topic.ID).Msg("Comment in archived topic rejected")
+		return usecase.ErrTopicArchived
+	}
+	if err := checkAttachments(ctx, uc.attachments, c.AttachmentIDs, c.UserID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Ints("attachmentIDs", c.AttachmentIDs).Msg("Comment attachments check failed")
+		return err
+	}
+	if err := uc.repo.Create(ctx, c); err != nil {
+		if errors.Is(err, repository.ErrForeignKey) {
+			log.Ctx(ctx).Warn().Int("postID", c.PostID).Msg("Comment post disappeared before save")
+			return usecase.ErrPostNotFound
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			log.Ctx(ctx).Warn().Ints("attachmentIDs", c.AttachmentIDs).Msg("Comment attachments were linked elsewhere before save")
+			return usecase.ErrInvalidAttachment
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to save comment")
 		return err
 	}
-	log.Info().
+	if len(c.AttachmentIDs) > 0 {
+		attachments, err := uc.attachments.GetByComments(ctx, []int{c.ID})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch comment attachments")
+			return err
+		}
+		c.Attachments = attachments
+	}
+	c.Mentions = saveMentions(ctx, uc.mentions, model.MentionSourceComment, c.ID, username, c.Content, c.Timestamp)
+	c.ContentHTML = uc.renderer.Render(c.Content)
+	uc.metrics.CommentCreated()
+	log.Ctx(ctx).Info().
 		Str("username", username).
 		Time("timestamp", c.Timestamp).
 		Msg("Comment created")
 	return nil
 }
 
-func (uc *CommentUseCase) GetByPost(postID int) ([]model.Comment, error) {
-	log.Debug().Int("postID", postID).Msg("Fetching comments for post")
-	comments, err := uc.repo.GetByPost(postID)
+// GetByID возвращает комментарий с вложениями, реакциями и голосом пользователя viewerID (0 — анонимный пользователь)
+func (uc *CommentUseCase) GetByID(ctx context.Context, id, viewerID int) (*model.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.GetByID")
+	defer span.End()
+	log.Ctx(ctx).Debug().Int("id", id).Msg("Fetching comment")
+	c, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Int("id", id).Msg("Comment not found")
+			return nil, usecase.ErrCommentNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch comment")
+		return nil, err
+	}
+	comments := []model.Comment{*c}
+	if err := uc.decorate(ctx, comments, viewerID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch comment details")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().Int("id", id).Msg("Comment fetched")
+	return &comments[0], nil
+}
+
+func (uc *CommentUseCase) GetByPost(ctx context.Context, postID, viewerID int, sort string) ([]model.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.GetByPost")
+	defer span.End()
+	log.Ctx(ctx).Debug().Int("postID", postID).Str("sort", sort).Msg("Fetching comments for post")
+	if !validSort(sort) {
+		log.Ctx(ctx).Warn().Str("sort", sort).Msg("Unknown comment sort")
+		return nil, usecase.ErrInvalidSort
+	}
+	comments, err := uc.repo.GetByPost(ctx, postID, sort)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch comments")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch comments")
 		return nil, err
 	}
-	log.Info().
+	if err := uc.decorate(ctx, comments, viewerID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch comment details")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
 		Int("postID", postID).
 		Int("count", len(comments)).
 		Msg("Comments fetched")
 	return comments, nil
 }
 
-func (uc *CommentUseCase) Delete(id int) error {
-	log.Debug().Int("id", id).Msg("Deleting comment")
-	if err := uc.repo.Delete(id); err != nil {
-		log.Error().Err(err).Msg("Failed to delete comment")
-		if err.Error() == "not found" {
+// Delete переносит комментарий в корзину; userID — удаливший пользователь (0 — неизвестен)
+func (uc *CommentUseCase) Delete(ctx context.Context, id, userID int) error {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.Delete")
+	defer span.End()
+	log.Ctx(ctx).Debug().Int("id", id).Int("userID", userID).Msg("Deleting comment")
+	if err := uc.repo.Delete(ctx, id, userID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete comment")
+		if errors.Is(err, repository.ErrNotFound) {
 			return usecase.ErrCommentNotFound
 		}
 		return err
 	}
-	log.Info().Int("id", id).Msg("Comment deleted")
+	log.Ctx(ctx).Info().Int("id", id).Msg("Comment deleted")
 	return nil
 }
+
+// decorate заполняет поля, которые не хранятся в самой записи комментария: позиции упоминаний, HTML, вложения,
+// реакции и голос пользователя viewerID (0 — анонимный пользователь)
+func (uc *CommentUseCase) decorate(ctx context.Context, comments []model.Comment, viewerID int) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	ids := make([]int, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+		comments[i].Mentions = mention.Parse(comments[i].Content)
+		comments[i].ContentHTML = uc.renderer.Render(comments[i].Content)
+	}
+	attachments, err := uc.attachments.GetByComments(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byComment := make(map[int][]model.Attachment)
+	for _, a := range attachments {
+		byComment[*a.CommentID] = append(byComment[*a.CommentID], a)
+	}
+	summaries, err := uc.reactions.GetSummaries(ctx, model.TargetComment, ids, viewerID)
+	if err != nil {
+		return err
+	}
+	for i := range comments {
+		comments[i].Attachments = byComment[comments[i].ID]
+		comments[i].Reactions = summaries[comments[i].ID].Reactions
+		comments[i].MyVote = summaries[comments[i].ID].MyVote
+	}
+	return nil
+}
+
+// validSort проверяет значение сортировки списка комментариев; пустое значение — порядок создания
+func validSort(sort string) bool {
+	return sort == "" || sort == model.SortTop
+}
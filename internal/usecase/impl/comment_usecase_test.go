@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func TestCommentUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	comment := &model.Comment{ID: 1, PostID: 1, Content: "This is a comment"}
+
+	mockTopics.EXPECT().GetByPost(gomock.Any(), 1).Return(&model.Topic{ID: 1, Locked: true}, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), comment).Return(nil).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "testUser", comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "testUser", comment.Username)
+	assert.NotZero(t, comment.Timestamp)
+}
+
+func TestCommentUseCase_Create_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	comment := &model.Comment{ID: 1, PostID: 1, Content: ""}
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "testUser", comment)
+
+	assert.Error(t, err)
+}
+
+func TestCommentUseCase_Create_ArchivedTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockTopics.EXPECT().GetByPost(gomock.Any(), 1).Return(&model.Topic{ID: 1, Archived: true}, nil).Times(1)
+	mockTopics.EXPECT().GetByPost(gomock.Any(), 2).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewCommentUseCase(mocks.NewMockCommentRepository(ctrl), mocks.NewMockMentionRepository(ctrl),
+		mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+
+	assert.ErrorIs(t, uc.Create(context.Background(), "testUser", &model.Comment{PostID: 1, Content: "reply"}), usecase.ErrTopicArchived)
+	assert.ErrorIs(t, uc.Create(context.Background(), "testUser", &model.Comment{PostID: 2, Content: "reply"}), usecase.ErrPostNotFound)
+}
+
+func TestCommentUseCase_GetByPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	comments := []model.Comment{
+		{ID: 1, PostID: 1, Content: "First comment", Timestamp: time.Now()},
+		{ID: 2, PostID: 1, Content: "Second comment", Timestamp: time.Now()},
+	}
+
+	mockRepo.EXPECT().GetByPost(gomock.Any(), 1, model.SortTop).Return(comments, nil).Times(1)
+	mockAttachments.EXPECT().GetByComments(gomock.Any(), []int{1, 2}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetComment, []int{1, 2}, 3).Return(nil, nil).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTopics, markdown.NewRenderer(0), nil)
+	result, err := uc.GetByPost(context.Background(), 1, 3, model.SortTop)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, comments, result)
+}
+
+func TestCommentUseCase_GetByPost_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().GetByPost(gomock.Any(), 1, "").Return(nil, errors.New("database error")).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTopics, markdown.NewRenderer(0), nil)
+	result, err := uc.GetByPost(context.Background(), 1, 0, "")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCommentUseCase_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, 7).Return(nil).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Delete(context.Background(), 1, 7)
+
+	assert.NoError(t, err)
+}
+
+func TestCommentUseCase_Delete_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, 7).Return(errors.New("delete error")).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Delete(context.Background(), 1, 7)
+
+	assert.Error(t, err)
+}
+
+func TestCommentUseCase_Delete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockRepo.EXPECT().Delete(gomock.Any(), 42, 7).Return(repository.ErrNotFound).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl),
+		mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	err := uc.Delete(context.Background(), 42, 7)
+
+	assert.ErrorIs(t, err, usecase.ErrCommentNotFound)
+}
+
+func TestCommentUseCase_Create_PostDeletedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	mockTopics.EXPECT().GetByPost(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: comments_post_id_fkey", repository.ErrForeignKey)).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl),
+		mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "testUser", &model.Comment{PostID: 1, Content: "reply"})
+
+	assert.ErrorIs(t, err, usecase.ErrPostNotFound)
+}
+
+func TestCommentUseCase_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 3).Return(&model.Comment{ID: 3, PostID: 1, Content: "reply"}, nil).Times(1)
+	mockAttachments.EXPECT().GetByComments(gomock.Any(), []int{3}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetComment, []int{3}, 0).Return(nil, nil).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	c, err := uc.GetByID(context.Background(), 3, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, c.ID)
+	assert.Equal(t, "<p>reply</p>\n", c.ContentHTML)
+}
+
+func TestCommentUseCase_GetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCommentRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 42).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewCommentUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl),
+		mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	_, err := uc.GetByID(context.Background(), 42, 0)
+
+	assert.ErrorIs(t, err, usecase.ErrCommentNotFound)
+}
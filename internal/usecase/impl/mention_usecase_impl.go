@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"golangforum/internal/mention"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/tracing"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MentionUseCase struct {
+	Repo repository.MentionRepository
+}
+
+func NewMentionUseCase(repo repository.MentionRepository) *MentionUseCase {
+	log.Info().Msg("MentionUseCase initialized")
+	return &MentionUseCase{Repo: repo}
+}
+
+func (uc *MentionUseCase) GetByUsername(ctx context.Context, username string, limit, offset int) ([]model.Mention, int, error) {
+	ctx, span := tracing.Start(ctx, "MentionUseCase.GetByUsername")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Str("username", username).
+		Int("limit", limit).
+		Int("offset", offset).
+		Msg("Fetching mentions of user")
+	mentions, err := uc.Repo.GetByUsername(ctx, username, limit, offset)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch mentions")
+		return nil, 0, err
+	}
+	total, err := uc.Repo.CountByUsername(ctx, username)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count mentions")
+		return nil, 0, err
+	}
+	log.Ctx(ctx).Info().
+		Str("username", username).
+		Int("count", len(mentions)).
+		Int("total", total).
+		Msg("Mentions fetched")
+	return mentions, total, nil
+}
+
+// saveMentions находит упоминания в тексте и сохраняет по одной записи на каждого упомянутого пользователя.
+// Ошибки сохранения только логируются: сам пост, комментарий или сообщение к этому моменту уже созданы.
+func saveMentions(ctx context.Context, repo repository.MentionRepository, sourceType string, sourceID int, author, text string, ts time.Time) []model.MentionRange {
+	ranges := mention.Parse(text)
+	for _, name := range mention.Usernames(ranges) {
+		if strings.EqualFold(name, author) {
+			continue
+		}
+		m := &model.Mention{SourceType: sourceType, SourceID: sourceID, Username: name, Author: author, Timestamp: ts}
+		if err := repo.Create(ctx, m); err != nil {
+			log.Ctx(ctx).Error().Err(err).
+				Str("sourceType", sourceType).
+				Int("sourceID", sourceID).
+				Str("username", name).
+				Msg("Failed to save mention")
+		}
+	}
+	return ranges
+}
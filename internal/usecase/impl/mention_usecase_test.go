@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/mocks"
+)
+
+func TestMentionUseCase_GetByUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMentionRepository(ctrl)
+	mentions := []model.Mention{
+		{ID: 2, SourceType: model.MentionSourceComment, SourceID: 5, Username: "alice", Author: "bob", Timestamp: time.Now()},
+		{ID: 1, SourceType: model.MentionSourcePost, SourceID: 3, Username: "alice", Author: "carol", Timestamp: time.Now()},
+	}
+
+	mockRepo.EXPECT().GetByUsername(gomock.Any(), "alice", 20, 0).Return(mentions, nil).Times(1)
+	mockRepo.EXPECT().CountByUsername(gomock.Any(), "alice").Return(7, nil).Times(1)
+
+	uc := NewMentionUseCase(mockRepo)
+	result, total, err := uc.GetByUsername(context.Background(), "alice", 20, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mentions, result)
+	assert.Equal(t, 7, total)
+}
+
+func TestMentionUseCase_GetByUsername_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMentionRepository(ctrl)
+	mockRepo.EXPECT().GetByUsername(gomock.Any(), "alice", 20, 0).Return(nil, errors.New("database error")).Times(1)
+
+	uc := NewMentionUseCase(mockRepo)
+	result, total, err := uc.GetByUsername(context.Background(), "alice", 20, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Zero(t, total)
+}
@@ -1,86 +1,364 @@
 package usecase
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"golangforum/internal/markdown"
+	"golangforum/internal/mention"
 	"golangforum/internal/repository"
+	"golangforum/internal/tag"
+	"golangforum/internal/tracing"
 	"golangforum/internal/usecase"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"golangforum/internal/metrics"
 	"golangforum/internal/model"
 )
 
 type PostUseCase struct {
-	Repo repository.PostRepository
+	Repo        repository.PostRepository
+	Mentions    repository.MentionRepository
+	Attachments repository.AttachmentRepository
+	Reactions   repository.ReactionRepository
+	Tags        repository.TagRepository
+	Topics      repository.TopicRepository
+	Renderer    *markdown.Renderer
+	Metrics     *metrics.Metrics
 }
 
-func NewPostUseCase(repo repository.PostRepository) *PostUseCase {
+func NewPostUseCase(
+	repo repository.PostRepository,
+	mentions repository.MentionRepository,
+	attachments repository.AttachmentRepository,
+	reactions repository.ReactionRepository,
+	tags repository.TagRepository,
+	topics repository.TopicRepository,
+	renderer *markdown.Renderer,
+	m *metrics.Metrics,
+) *PostUseCase {
 	log.Info().Msg("PostUseCase initialized")
-	return &PostUseCase{Repo: repo}
+	return &PostUseCase{Repo: repo, Mentions: mentions, Attachments: attachments, Reactions: reactions, Tags: tags, Topics: topics, Renderer: renderer, Metrics: m}
 }
 
-func (uc *PostUseCase) Create(username string, post *model.Post) error {
-	log.Debug().
+func (uc *PostUseCase) Create(ctx context.Context, username string, post *model.Post) error {
+	ctx, span := tracing.Start(ctx, "PostUseCase.Create")
+	defer span.End()
+	log.Ctx(ctx).Debug().
 		Str("username", username).
 		Msg("Creating post")
 	post.Username = username
 	post.Timestamp = time.Now()
 	if err := post.Validate(); err != nil {
-		log.Warn().Err(err).Msg("Post validation failed")
-		return usecase.ErrInvalidPostData
+		log.Ctx(ctx).Warn().Err(err).Msg("Post validation failed")
+		return err
+	}
+	tags, err := tag.Normalize(post.Tags)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Strs("tags", post.Tags).Msg("Post tags validation failed")
+		return usecase.ErrInvalidTag
 	}
-	if err := uc.Repo.Create(post); err != nil {
-		log.Error().Err(err).Msg("Failed to save post")
+	post.Tags = tags
+	if err := uc.checkTopic(ctx, post.TopicID, true); err != nil {
 		return err
 	}
-	log.Info().
+	if err := checkAttachments(ctx, uc.Attachments, post.AttachmentIDs, post.UserID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Ints("attachmentIDs", post.AttachmentIDs).Msg("Post attachments check failed")
+		return err
+	}
+	if err := uc.Repo.Create(ctx, post); err != nil {
+		if errors.Is(err, repository.ErrForeignKey) {
+			log.Ctx(ctx).Warn().Int("topicID", post.TopicID).Msg("Post topic disappeared before save")
+			return usecase.ErrTopicNotFound
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			log.Ctx(ctx).Warn().Ints("attachmentIDs", post.AttachmentIDs).Msg("Post attachments were linked elsewhere before save")
+			return usecase.ErrInvalidAttachment
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to save post")
+		return err
+	}
+	if len(post.AttachmentIDs) > 0 {
+		attachments, err := uc.Attachments.GetByPosts(ctx, []int{post.ID})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post attachments")
+			return err
+		}
+		post.Attachments = attachments
+	}
+	post.Mentions = saveMentions(ctx, uc.Mentions, model.MentionSourcePost, post.ID, username, post.Content, post.Timestamp)
+	post.ContentHTML = uc.Renderer.Render(post.Content)
+	uc.Metrics.PostCreated()
+	log.Ctx(ctx).Info().
 		Str("username", username).
 		Time("timestamp", post.Timestamp).
 		Msg("Post created")
 	return nil
 }
 
-func (uc *PostUseCase) GetByTopic(topicID int) ([]model.Post, error) {
-	log.Debug().
-		Int("topicID", topicID).
-		Msg("Fetching posts by topic")
-	posts, err := uc.Repo.GetByTopic(topicID)
+// GetByID возвращает пост с вложениями, тегами, путем темы, реакциями и голосом пользователя viewerID (0 — анонимный пользователь)
+func (uc *PostUseCase) GetByID(ctx context.Context, id, viewerID int) (*model.Post, error) {
+	ctx, span := tracing.Start(ctx, "PostUseCase.GetByID")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("id", id).
+		Int("viewerID", viewerID).
+		Msg("Fetching post")
+	post, err := uc.Repo.GetByID(ctx, id)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch posts by topic")
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Int("id", id).Msg("Post not found")
+			return nil, usecase.ErrPostNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post")
 		return nil, err
 	}
-	log.Info().
-		Int("topicID", topicID).
+	posts := []model.Post{*post}
+	if err := uc.decorate(ctx, posts, viewerID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post details")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", id).
+		Msg("Post fetched")
+	return &posts[0], nil
+}
+
+func (uc *PostUseCase) List(ctx context.Context, viewerID int, f model.PostFilter) ([]model.Post, int, error) {
+	ctx, span := tracing.Start(ctx, "PostUseCase.List")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("topicID", f.TopicID).
+		Str("author", f.Author).
+		Str("sort", f.Sort).
+		Int("limit", f.Limit).
+		Int("offset", f.Offset).
+		Msg("Fetching posts")
+	if err := f.Validate(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Post filter validation failed")
+		return nil, 0, fmt.Errorf("%w: %w", usecase.ErrInvalidPostFilter, err)
+	}
+	tags, err := tag.Normalize(f.Tags)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Strs("tags", f.Tags).Msg("Post filter tags validation failed")
+		return nil, 0, usecase.ErrInvalidPostFilter
+	}
+	f.Tags = tags
+	posts, err := uc.Repo.List(ctx, f)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch posts")
+		return nil, 0, err
+	}
+	total, err := uc.Repo.Count(ctx, f)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count posts")
+		return nil, 0, err
+	}
+	if err := uc.decorate(ctx, posts, viewerID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post details")
+		return nil, 0, err
+	}
+	log.Ctx(ctx).Info().
+		Int("topicID", f.TopicID).
 		Int("count", len(posts)).
-		Msg("Posts fetched by topic")
-	return posts, nil
+		Int("total", total).
+		Msg("Posts fetched")
+	return posts, total, nil
 }
 
-func (uc *PostUseCase) GetAll() ([]model.Post, error) {
-	log.Debug().Msg("Fetching all posts")
-	posts, err := uc.Repo.GetAll()
+// Update изменяет у поста только переданные в req поля: заголовок, текст и теги. Редактировать пост может только его автор.
+// При изменении текста упоминания поста пересобираются по новому тексту со временем создания поста
+func (uc *PostUseCase) Update(ctx context.Context, userID int, req *model.PostUpdateRequest) (*model.Post, error) {
+	ctx, span := tracing.Start(ctx, "PostUseCase.Update")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("id", req.ID).
+		Int("userID", userID).
+		Msg("Updating post")
+	existing, err := uc.Repo.GetByID(ctx, req.ID)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch all posts")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, usecase.ErrPostNotFound
+		}
 		return nil, err
 	}
-	log.Info().
-		Int("count", len(posts)).
-		Msg("All posts fetched")
-	return posts, nil
+	if existing.UserID != userID {
+		log.Ctx(ctx).Warn().Int("authorID", existing.UserID).Msg("Post update by non-author rejected")
+		return nil, usecase.ErrForbidden
+	}
+	if err := uc.checkTopic(ctx, existing.TopicID, false); err != nil {
+		return nil, err
+	}
+	if req.Title != nil {
+		existing.Title = *req.Title
+	}
+	if req.Content != nil {
+		existing.Content = *req.Content
+	}
+	if err := existing.Validate(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Post validation failed")
+		return nil, err
+	}
+	if req.Tags != nil {
+		if existing.Tags, err = tag.Normalize(*req.Tags); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Strs("tags", *req.Tags).Msg("Post tags validation failed")
+			return nil, usecase.ErrInvalidTag
+		}
+	} else {
+		// репозиторий записывает теги поста целиком, поэтому без tags в запросе сохраняются текущие
+		tags, err := uc.Tags.GetByPosts(ctx, []int{existing.ID})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post tags")
+			return nil, err
+		}
+		existing.Tags = tags[existing.ID]
+	}
+	if err := uc.Repo.Update(ctx, existing); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update post")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, usecase.ErrPostNotFound
+		}
+		return nil, err
+	}
+	if req.Content != nil {
+		if err := uc.Mentions.DeleteBySource(ctx, model.MentionSourcePost, existing.ID); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to delete post mentions")
+			return nil, err
+		}
+		saveMentions(ctx, uc.Mentions, model.MentionSourcePost, existing.ID, existing.Username, existing.Content, existing.Timestamp)
+	}
+	posts := []model.Post{*existing}
+	if err := uc.decorate(ctx, posts, userID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post details")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", existing.ID).
+		Strs("tags", posts[0].Tags).
+		Msg("Post updated")
+	return &posts[0], nil
 }
 
-func (uc *PostUseCase) Delete(id int) error {
-	log.Debug().
+// Delete переносит пост вместе с комментариями в корзину; userID — удаливший пользователь (0 — неизвестен)
+func (uc *PostUseCase) Delete(ctx context.Context, id, userID int) error {
+	ctx, span := tracing.Start(ctx, "PostUseCase.Delete")
+	defer span.End()
+	log.Ctx(ctx).Debug().
 		Int("id", id).
+		Int("userID", userID).
 		Msg("Deleting post")
-	if err := uc.Repo.Delete(id); err != nil {
-		log.Error().Err(err).Msg("Failed to delete post")
-		if err.Error() == "not found" {
+	if err := uc.Repo.Delete(ctx, id, userID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete post")
+		if errors.Is(err, repository.ErrNotFound) {
 			return usecase.ErrPostNotFound
 		}
 		return err
 	}
-	log.Info().
+	log.Ctx(ctx).Info().
 		Int("id", id).
 		Msg("Post deleted")
 	return nil
 }
+
+// Move переносит посты ids в тему topicID вместе с комментариями и возвращает количество перенесенных.
+// Удаленные посты и посты, уже находящиеся в теме, пропускаются. В архивную тему посты не переносятся
+func (uc *PostUseCase) Move(ctx context.Context, ids []int, topicID int) (int, error) {
+	ctx, span := tracing.Start(ctx, "PostUseCase.Move")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Ints("ids", ids).
+		Int("topicID", topicID).
+		Msg("Moving posts")
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := uc.checkTopic(ctx, topicID, false); err != nil {
+		return 0, err
+	}
+	n, err := uc.Repo.Move(ctx, ids, topicID)
+	if err != nil {
+		if errors.Is(err, repository.ErrForeignKey) {
+			log.Ctx(ctx).Warn().Int("topicID", topicID).Msg("Target topic disappeared before move")
+			return 0, usecase.ErrTopicNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to move posts")
+		return 0, err
+	}
+	log.Ctx(ctx).Info().
+		Int("topicID", topicID).
+		Int("count", n).
+		Msg("Posts moved")
+	return n, nil
+}
+
+// checkTopic проверяет, что в тему можно писать: она существует и не в архиве, а для нового поста (newPost) — еще и не закрыта
+func (uc *PostUseCase) checkTopic(ctx context.Context, topicID int, newPost bool) error {
+	topic, err := uc.Topics.GetByID(ctx, topicID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Int("topicID", topicID).Msg("Post topic not found")
+			return usecase.ErrTopicNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch post topic")
+		return err
+	}
+	if topic.Archived {
+		log.Ctx(ctx).Warn().Int("topicID", topicID).Msg("Write to archived topic rejected")
+		return usecase.ErrTopicArchived
+	}
+	if newPost && topic.Locked {
+		log.Ctx(ctx).Warn().Int("topicID", topicID).Msg("Post in locked topic rejected")
+		return usecase.ErrTopicLocked
+	}
+	return nil
+}
+
+// decorate заполняет поля, которые не хранятся в самой записи поста: позиции упоминаний, HTML, вложения, теги,
+// навигационную цепочку тем, реакции и голос пользователя viewerID (0 — анонимный пользователь)
+func (uc *PostUseCase) decorate(ctx context.Context, posts []model.Post, viewerID int) error {
+	if len(posts) == 0 {
+		return nil
+	}
+	ids := make([]int, len(posts))
+	var topicIDs []int
+	seenTopics := make(map[int]bool)
+	for i := range posts {
+		ids[i] = posts[i].ID
+		if !seenTopics[posts[i].TopicID] {
+			seenTopics[posts[i].TopicID] = true
+			topicIDs = append(topicIDs, posts[i].TopicID)
+		}
+		posts[i].Mentions = mention.Parse(posts[i].Content)
+		posts[i].ContentHTML = uc.Renderer.Render(posts[i].Content)
+	}
+	attachments, err := uc.Attachments.GetByPosts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byPost := make(map[int][]model.Attachment)
+	for _, a := range attachments {
+		byPost[*a.PostID] = append(byPost[*a.PostID], a)
+	}
+	summaries, err := uc.Reactions.GetSummaries(ctx, model.TargetPost, ids, viewerID)
+	if err != nil {
+		return err
+	}
+	tags, err := uc.Tags.GetByPosts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	paths, err := uc.Topics.GetPaths(ctx, topicIDs)
+	if err != nil {
+		return err
+	}
+	for i := range posts {
+		posts[i].Attachments = byPost[posts[i].ID]
+		posts[i].Tags = tags[posts[i].ID]
+		posts[i].Breadcrumbs = paths[posts[i].TopicID]
+		posts[i].Reactions = summaries[posts[i].ID].Reactions
+		posts[i].MyVote = summaries[posts[i].ID].MyVote
+	}
+	return nil
+}
@@ -0,0 +1,673 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func TestPostUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	post := &model.Post{
+		ID:      1,
+		TopicID: 1,
+		Title:   "Valid Title",
+		Content: "This is a post",
+	}
+
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Eq(post)).Return(nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "testUser", post)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "testUser", post.Username)
+	assert.NotZero(t, post.Timestamp)
+	assert.Equal(t, "<p>This is a post</p>\n", post.ContentHTML)
+}
+
+func TestPostUseCase_Create_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	post := &model.Post{
+		ID:      1,
+		TopicID: 1,
+		Content: "",
+	}
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "testUser", post)
+
+	var verr *model.ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, []model.FieldError{
+			{Field: "title", Message: "cannot be empty"},
+			{Field: "content", Message: "cannot be empty"},
+		}, verr.Fields)
+	}
+}
+
+func TestPostUseCase_Create_SavesMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockMentions := mocks.NewMockMentionRepository(ctrl)
+	post := &model.Post{TopicID: 1, Title: "Title", Content: "@alice и @bob, смотрите. @testUser @alice"}
+
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), post).DoAndReturn(func(_ context.Context, p *model.Post) error {
+		p.ID = 42
+		return nil
+	}).Times(1)
+	var saved []model.Mention
+	mockMentions.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *model.Mention) error {
+		saved = append(saved, *m)
+		return nil
+	}).Times(2)
+
+	uc := NewPostUseCase(mockRepo, mockMentions, mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "testUser", post)
+
+	assert.NoError(t, err)
+	assert.Len(t, post.Mentions, 4)
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, "alice", saved[0].Username)
+		assert.Equal(t, "bob", saved[1].Username)
+		for _, m := range saved {
+			assert.Equal(t, model.MentionSourcePost, m.SourceType)
+			assert.Equal(t, 42, m.SourceID)
+			assert.Equal(t, "testUser", m.Author)
+		}
+	}
+}
+
+func TestPostUseCase_Create_WithAttachments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	post := &model.Post{TopicID: 1, UserID: 3, Title: "Logs", Content: "see attached", AttachmentIDs: []int{10}}
+
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockAttachments.EXPECT().GetByID(gomock.Any(), 10).Return(&model.Attachment{ID: 10, UserID: 3}, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), post).DoAndReturn(func(_ context.Context, p *model.Post) error {
+		p.ID = 42
+		return nil
+	})
+	postID := 42
+	mockAttachments.EXPECT().GetByPosts(gomock.Any(), []int{42}).Return([]model.Attachment{{ID: 10, PostID: &postID}}, nil)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "alice", post)
+
+	assert.NoError(t, err)
+	assert.Len(t, post.Attachments, 1)
+}
+
+func TestPostUseCase_Create_AttachmentLinkedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	post := &model.Post{TopicID: 1, UserID: 3, Title: "Logs", Content: "see attached", AttachmentIDs: []int{10}}
+
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockAttachments.EXPECT().GetByID(gomock.Any(), 10).Return(&model.Attachment{ID: 10, UserID: 3}, nil)
+	// вложение прикрепили к другому посту между проверкой и транзакцией создания
+	mockRepo.EXPECT().Create(gomock.Any(), post).Return(repository.ErrConflict)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "alice", post)
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidAttachment)
+}
+
+func TestPostUseCase_Create_ForeignAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	post := &model.Post{TopicID: 1, UserID: 3, Title: "Logs", Content: "see attached", AttachmentIDs: []int{10}}
+
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockAttachments.EXPECT().GetByID(gomock.Any(), 10).Return(&model.Attachment{ID: 10, UserID: 4}, nil)
+
+	uc := NewPostUseCase(mocks.NewMockPostRepository(ctrl), mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "alice", post)
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidAttachment)
+}
+
+func TestPostUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	posts := []model.Post{
+		{ID: 1, TopicID: 1, Content: "First post", Timestamp: time.Now()},
+		{ID: 2, TopicID: 1, Content: "Second post", Timestamp: time.Now()},
+	}
+	f := model.PostFilter{TopicID: 1, Limit: 2}
+
+	mockRepo.EXPECT().List(gomock.Any(), f).Return(posts, nil).Times(1)
+	mockRepo.EXPECT().Count(gomock.Any(), f).Return(5, nil).Times(1)
+	mockAttachments.EXPECT().GetByPosts(gomock.Any(), []int{1, 2}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetPost, []int{1, 2}, 0).Return(nil, nil).Times(1)
+	mockTags.EXPECT().GetByPosts(gomock.Any(), []int{1, 2}).Return(nil, nil).Times(1)
+	mockTopics.EXPECT().GetPaths(gomock.Any(), []int{1}).Return(nil, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	result, total, err := uc.List(context.Background(), 0, f)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, posts, result)
+	assert.Equal(t, 5, total)
+}
+
+func TestPostUseCase_List_Canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f := model.PostFilter{TopicID: 1}
+
+	mockRepo.EXPECT().List(ctx, f).Return(nil, ctx.Err()).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	_, _, err := uc.List(ctx, 0, f)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPostUseCase_List_Reactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	posts := []model.Post{
+		{ID: 2, TopicID: 1, Content: "Popular post", Score: 5},
+		{ID: 1, TopicID: 1, Content: "Quiet post"},
+	}
+	f := model.PostFilter{TopicID: 1, Sort: model.SortTop}
+
+	mockRepo.EXPECT().List(gomock.Any(), f).Return(posts, nil).Times(1)
+	mockRepo.EXPECT().Count(gomock.Any(), f).Return(2, nil).Times(1)
+	mockAttachments.EXPECT().GetByPosts(gomock.Any(), []int{2, 1}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetPost, []int{2, 1}, 7).Return(map[int]model.ReactionSummary{
+		2: {Reactions: []model.ReactionCount{{Emoji: "👍", Count: 3, Reacted: true}}, MyVote: 1},
+	}, nil).Times(1)
+	mockTags.EXPECT().GetByPosts(gomock.Any(), []int{2, 1}).Return(map[int][]string{1: {"go"}}, nil).Times(1)
+	mockTopics.EXPECT().GetPaths(gomock.Any(), []int{1}).Return(map[int][]model.TopicRef{
+		1: {{ID: 3, Title: "Backend"}, {ID: 1, Title: "Go"}},
+	}, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	result, _, err := uc.List(context.Background(), 7, f)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result[0].MyVote)
+	assert.Equal(t, []model.ReactionCount{{Emoji: "👍", Count: 3, Reacted: true}}, result[0].Reactions)
+	assert.Zero(t, result[1].MyVote)
+	assert.Empty(t, result[1].Reactions)
+	assert.Empty(t, result[0].Tags)
+	assert.Equal(t, []string{"go"}, result[1].Tags)
+	assert.Equal(t, []model.TopicRef{{ID: 3, Title: "Backend"}, {ID: 1, Title: "Go"}}, result[0].Breadcrumbs)
+	assert.Equal(t, result[0].Breadcrumbs, result[1].Breadcrumbs)
+}
+
+func TestPostUseCase_List_NormalizesTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	expected := model.PostFilter{Tags: []string{"go-modules", "sql"}, TagMode: model.TagModeAny}
+
+	mockRepo.EXPECT().List(gomock.Any(), expected).Return(nil, nil).Times(1)
+	mockRepo.EXPECT().Count(gomock.Any(), expected).Return(0, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	_, _, err := uc.List(context.Background(), 0, model.PostFilter{Tags: []string{"Go Modules", "SQL", "#sql"}, TagMode: model.TagModeAny})
+
+	assert.NoError(t, err)
+}
+
+func TestPostUseCase_List_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewPostUseCase(mocks.NewMockPostRepository(ctrl), mocks.NewMockMentionRepository(ctrl),
+		mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, f := range []model.PostFilter{
+		{Sort: "score; DROP TABLE posts"},
+		{From: &from, To: &to},
+	} {
+		_, _, err := uc.List(context.Background(), 0, f)
+		assert.ErrorIs(t, err, usecase.ErrInvalidPostFilter)
+	}
+}
+
+func TestPostUseCase_List_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().List(gomock.Any(), model.PostFilter{}).Return(nil, errors.New("database error")).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	result, _, err := uc.List(context.Background(), 0, model.PostFilter{})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestPostUseCase_Create_NormalizesTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	post := &model.Post{TopicID: 1, Title: "Title", Content: "content", Tags: []string{" PostgreSQL ", "postgresql", "Go"}}
+
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), post).Return(nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "alice", post)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"postgresql", "go"}, post.Tags)
+}
+
+func TestPostUseCase_Create_TooManyTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewPostUseCase(mocks.NewMockPostRepository(ctrl), mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "alice", &model.Post{TopicID: 1, Title: "Title", Content: "content", Tags: []string{"a", "b", "c", "d", "e", "f"}})
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidTag)
+}
+
+func TestPostUseCase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	existing := &model.Post{ID: 5, TopicID: 2, UserID: 3, Username: "alice", Title: "Old", Content: "old"}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(existing, nil).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 2).Return(&model.Topic{ID: 2, Locked: true}, nil).Times(1)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *model.Post) error {
+		assert.Equal(t, 2, p.TopicID)
+		assert.Equal(t, "New", p.Title)
+		assert.Equal(t, []string{"go"}, p.Tags)
+		return nil
+	}).Times(1)
+	mockAttachments.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetPost, []int{5}, 3).Return(nil, nil).Times(1)
+	mockTags.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(map[int][]string{5: {"go"}}, nil).Times(1)
+	mockTopics.EXPECT().GetPaths(gomock.Any(), []int{2}).Return(nil, nil).Times(1)
+
+	mockMentions := mocks.NewMockMentionRepository(ctrl)
+	mockMentions.EXPECT().DeleteBySource(gomock.Any(), model.MentionSourcePost, 5).Return(nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mockMentions, mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	title, content := "New", "**new**"
+	post, err := uc.Update(context.Background(), 3, &model.PostUpdateRequest{ID: 5, Title: &title, Content: &content, Tags: &[]string{"Go"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", post.Username)
+	assert.Equal(t, "<p><strong>new</strong></p>\n", post.ContentHTML)
+	assert.Equal(t, []string{"go"}, post.Tags)
+}
+
+func TestPostUseCase_Update_KeepsOmittedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	existing := &model.Post{ID: 5, TopicID: 2, UserID: 3, Username: "alice", Title: "Old", Content: "old"}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(existing, nil).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 2).Return(&model.Topic{ID: 2}, nil).Times(1)
+	mockTags.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(map[int][]string{5: {"go", "sql"}}, nil).Times(2)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *model.Post) error {
+		assert.Equal(t, "Old", p.Title)
+		assert.Equal(t, "edited", p.Content)
+		assert.Equal(t, []string{"go", "sql"}, p.Tags)
+		return nil
+	}).Times(1)
+	mockAttachments.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetPost, []int{5}, 3).Return(nil, nil).Times(1)
+	mockTopics.EXPECT().GetPaths(gomock.Any(), []int{2}).Return(nil, nil).Times(1)
+
+	mockMentions := mocks.NewMockMentionRepository(ctrl)
+	mockMentions.EXPECT().DeleteBySource(gomock.Any(), model.MentionSourcePost, 5).Return(nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mockMentions, mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	content := "edited"
+	post, err := uc.Update(context.Background(), 3, &model.PostUpdateRequest{ID: 5, Content: &content})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Old", post.Title)
+	assert.Equal(t, []string{"go", "sql"}, post.Tags)
+}
+
+func TestPostUseCase_Update_RefreshesMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockMentions := mocks.NewMockMentionRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	existing := &model.Post{ID: 5, TopicID: 2, UserID: 3, Username: "alice", Title: "Old", Content: "cc @carol", Timestamp: created}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(existing, nil).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 2).Return(&model.Topic{ID: 2}, nil).Times(1)
+	mockTags.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	// упоминание @carol убрано из текста, а @bob добавлено
+	gomock.InOrder(
+		mockMentions.EXPECT().DeleteBySource(gomock.Any(), model.MentionSourcePost, 5).Return(nil),
+		mockMentions.EXPECT().Create(gomock.Any(), &model.Mention{
+			SourceType: model.MentionSourcePost, SourceID: 5, Username: "bob", Author: "alice", Timestamp: created,
+		}).Return(nil),
+	)
+	mockAttachments.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetPost, []int{5}, 3).Return(nil, nil).Times(1)
+	mockTopics.EXPECT().GetPaths(gomock.Any(), []int{2}).Return(nil, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mockMentions, mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	content := "cc @bob"
+	post, err := uc.Update(context.Background(), 3, &model.PostUpdateRequest{ID: 5, Content: &content})
+
+	assert.NoError(t, err)
+	if assert.Len(t, post.Mentions, 1) {
+		assert.Equal(t, "bob", post.Mentions[0].Username)
+	}
+}
+
+func TestPostUseCase_Update_NotAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&model.Post{ID: 5, TopicID: 2, UserID: 3}, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	_, err := uc.Update(context.Background(), 4, &model.PostUpdateRequest{ID: 5})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPostUseCase_Update_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	_, err := uc.Update(context.Background(), 3, &model.PostUpdateRequest{ID: 5})
+
+	assert.ErrorIs(t, err, usecase.ErrPostNotFound)
+}
+
+func TestPostUseCase_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, 7).Return(nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Delete(context.Background(), 1, 7)
+
+	assert.NoError(t, err)
+}
+
+func TestPostUseCase_Delete_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, 7).Return(errors.New("delete error")).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Delete(context.Background(), 1, 7)
+
+	assert.Error(t, err)
+}
+
+func TestPostUseCase_Delete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 42, 7).Return(repository.ErrNotFound).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl),
+		mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	err := uc.Delete(context.Background(), 42, 7)
+
+	assert.ErrorIs(t, err, usecase.ErrPostNotFound)
+}
+
+func TestPostUseCase_Create_TopicDeletedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: posts_topic_id_fkey", repository.ErrForeignKey)).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl),
+		mocks.NewMockTagRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+	err := uc.Create(context.Background(), "testUser", &model.Post{TopicID: 1, Title: "Title", Content: "content"})
+
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
+
+func TestPostUseCase_Create_ClosedTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1, Locked: true}, nil).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 2).Return(&model.Topic{ID: 2, Archived: true}, nil).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 3).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewPostUseCase(mocks.NewMockPostRepository(ctrl), mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+
+	assert.ErrorIs(t, uc.Create(context.Background(), "alice", &model.Post{TopicID: 1, Title: "Title", Content: "content"}), usecase.ErrTopicLocked)
+	assert.ErrorIs(t, uc.Create(context.Background(), "alice", &model.Post{TopicID: 2, Title: "Title", Content: "content"}), usecase.ErrTopicArchived)
+	assert.ErrorIs(t, uc.Create(context.Background(), "alice", &model.Post{TopicID: 3, Title: "Title", Content: "content"}), usecase.ErrTopicNotFound)
+}
+
+func TestPostUseCase_Update_ArchivedTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&model.Post{ID: 5, TopicID: 2, UserID: 3, Title: "Old", Content: "old"}, nil).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 2).Return(&model.Topic{ID: 2, Archived: true}, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+	_, err := uc.Update(context.Background(), 3, &model.PostUpdateRequest{ID: 5})
+
+	assert.ErrorIs(t, err, usecase.ErrTopicArchived)
+}
+
+func TestPostUseCase_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockAttachments := mocks.NewMockAttachmentRepository(ctrl)
+	mockReactions := mocks.NewMockReactionRepository(ctrl)
+	mockTags := mocks.NewMockTagRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&model.Post{ID: 5, TopicID: 2, Content: "**hi**"}, nil).Times(1)
+	mockAttachments.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(nil, nil).Times(1)
+	mockReactions.EXPECT().GetSummaries(gomock.Any(), model.TargetPost, []int{5}, 7).Return(map[int]model.ReactionSummary{5: {MyVote: 1}}, nil).Times(1)
+	mockTags.EXPECT().GetByPosts(gomock.Any(), []int{5}).Return(map[int][]string{5: {"go"}}, nil).Times(1)
+	mockTopics.EXPECT().GetPaths(gomock.Any(), []int{2}).Return(map[int][]model.TopicRef{2: {{ID: 2, Title: "Go"}}}, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mockAttachments, mockReactions, mockTags, mockTopics, markdown.NewRenderer(0), nil)
+	post, err := uc.GetByID(context.Background(), 5, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<p><strong>hi</strong></p>\n", post.ContentHTML)
+	assert.Equal(t, []string{"go"}, post.Tags)
+	assert.Equal(t, []model.TopicRef{{ID: 2, Title: "Go"}}, post.Breadcrumbs)
+	assert.Equal(t, 1, post.MyVote)
+}
+
+func TestPostUseCase_GetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 42).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl), mocks.NewMockReactionRepository(ctrl),
+		mocks.NewMockTagRepository(ctrl), mocks.NewMockTopicRepository(ctrl), markdown.NewRenderer(0), nil)
+	_, err := uc.GetByID(context.Background(), 42, 0)
+
+	assert.ErrorIs(t, err, usecase.ErrPostNotFound)
+}
+
+func TestPostUseCase_Move(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 4).Return(&model.Topic{ID: 4, Locked: true}, nil).Times(1)
+	mockRepo.EXPECT().Move(gomock.Any(), []int{1, 2, 3}, 4).Return(2, nil).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+	n, err := uc.Move(context.Background(), []int{1, 2, 3}, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestPostUseCase_Move_TargetTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockPostRepository(ctrl)
+	mockTopics := mocks.NewMockTopicRepository(ctrl)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 2).Return(&model.Topic{ID: 2, Archived: true}, nil).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 3).Return(nil, repository.ErrNotFound).Times(1)
+	mockTopics.EXPECT().GetByID(gomock.Any(), 4).Return(&model.Topic{ID: 4}, nil).Times(1)
+	mockRepo.EXPECT().Move(gomock.Any(), []int{1}, 4).Return(0, fmt.Errorf("%w: posts_topic_id_fkey", repository.ErrForeignKey)).Times(1)
+
+	uc := NewPostUseCase(mockRepo, mocks.NewMockMentionRepository(ctrl), mocks.NewMockAttachmentRepository(ctrl),
+		mocks.NewMockReactionRepository(ctrl), mocks.NewMockTagRepository(ctrl), mockTopics, markdown.NewRenderer(0), nil)
+
+	_, err := uc.Move(context.Background(), []int{1}, 2)
+	assert.ErrorIs(t, err, usecase.ErrTopicArchived)
+	_, err = uc.Move(context.Background(), []int{1}, 3)
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+	_, err = uc.Move(context.Background(), []int{1}, 4)
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
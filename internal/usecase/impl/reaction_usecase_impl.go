@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/tracing"
+	"golangforum/internal/usecase"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ReactionUseCase struct {
+	Repo repository.ReactionRepository
+}
+
+func NewReactionUseCase(repo repository.ReactionRepository) *ReactionUseCase {
+	log.Info().Msg("ReactionUseCase initialized")
+	return &ReactionUseCase{Repo: repo}
+}
+
+func (uc *ReactionUseCase) Vote(ctx context.Context, userID int, req model.VoteRequest) (*model.VoteResult, error) {
+	ctx, span := tracing.Start(ctx, "ReactionUseCase.Vote")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("userID", userID).
+		Str("targetType", req.TargetType).
+		Int("targetID", req.TargetID).
+		Int("value", req.Value).
+		Msg("Voting")
+	if userID <= 0 {
+		return nil, usecase.ErrInvalidUserID
+	}
+	if !validTarget(req.TargetType, req.TargetID) || (req.Value != 1 && req.Value != -1) {
+		log.Ctx(ctx).Warn().Msg("Vote validation failed")
+		return nil, usecase.ErrInvalidVote
+	}
+	res, err := uc.Repo.Vote(ctx, req.TargetType, req.TargetID, userID, req.Value)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to save vote")
+		return nil, targetError(req.TargetType, err)
+	}
+	log.Ctx(ctx).Info().
+		Str("targetType", req.TargetType).
+		Int("targetID", req.TargetID).
+		Int("score", res.Score).
+		Msg("Vote saved")
+	return res, nil
+}
+
+func (uc *ReactionUseCase) ToggleReaction(ctx context.Context, userID int, req model.ReactionRequest) (*model.ReactionCount, error) {
+	ctx, span := tracing.Start(ctx, "ReactionUseCase.ToggleReaction")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("userID", userID).
+		Str("targetType", req.TargetType).
+		Int("targetID", req.TargetID).
+		Str("emoji", req.Emoji).
+		Msg("Toggling reaction")
+	if userID <= 0 {
+		return nil, usecase.ErrInvalidUserID
+	}
+	if !validTarget(req.TargetType, req.TargetID) || !model.AllowedReactions[req.Emoji] {
+		log.Ctx(ctx).Warn().Msg("Reaction validation failed")
+		return nil, usecase.ErrInvalidReaction
+	}
+	res, err := uc.Repo.ToggleReaction(ctx, req.TargetType, req.TargetID, userID, req.Emoji)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to toggle reaction")
+		return nil, targetError(req.TargetType, err)
+	}
+	log.Ctx(ctx).Info().
+		Str("targetType", req.TargetType).
+		Int("targetID", req.TargetID).
+		Bool("reacted", res.Reacted).
+		Msg("Reaction toggled")
+	return res, nil
+}
+
+func validTarget(targetType string, targetID int) bool {
+	return (targetType == model.TargetPost || targetType == model.TargetComment) && targetID > 0
+}
+
+// targetError переводит отсутствие объекта голосования в ошибку соответствующего типа
+func targetError(targetType string, err error) error {
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if targetType == model.TargetComment {
+		return usecase.ErrCommentNotFound
+	}
+	return usecase.ErrPostNotFound
+}
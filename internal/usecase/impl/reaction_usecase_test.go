@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func TestReactionUseCase_Vote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReactionRepository(ctrl)
+	expected := &model.VoteResult{Score: 1, Upvotes: 1, MyVote: 1}
+	mockRepo.EXPECT().Vote(gomock.Any(), model.TargetPost, 5, 3, 1).Return(expected, nil).Times(1)
+
+	uc := NewReactionUseCase(mockRepo)
+	res, err := uc.Vote(context.Background(), 3, model.VoteRequest{TargetType: model.TargetPost, TargetID: 5, Value: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestReactionUseCase_Vote_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewReactionUseCase(mocks.NewMockReactionRepository(ctrl))
+	for _, req := range []model.VoteRequest{
+		{TargetType: model.TargetPost, TargetID: 5, Value: 2},
+		{TargetType: model.TargetPost, TargetID: 5, Value: 0},
+		{TargetType: "topic", TargetID: 5, Value: 1},
+		{TargetType: model.TargetComment, TargetID: 0, Value: -1},
+	} {
+		_, err := uc.Vote(context.Background(), 3, req)
+		assert.ErrorIs(t, err, usecase.ErrInvalidVote)
+	}
+}
+
+func TestReactionUseCase_Vote_CommentNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReactionRepository(ctrl)
+	mockRepo.EXPECT().Vote(gomock.Any(), model.TargetComment, 9, 3, -1).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewReactionUseCase(mockRepo)
+	_, err := uc.Vote(context.Background(), 3, model.VoteRequest{TargetType: model.TargetComment, TargetID: 9, Value: -1})
+
+	assert.ErrorIs(t, err, usecase.ErrCommentNotFound)
+}
+
+func TestReactionUseCase_ToggleReaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReactionRepository(ctrl)
+	expected := &model.ReactionCount{Emoji: "🎉", Count: 2, Reacted: true}
+	mockRepo.EXPECT().ToggleReaction(gomock.Any(), model.TargetComment, 4, 3, "🎉").Return(expected, nil).Times(1)
+
+	uc := NewReactionUseCase(mockRepo)
+	res, err := uc.ToggleReaction(context.Background(), 3, model.ReactionRequest{TargetType: model.TargetComment, TargetID: 4, Emoji: "🎉"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestReactionUseCase_ToggleReaction_UnknownEmoji(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewReactionUseCase(mocks.NewMockReactionRepository(ctrl))
+	_, err := uc.ToggleReaction(context.Background(), 3, model.ReactionRequest{TargetType: model.TargetPost, TargetID: 4, Emoji: "<script>"})
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidReaction)
+}
+
+func TestReactionUseCase_ToggleReaction_Anonymous(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewReactionUseCase(mocks.NewMockReactionRepository(ctrl))
+	_, err := uc.ToggleReaction(context.Background(), 0, model.ReactionRequest{TargetType: model.TargetPost, TargetID: 4, Emoji: "👍"})
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidUserID)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/tag"
+	"golangforum/internal/tracing"
+	"golangforum/internal/usecase"
+
+	"github.com/rs/zerolog/log"
+)
+
+type TagUseCase struct {
+	Repo repository.TagRepository
+}
+
+func NewTagUseCase(repo repository.TagRepository) *TagUseCase {
+	log.Info().Msg("TagUseCase initialized")
+	return &TagUseCase{Repo: repo}
+}
+
+// List возвращает теги для автодополнения; префикс нормализуется так же, как имена тегов
+func (uc *TagUseCase) List(ctx context.Context, prefix string, limit int) ([]model.Tag, error) {
+	ctx, span := tracing.Start(ctx, "TagUseCase.List")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Str("prefix", prefix).
+		Int("limit", limit).
+		Msg("Fetching tags")
+	normalized, err := tag.NormalizeOne(prefix)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("prefix", prefix).Msg("Tag prefix validation failed")
+		return nil, usecase.ErrInvalidTag
+	}
+	tags, err := uc.Repo.List(ctx, normalized, limit)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch tags")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Str("prefix", normalized).
+		Int("count", len(tags)).
+		Msg("Tags fetched")
+	return tags, nil
+}
+
+func (uc *TagUseCase) Rename(ctx context.Context, id int, name string) (*model.Tag, error) {
+	ctx, span := tracing.Start(ctx, "TagUseCase.Rename")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("id", id).
+		Str("name", name).
+		Msg("Renaming tag")
+	normalized, err := tag.NormalizeOne(name)
+	if err != nil || normalized == "" {
+		log.Ctx(ctx).Warn().Err(err).Str("name", name).Msg("Tag name validation failed")
+		return nil, usecase.ErrInvalidTag
+	}
+	t, err := uc.Repo.Rename(ctx, id, normalized)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to rename tag")
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, usecase.ErrTagNotFound
+		case errors.Is(err, repository.ErrConflict):
+			return nil, usecase.ErrTagExists
+		}
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", id).
+		Str("name", t.Name).
+		Msg("Tag renamed")
+	return t, nil
+}
+
+func (uc *TagUseCase) Merge(ctx context.Context, sourceID, targetID int) (*model.Tag, error) {
+	ctx, span := tracing.Start(ctx, "TagUseCase.Merge")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("sourceID", sourceID).
+		Int("targetID", targetID).
+		Msg("Merging tags")
+	if sourceID == targetID {
+		log.Ctx(ctx).Warn().Msg("Tag cannot be merged into itself")
+		return nil, usecase.ErrInvalidTag
+	}
+	t, err := uc.Repo.Merge(ctx, sourceID, targetID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to merge tags")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, usecase.ErrTagNotFound
+		}
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("sourceID", sourceID).
+		Str("target", t.Name).
+		Int("postCount", t.PostCount).
+		Msg("Tags merged")
+	return t, nil
+}
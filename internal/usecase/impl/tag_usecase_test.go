@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func TestTagUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTagRepository(ctrl)
+	tags := []model.Tag{{ID: 1, Name: "go-modules", PostCount: 3}}
+	mockRepo.EXPECT().List(gomock.Any(), "go-mod", 10).Return(tags, nil).Times(1)
+
+	uc := NewTagUseCase(mockRepo)
+	result, err := uc.List(context.Background(), " Go Mod", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tags, result)
+}
+
+func TestTagUseCase_List_InvalidPrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewTagUseCase(mocks.NewMockTagRepository(ctrl))
+	_, err := uc.List(context.Background(), "50%", 10)
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidTag)
+}
+
+func TestTagUseCase_Rename(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTagRepository(ctrl)
+	mockRepo.EXPECT().Rename(gomock.Any(), 1, "golang").Return(&model.Tag{ID: 1, Name: "golang", PostCount: 2}, nil).Times(1)
+
+	uc := NewTagUseCase(mockRepo)
+	result, err := uc.Rename(context.Background(), 1, "GoLang")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "golang", result.Name)
+}
+
+func TestTagUseCase_Rename_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTagRepository(ctrl)
+	mockRepo.EXPECT().Rename(gomock.Any(), 1, "go").Return(nil, repository.ErrConflict).Times(1)
+	mockRepo.EXPECT().Rename(gomock.Any(), 2, "go").Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewTagUseCase(mockRepo)
+	_, err := uc.Rename(context.Background(), 1, "go")
+	assert.ErrorIs(t, err, usecase.ErrTagExists)
+	_, err = uc.Rename(context.Background(), 2, "go")
+	assert.ErrorIs(t, err, usecase.ErrTagNotFound)
+	_, err = uc.Rename(context.Background(), 3, "  ")
+	assert.ErrorIs(t, err, usecase.ErrInvalidTag)
+}
+
+func TestTagUseCase_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTagRepository(ctrl)
+	mockRepo.EXPECT().Merge(gomock.Any(), 2, 1).Return(&model.Tag{ID: 1, Name: "go", PostCount: 7}, nil).Times(1)
+
+	uc := NewTagUseCase(mockRepo)
+	result, err := uc.Merge(context.Background(), 2, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result.PostCount)
+
+	_, err = uc.Merge(context.Background(), 1, 1)
+	assert.ErrorIs(t, err, usecase.ErrInvalidTag)
+}
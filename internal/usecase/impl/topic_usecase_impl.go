@@ -1,7 +1,10 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"golangforum/internal/repository"
+	"golangforum/internal/tracing"
 	"golangforum/internal/usecase"
 	"time"
 
@@ -18,57 +21,320 @@ func NewTopicUseCase(repo repository.TopicRepository) *TopicUseCase {
 	return &TopicUseCase{Repo: repo}
 }
 
-func (uc *TopicUseCase) Create(title, description string) error {
-	log.Debug().
-		Str("title", title).
-		Str("description", description).
+func (uc *TopicUseCase) Create(ctx context.Context, topic *model.Topic) error {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.Create")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Str("title", topic.Title).
+		Str("description", topic.Description).
 		Msg("Creating topic")
-	t := &model.Topic{
-		Title:       title,
-		Description: description,
-		CreatedAt:   time.Now(),
+	topic.CreatedAt = time.Now()
+	if err := topic.Validate(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Topic validation failed")
+		return err
 	}
-	if err := t.Validate(); err != nil {
-		log.Warn().Err(err).Msg("Topic validation failed")
-		return usecase.ErrInvalidTopicData
+	if err := uc.checkParent(ctx, topic.ParentID); err != nil {
+		return err
 	}
-	if err := uc.Repo.Create(t); err != nil {
-		log.Error().Err(err).Msg("Failed to save topic")
+	if err := uc.Repo.Create(ctx, topic); err != nil {
+		if errors.Is(err, repository.ErrForeignKey) {
+			log.Ctx(ctx).Warn().Msg("Parent topic disappeared before save")
+			return usecase.ErrTopicNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to save topic")
 		return err
 	}
-	log.Info().
-		Str("title", title).
-		Time("createdAt", t.CreatedAt).
+	log.Ctx(ctx).Info().
+		Int("id", topic.ID).
+		Str("title", topic.Title).
+		Time("createdAt", topic.CreatedAt).
 		Msg("Topic created")
 	return nil
 }
 
-func (uc *TopicUseCase) GetAll() ([]model.Topic, error) {
-	log.Debug().Msg("Fetching all topics")
-	topics, err := uc.Repo.GetAll()
+// GetAll возвращает темы в порядке отображения. Без includeArchived архивные темы скрываются вместе с подтемами
+func (uc *TopicUseCase) GetAll(ctx context.Context, includeArchived bool) ([]model.Topic, error) {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.GetAll")
+	defer span.End()
+	log.Ctx(ctx).Debug().Bool("includeArchived", includeArchived).Msg("Fetching all topics")
+	topics, err := uc.Repo.GetAll(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to fetch topics")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch topics")
 		return nil, err
 	}
-	log.Info().
+	if !includeArchived {
+		topics = withoutArchived(topics)
+	}
+	log.Ctx(ctx).Info().
 		Int("count", len(topics)).
 		Msg("Topics fetched")
 	return topics, nil
 }
 
-func (uc *TopicUseCase) Delete(id int) error {
-	log.Debug().
+// GetByID возвращает тему со статистикой; удаленные темы не возвращаются
+func (uc *TopicUseCase) GetByID(ctx context.Context, id int) (*model.Topic, error) {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.GetByID")
+	defer span.End()
+	log.Ctx(ctx).Debug().Int("id", id).Msg("Fetching topic")
+	topic, err := uc.Repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Int("id", id).Msg("Topic not found")
+			return nil, usecase.ErrTopicNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch topic")
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", id).
+		Msg("Topic fetched")
+	return topic, nil
+}
+
+// GetTree возвращает темы верхнего уровня с вложенными подтемами. Без includeArchived архивные темы скрываются вместе с подтемами
+func (uc *TopicUseCase) GetTree(ctx context.Context, includeArchived bool) ([]model.Topic, error) {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.GetTree")
+	defer span.End()
+	log.Ctx(ctx).Debug().Bool("includeArchived", includeArchived).Msg("Fetching topic tree")
+	topics, err := uc.Repo.GetAll(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch topics")
+		return nil, err
+	}
+	if !includeArchived {
+		topics = withoutArchived(topics)
+	}
+	tree := buildTopicTree(topics)
+	log.Ctx(ctx).Info().
+		Int("count", len(topics)).
+		Int("roots", len(tree)).
+		Msg("Topic tree fetched")
+	return tree, nil
+}
+
+// Update изменяет у темы только переданные в req поля: заголовок, описание и порядок отображения
+func (uc *TopicUseCase) Update(ctx context.Context, req *model.TopicUpdateRequest) (*model.Topic, error) {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.Update")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("id", req.ID).
+		Msg("Updating topic")
+	existing, err := uc.Repo.GetByID(ctx, req.ID)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch topic")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, usecase.ErrTopicNotFound
+		}
+		return nil, err
+	}
+	if req.Title != nil {
+		existing.Title = *req.Title
+	}
+	if req.Description != nil {
+		existing.Description = *req.Description
+	}
+	if req.Position != nil {
+		existing.Position = *req.Position
+	}
+	if err := existing.Validate(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("Topic validation failed")
+		return nil, err
+	}
+	if err := uc.Repo.Update(ctx, existing); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to update topic")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, usecase.ErrTopicNotFound
+		}
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", existing.ID).
+		Int("position", existing.Position).
+		Msg("Topic updated")
+	return existing, nil
+}
+
+// Move переносит тему под другую родительскую тему; parentID = nil переносит ее на верхний уровень.
+// Перенос темы в саму себя или в собственное поддерево отклоняется с ErrTopicCycle
+func (uc *TopicUseCase) Move(ctx context.Context, id int, parentID *int) error {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.Move")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("id", id).
+		Interface("parentID", parentID).
+		Msg("Moving topic")
+	if parentID != nil && *parentID == id {
+		log.Ctx(ctx).Warn().Msg("Topic move into itself rejected")
+		return usecase.ErrTopicCycle
+	}
+	if err := uc.checkParent(ctx, parentID); err != nil {
+		return err
+	}
+	if err := uc.Repo.Move(ctx, id, parentID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrConflict):
+			log.Ctx(ctx).Warn().Msg("Topic move into its own subtree rejected")
+			return usecase.ErrTopicCycle
+		case errors.Is(err, repository.ErrNotFound):
+			log.Ctx(ctx).Warn().Msg("Topic to move not found")
+			return usecase.ErrTopicNotFound
+		case errors.Is(err, repository.ErrForeignKey):
+			log.Ctx(ctx).Warn().Msg("New parent topic not found")
+			return usecase.ErrTopicNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to move topic")
+		return err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", id).
+		Interface("parentID", parentID).
+		Msg("Topic moved")
+	return nil
+}
+
+// SetState помещает тему в архив или закрывает ее для новых постов; nil оставляет признак без изменений.
+// Возвращает тему после изменения
+func (uc *TopicUseCase) SetState(ctx context.Context, id int, archived, locked *bool) (*model.Topic, error) {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.SetState")
+	defer span.End()
+	log.Ctx(ctx).Debug().
 		Int("id", id).
+		Interface("archived", archived).
+		Interface("locked", locked).
+		Msg("Changing topic state")
+	if err := uc.Repo.SetState(ctx, id, archived, locked); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Msg("Topic not found")
+			return nil, usecase.ErrTopicNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to change topic state")
+		return nil, err
+	}
+	topic, err := uc.Repo.GetByID(ctx, id)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch topic")
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, usecase.ErrTopicNotFound
+		}
+		return nil, err
+	}
+	log.Ctx(ctx).Info().
+		Int("id", id).
+		Bool("archived", topic.Archived).
+		Bool("locked", topic.Locked).
+		Msg("Topic state changed")
+	return topic, nil
+}
+
+// RecomputeStats заново вычисляет счетчики комментариев и время последней активности всех постов,
+// а также счетчики постов и комментариев и последний пост всех тем. Возвращает количество исправленных тем и постов
+func (uc *TopicUseCase) RecomputeStats(ctx context.Context) (model.StatsRecount, error) {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.RecomputeStats")
+	defer span.End()
+	log.Ctx(ctx).Debug().Msg("Recomputing topic statistics")
+	n, err := uc.Repo.RecomputeStats(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to recompute topic statistics")
+		return model.StatsRecount{}, err
+	}
+	log.Ctx(ctx).Info().
+		Int("topics", n.Topics).
+		Int("posts", n.Posts).
+		Msg("Topic statistics recomputed")
+	return n, nil
+}
+
+// Delete переносит тему вместе с подтемами, постами и комментариями в корзину; userID — удаливший пользователь (0 — неизвестен)
+func (uc *TopicUseCase) Delete(ctx context.Context, id, userID int) error {
+	ctx, span := tracing.Start(ctx, "TopicUseCase.Delete")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Int("id", id).
+		Int("userID", userID).
 		Msg("Deleting topic")
-	if err := uc.Repo.Delete(id); err != nil {
-		log.Error().Err(err).Msg("Failed to delete topic")
-		if err.Error() == "not found" {
+	if err := uc.Repo.Delete(ctx, id, userID); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to delete topic")
+		if errors.Is(err, repository.ErrNotFound) {
 			return usecase.ErrTopicNotFound
 		}
 		return err
 	}
-	log.Info().
+	log.Ctx(ctx).Info().
 		Int("id", id).
 		Msg("Topic deleted")
 	return nil
 }
+
+// checkParent проверяет, что родительская тема существует
+func (uc *TopicUseCase) checkParent(ctx context.Context, parentID *int) error {
+	if parentID == nil {
+		return nil
+	}
+	if _, err := uc.Repo.GetByID(ctx, *parentID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			log.Ctx(ctx).Warn().Int("parentID", *parentID).Msg("Parent topic not found")
+			return usecase.ErrTopicNotFound
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch parent topic")
+		return err
+	}
+	return nil
+}
+
+// withoutArchived убирает из списка архивные темы и все темы, у которых архивный предок
+func withoutArchived(topics []model.Topic) []model.Topic {
+	byID := make(map[int]*model.Topic, len(topics))
+	for i := range topics {
+		byID[topics[i].ID] = &topics[i]
+	}
+	hidden := func(t *model.Topic) bool {
+		for depth := 0; t != nil && depth < maxTopicTreeDepth; depth++ {
+			if t.Archived {
+				return true
+			}
+			if t.ParentID == nil {
+				return false
+			}
+			t = byID[*t.ParentID]
+		}
+		return false
+	}
+	res := make([]model.Topic, 0, len(topics))
+	for i := range topics {
+		if !hidden(&topics[i]) {
+			res = append(res, topics[i])
+		}
+	}
+	return res
+}
+
+// buildTopicTree собирает дерево из плоского списка тем, сохраняя их порядок внутри каждого уровня.
+// Темы, родитель которых отсутствует в списке, попадают на верхний уровень
+func buildTopicTree(topics []model.Topic) []model.Topic {
+	byParent := make(map[int][]model.Topic)
+	known := make(map[int]bool, len(topics))
+	for _, t := range topics {
+		known[t.ID] = true
+	}
+	var roots []model.Topic
+	for _, t := range topics {
+		if t.ParentID == nil || !known[*t.ParentID] {
+			roots = append(roots, t)
+			continue
+		}
+		byParent[*t.ParentID] = append(byParent[*t.ParentID], t)
+	}
+	var attach func(nodes []model.Topic, depth int) []model.Topic
+	attach = func(nodes []model.Topic, depth int) []model.Topic {
+		for i := range nodes {
+			if depth < maxTopicTreeDepth {
+				nodes[i].Children = attach(byParent[nodes[i].ID], depth+1)
+			}
+		}
+		return nodes
+	}
+	return attach(roots, 0)
+}
+
+// maxTopicTreeDepth ограничивает глубину дерева при сборке на случай цикла в данных
+const maxTopicTreeDepth = 64
@@ -0,0 +1,371 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func TestTopicUseCase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Create(context.Background(), &model.Topic{Title: "Valid Topic", Description: "This is a valid topic"})
+
+	assert.NoError(t, err)
+}
+
+func TestTopicUseCase_Create_WithParent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	parentID := 1
+	topic := &model.Topic{ParentID: &parentID, Title: "Sub-forum", Description: "Nested topic"}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockRepo.EXPECT().Create(gomock.Any(), topic).Return(nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Create(context.Background(), topic)
+
+	assert.NoError(t, err)
+	assert.NotZero(t, topic.CreatedAt)
+}
+
+func TestTopicUseCase_Create_ParentNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	parentID := 42
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 42).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Create(context.Background(), &model.Topic{ParentID: &parentID, Title: "Sub-forum", Description: "Nested topic"})
+
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
+
+func TestTopicUseCase_Create_ValidationError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Create(context.Background(), &model.Topic{Description: "This is a topic without a title"})
+
+	assert.Error(t, err)
+}
+
+func TestTopicUseCase_GetAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	topics := []model.Topic{
+		{ID: 1, Title: "First Topic", Description: "This is the first topic", CreatedAt: time.Now()},
+		{ID: 2, Title: "Second Topic", Description: "This is the second topic", CreatedAt: time.Now()},
+	}
+
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return(topics, nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	result, err := uc.GetAll(context.Background(), false)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, topics, result)
+}
+
+func TestTopicUseCase_GetAll_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New("database error")).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	result, err := uc.GetAll(context.Background(), false)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestTopicUseCase_GetTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	one, two := 1, 2
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return([]model.Topic{
+		{ID: 1, Title: "Backend"},
+		{ID: 3, ParentID: &one, Title: "Go"},
+		{ID: 2, Title: "Frontend"},
+		{ID: 4, ParentID: &two, Title: "React"},
+		{ID: 5, ParentID: &one, Title: "Postgres"},
+		{ID: 6, ParentID: &two, Title: "CSS"},
+	}, nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	tree, err := uc.GetTree(context.Background(), false)
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "Backend", tree[0].Title)
+	assert.Equal(t, "Frontend", tree[1].Title)
+	assert.Equal(t, []string{"Go", "Postgres"}, []string{tree[0].Children[0].Title, tree[0].Children[1].Title})
+	assert.Equal(t, []string{"React", "CSS"}, []string{tree[1].Children[0].Title, tree[1].Children[1].Title})
+	assert.Empty(t, tree[0].Children[0].Children)
+}
+
+func TestTopicUseCase_GetAll_HidesArchived(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	one, three := 1, 3
+	topics := []model.Topic{
+		{ID: 1, Title: "Backend"},
+		{ID: 2, ParentID: &one, Title: "Go"},
+		{ID: 3, ParentID: &one, Title: "Legacy", Archived: true},
+		{ID: 4, ParentID: &three, Title: "Perl"},
+	}
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return(topics, nil).Times(2)
+
+	uc := NewTopicUseCase(mockRepo)
+
+	visible, err := uc.GetAll(context.Background(), false)
+	assert.NoError(t, err)
+	assert.Len(t, visible, 2)
+	assert.Equal(t, "Go", visible[1].Title)
+
+	all, err := uc.GetAll(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Len(t, all, 4)
+}
+
+func TestTopicUseCase_SetState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	locked := true
+	mockRepo.EXPECT().SetState(gomock.Any(), 1, nil, &locked).Return(nil).Times(1)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1, Locked: true}, nil).Times(1)
+	mockRepo.EXPECT().SetState(gomock.Any(), 2, nil, &locked).Return(repository.ErrNotFound).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+
+	topic, err := uc.SetState(context.Background(), 1, nil, &locked)
+	assert.NoError(t, err)
+	assert.True(t, topic.Locked)
+
+	_, err = uc.SetState(context.Background(), 2, nil, &locked)
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
+
+func TestTopicUseCase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	parentID := 1
+	existing := &model.Topic{ID: 2, ParentID: &parentID, Title: "Old", Description: "Old description"}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 2).Return(existing, nil).Times(1)
+	mockRepo.EXPECT().Update(gomock.Any(), existing).Return(nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	title, description, position := "New", "New description", 3
+	topic, err := uc.Update(context.Background(), &model.TopicUpdateRequest{ID: 2, Title: &title, Description: &description, Position: &position})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "New", topic.Title)
+	assert.Equal(t, "New description", topic.Description)
+	assert.Equal(t, 3, topic.Position)
+	assert.Equal(t, &parentID, topic.ParentID)
+}
+
+func TestTopicUseCase_Update_KeepsOmittedFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	existing := &model.Topic{ID: 2, Title: "Old", Description: "Old description", Position: 4}
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 2).Return(existing, nil).Times(1)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, topic *model.Topic) error {
+		assert.Equal(t, "Renamed", topic.Title)
+		assert.Equal(t, "Old description", topic.Description)
+		assert.Equal(t, 4, topic.Position)
+		return nil
+	}).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	title := "Renamed"
+	_, err := uc.Update(context.Background(), &model.TopicUpdateRequest{ID: 2, Title: &title})
+
+	assert.NoError(t, err)
+}
+
+func TestTopicUseCase_Update_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 2).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	_, err := uc.Update(context.Background(), &model.TopicUpdateRequest{ID: 2})
+
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
+
+func TestTopicUseCase_Move(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	parentID := 1
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1}, nil).Times(1)
+	mockRepo.EXPECT().Move(gomock.Any(), 2, &parentID).Return(nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Move(context.Background(), 2, &parentID)
+
+	assert.NoError(t, err)
+}
+
+func TestTopicUseCase_Move_ToRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().Move(gomock.Any(), 2, nil).Return(nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Move(context.Background(), 2, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestTopicUseCase_Move_Cycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	self, child := 2, 5
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&model.Topic{ID: 5}, nil).Times(1)
+	mockRepo.EXPECT().Move(gomock.Any(), 2, &child).Return(repository.ErrConflict).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+
+	assert.ErrorIs(t, uc.Move(context.Background(), 2, &self), usecase.ErrTopicCycle)
+	assert.ErrorIs(t, uc.Move(context.Background(), 2, &child), usecase.ErrTopicCycle)
+}
+
+func TestTopicUseCase_Move_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().Move(gomock.Any(), 7, nil).Return(repository.ErrNotFound).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Move(context.Background(), 7, nil)
+
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
+
+func TestTopicUseCase_RecomputeStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+	mockRepo.EXPECT().RecomputeStats(gomock.Any()).Return(model.StatsRecount{Topics: 3, Posts: 5}, nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	n, err := uc.RecomputeStats(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatsRecount{Topics: 3, Posts: 5}, n)
+}
+
+func TestTopicUseCase_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, 7).Return(nil).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Delete(context.Background(), 1, 7)
+
+	assert.NoError(t, err)
+}
+
+func TestTopicUseCase_Delete_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, 7).Return(errors.New("delete error")).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Delete(context.Background(), 1, 7)
+
+	assert.Error(t, err)
+}
+
+func TestTopicUseCase_Delete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().Delete(gomock.Any(), 42, 7).Return(repository.ErrNotFound).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	err := uc.Delete(context.Background(), 42, 7)
+
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
+
+func TestTopicUseCase_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTopicRepository(ctrl)
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&model.Topic{ID: 1, Title: "Go"}, nil).Times(1)
+	mockRepo.EXPECT().GetByID(gomock.Any(), 42).Return(nil, repository.ErrNotFound).Times(1)
+
+	uc := NewTopicUseCase(mockRepo)
+	topic, err := uc.GetByID(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", topic.Title)
+
+	_, err = uc.GetByID(context.Background(), 42)
+	assert.ErrorIs(t, err, usecase.ErrTopicNotFound)
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/tracing"
+	"golangforum/internal/usecase"
+
+	"github.com/rs/zerolog/log"
+)
+
+type TrashUseCase struct {
+	Repo repository.TrashRepository
+}
+
+func NewTrashUseCase(repo repository.TrashRepository) *TrashUseCase {
+	log.Info().Msg("TrashUseCase initialized")
+	return &TrashUseCase{Repo: repo}
+}
+
+// List возвращает страницу удаленных объектов типа itemType и их общее количество
+func (uc *TrashUseCase) List(ctx context.Context, itemType string, limit, offset int) ([]model.TrashItem, int, error) {
+	ctx, span := tracing.Start(ctx, "TrashUseCase.List")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Str("type", itemType).
+		Int("limit", limit).
+		Int("offset", offset).
+		Msg("Fetching trash")
+	if !model.ValidTrashType(itemType) {
+		log.Ctx(ctx).Warn().Str("type", itemType).Msg("Unknown trash item type")
+		return nil, 0, usecase.ErrInvalidTrashType
+	}
+	items, err := uc.Repo.List(ctx, itemType, limit, offset)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to fetch trash")
+		return nil, 0, err
+	}
+	total, err := uc.Repo.Count(ctx, itemType)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count trash")
+		return nil, 0, err
+	}
+	log.Ctx(ctx).Info().
+		Str("type", itemType).
+		Int("count", len(items)).
+		Int("total", total).
+		Msg("Trash fetched")
+	return items, total, nil
+}
+
+// Restore восстанавливает объект из корзины вместе с объектами, удаленными вместе с ним
+func (uc *TrashUseCase) Restore(ctx context.Context, itemType string, id int) error {
+	ctx, span := tracing.Start(ctx, "TrashUseCase.Restore")
+	defer span.End()
+	log.Ctx(ctx).Debug().
+		Str("type", itemType).
+		Int("id", id).
+		Msg("Restoring from trash")
+	if !model.ValidTrashType(itemType) {
+		log.Ctx(ctx).Warn().Str("type", itemType).Msg("Unknown trash item type")
+		return usecase.ErrInvalidTrashType
+	}
+	if err := uc.Repo.Restore(ctx, itemType, id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			log.Ctx(ctx).Warn().Msg("Trash item not found")
+			return usecase.ErrTrashNotFound
+		case errors.Is(err, repository.ErrConflict):
+			log.Ctx(ctx).Warn().Msg("Restore rejected: parent is deleted")
+			return usecase.ErrParentDeleted
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to restore from trash")
+		return err
+	}
+	log.Ctx(ctx).Info().
+		Str("type", itemType).
+		Int("id", id).
+		Msg("Restored from trash")
+	return nil
+}
+
+// Purge окончательно стирает объекты, пролежавшие в корзине дольше olderThan
+func (uc *TrashUseCase) Purge(ctx context.Context, olderThan time.Duration) (int, error) {
+	ctx, span := tracing.Start(ctx, "TrashUseCase.Purge")
+	defer span.End()
+	log.Ctx(ctx).Debug().Dur("olderThan", olderThan).Msg("Purging trash")
+	n, err := uc.Repo.Purge(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to purge trash")
+		return 0, err
+	}
+	log.Ctx(ctx).Info().Int("count", n).Msg("Trash purged")
+	return n, nil
+}
+
+// CountPurgeable возвращает количество объектов, которые Purge с тем же olderThan сотрет окончательно
+func (uc *TrashUseCase) CountPurgeable(ctx context.Context, olderThan time.Duration) (int, error) {
+	ctx, span := tracing.Start(ctx, "TrashUseCase.CountPurgeable")
+	defer span.End()
+	n, err := uc.Repo.CountPurgeable(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to count purgeable trash")
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golangforum/internal/model"
+	"golangforum/internal/repository"
+	"golangforum/internal/repository/mocks"
+	"golangforum/internal/usecase"
+)
+
+func TestTrashUseCase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTrashRepository(ctrl)
+	items := []model.TrashItem{{Type: model.TrashPost, ID: 5, Title: "Deleted"}}
+
+	mockRepo.EXPECT().List(gomock.Any(), model.TrashPost, 20, 0).Return(items, nil).Times(1)
+	mockRepo.EXPECT().Count(gomock.Any(), model.TrashPost).Return(1, nil).Times(1)
+
+	uc := NewTrashUseCase(mockRepo)
+	got, total, err := uc.List(context.Background(), model.TrashPost, 20, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, items, got)
+	assert.Equal(t, 1, total)
+}
+
+func TestTrashUseCase_List_InvalidType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTrashRepository(ctrl)
+
+	uc := NewTrashUseCase(mockRepo)
+	_, _, err := uc.List(context.Background(), "user", 20, 0)
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidTrashType)
+}
+
+func TestTrashUseCase_Restore(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		want    error
+	}{
+		{name: "успех", repoErr: nil, want: nil},
+		{name: "нет в корзине", repoErr: repository.ErrNotFound, want: usecase.ErrTrashNotFound},
+		{name: "родитель удален", repoErr: repository.ErrConflict, want: usecase.ErrParentDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockTrashRepository(ctrl)
+			mockRepo.EXPECT().Restore(gomock.Any(), model.TrashComment, 3).Return(tt.repoErr).Times(1)
+
+			uc := NewTrashUseCase(mockRepo)
+			err := uc.Restore(context.Background(), model.TrashComment, 3)
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrashUseCase_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTrashRepository(ctrl)
+	retention := 30 * 24 * time.Hour
+
+	mockRepo.EXPECT().Purge(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		assert.WithinDuration(t, time.Now().Add(-retention), before, time.Minute)
+		return 4, nil
+	}).Times(1)
+
+	uc := NewTrashUseCase(mockRepo)
+	n, err := uc.Purge(context.Background(), retention)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+}
+
+func TestTrashUseCase_CountPurgeable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTrashRepository(ctrl)
+	retention := 30 * 24 * time.Hour
+
+	mockRepo.EXPECT().CountPurgeable(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		assert.WithinDuration(t, time.Now().Add(-retention), before, time.Minute)
+		return 7, nil
+	}).Times(1)
+
+	uc := NewTrashUseCase(mockRepo)
+	n, err := uc.CountPurgeable(context.Background(), retention)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+}
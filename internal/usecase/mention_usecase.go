@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+
+	"golangforum/internal/model"
+)
+
+type MentionUseCase interface {
+	GetByUsername(ctx context.Context, username string, limit, offset int) ([]model.Mention, int, error)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/activity_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/activity_usecase.go -destination=internal/usecase/mocks/activity_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockActivityUseCase is a mock of ActivityUseCase interface.
+type MockActivityUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityUseCaseMockRecorder is the mock recorder for MockActivityUseCase.
+type MockActivityUseCaseMockRecorder struct {
+	mock *MockActivityUseCase
+}
+
+// NewMockActivityUseCase creates a new mock instance.
+func NewMockActivityUseCase(ctrl *gomock.Controller) *MockActivityUseCase {
+	mock := &MockActivityUseCase{ctrl: ctrl}
+	mock.recorder = &MockActivityUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityUseCase) EXPECT() *MockActivityUseCaseMockRecorder {
+	return m.recorder
+}
+
+// PurgeUser mocks base method.
+func (m *MockActivityUseCase) PurgeUser(ctx context.Context, userID int) (*model.UserContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", ctx, userID)
+	ret0, _ := ret[0].(*model.UserContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockActivityUseCaseMockRecorder) PurgeUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockActivityUseCase)(nil).PurgeUser), ctx, userID)
+}
+
+// Recent mocks base method.
+func (m *MockActivityUseCase) Recent(ctx context.Context, userID, limit int) ([]model.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recent", ctx, userID, limit)
+	ret0, _ := ret[0].([]model.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recent indicates an expected call of Recent.
+func (mr *MockActivityUseCaseMockRecorder) Recent(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recent", reflect.TypeOf((*MockActivityUseCase)(nil).Recent), ctx, userID, limit)
+}
+
+// UserContent mocks base method.
+func (m *MockActivityUseCase) UserContent(ctx context.Context, userID int) (*model.UserContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserContent", ctx, userID)
+	ret0, _ := ret[0].(*model.UserContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserContent indicates an expected call of UserContent.
+func (mr *MockActivityUseCaseMockRecorder) UserContent(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserContent", reflect.TypeOf((*MockActivityUseCase)(nil).UserContent), ctx, userID)
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/attachment_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/attachment_usecase.go -destination=internal/usecase/mocks/attachment_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAttachmentUseCase is a mock of AttachmentUseCase interface.
+type MockAttachmentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockAttachmentUseCaseMockRecorder is the mock recorder for MockAttachmentUseCase.
+type MockAttachmentUseCaseMockRecorder struct {
+	mock *MockAttachmentUseCase
+}
+
+// NewMockAttachmentUseCase creates a new mock instance.
+func NewMockAttachmentUseCase(ctrl *gomock.Controller) *MockAttachmentUseCase {
+	mock := &MockAttachmentUseCase{ctrl: ctrl}
+	mock.recorder = &MockAttachmentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentUseCase) EXPECT() *MockAttachmentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CollectOrphans mocks base method.
+func (m *MockAttachmentUseCase) CollectOrphans(ctx context.Context, olderThan time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectOrphans", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectOrphans indicates an expected call of CollectOrphans.
+func (mr *MockAttachmentUseCaseMockRecorder) CollectOrphans(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectOrphans", reflect.TypeOf((*MockAttachmentUseCase)(nil).CollectOrphans), ctx, olderThan)
+}
+
+// CountOrphans mocks base method.
+func (m *MockAttachmentUseCase) CountOrphans(ctx context.Context, olderThan time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOrphans", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOrphans indicates an expected call of CountOrphans.
+func (mr *MockAttachmentUseCaseMockRecorder) CountOrphans(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrphans", reflect.TypeOf((*MockAttachmentUseCase)(nil).CountOrphans), ctx, olderThan)
+}
+
+// Open mocks base method.
+func (m *MockAttachmentUseCase) Open(ctx context.Context, id int, thumbnail bool) (*model.Attachment, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, id, thumbnail)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAttachmentUseCaseMockRecorder) Open(ctx, id, thumbnail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAttachmentUseCase)(nil).Open), ctx, id, thumbnail)
+}
+
+// Upload mocks base method.
+func (m *MockAttachmentUseCase) Upload(ctx context.Context, userID int, username, filename string, r io.Reader) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, userID, username, filename, r)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAttachmentUseCaseMockRecorder) Upload(ctx, userID, username, filename, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAttachmentUseCase)(nil).Upload), ctx, userID, username, filename, r)
+}
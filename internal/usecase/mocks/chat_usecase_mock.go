@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/chat_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/chat_usecase.go -destination=internal/usecase/mocks/chat_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	websocket "github.com/gorilla/websocket"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatUseCase is a mock of ChatUseCase interface.
+type MockChatUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockChatUseCaseMockRecorder is the mock recorder for MockChatUseCase.
+type MockChatUseCaseMockRecorder struct {
+	mock *MockChatUseCase
+}
+
+// NewMockChatUseCase creates a new mock instance.
+func NewMockChatUseCase(ctrl *gomock.Controller) *MockChatUseCase {
+	mock := &MockChatUseCase{ctrl: ctrl}
+	mock.recorder = &MockChatUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatUseCase) EXPECT() *MockChatUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CountExpired mocks base method.
+func (m *MockChatUseCase) CountExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountExpired indicates an expected call of CountExpired.
+func (mr *MockChatUseCaseMockRecorder) CountExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountExpired", reflect.TypeOf((*MockChatUseCase)(nil).CountExpired), ctx)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockChatUseCase) DeleteExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockChatUseCaseMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockChatUseCase)(nil).DeleteExpired), ctx)
+}
+
+// GetAllMessages mocks base method.
+func (m *MockChatUseCase) GetAllMessages(ctx context.Context) ([]model.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllMessages", ctx)
+	ret0, _ := ret[0].([]model.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllMessages indicates an expected call of GetAllMessages.
+func (mr *MockChatUseCaseMockRecorder) GetAllMessages(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllMessages", reflect.TypeOf((*MockChatUseCase)(nil).GetAllMessages), ctx)
+}
+
+// HandleConnection mocks base method.
+func (m *MockChatUseCase) HandleConnection(ctx context.Context, conn *websocket.Conn, user string, id int, clients map[*websocket.Conn]struct{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandleConnection", ctx, conn, user, id, clients)
+}
+
+// HandleConnection indicates an expected call of HandleConnection.
+func (mr *MockChatUseCaseMockRecorder) HandleConnection(ctx, conn, user, id, clients any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleConnection", reflect.TypeOf((*MockChatUseCase)(nil).HandleConnection), ctx, conn, user, id, clients)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/comment_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/comment_usecase.go -destination=internal/usecase/mocks/comment_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommentUseCase is a mock of CommentUseCase interface.
+type MockCommentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockCommentUseCaseMockRecorder is the mock recorder for MockCommentUseCase.
+type MockCommentUseCaseMockRecorder struct {
+	mock *MockCommentUseCase
+}
+
+// NewMockCommentUseCase creates a new mock instance.
+func NewMockCommentUseCase(ctrl *gomock.Controller) *MockCommentUseCase {
+	mock := &MockCommentUseCase{ctrl: ctrl}
+	mock.recorder = &MockCommentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentUseCase) EXPECT() *MockCommentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentUseCase) Create(ctx context.Context, username string, c *model.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, username, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentUseCaseMockRecorder) Create(ctx, username, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentUseCase)(nil).Create), ctx, username, c)
+}
+
+// Delete mocks base method.
+func (m *MockCommentUseCase) Delete(ctx context.Context, id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentUseCaseMockRecorder) Delete(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentUseCase)(nil).Delete), ctx, id, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockCommentUseCase) GetByID(ctx context.Context, id, viewerID int) (*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, viewerID)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCommentUseCaseMockRecorder) GetByID(ctx, id, viewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCommentUseCase)(nil).GetByID), ctx, id, viewerID)
+}
+
+// GetByPost mocks base method.
+func (m *MockCommentUseCase) GetByPost(ctx context.Context, postID, viewerID int, sort string) ([]model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPost", ctx, postID, viewerID, sort)
+	ret0, _ := ret[0].([]model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPost indicates an expected call of GetByPost.
+func (mr *MockCommentUseCaseMockRecorder) GetByPost(ctx, postID, viewerID, sort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPost", reflect.TypeOf((*MockCommentUseCase)(nil).GetByPost), ctx, postID, viewerID, sort)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/mention_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/mention_usecase.go -destination=internal/usecase/mocks/mention_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMentionUseCase is a mock of MentionUseCase interface.
+type MockMentionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockMentionUseCaseMockRecorder is the mock recorder for MockMentionUseCase.
+type MockMentionUseCaseMockRecorder struct {
+	mock *MockMentionUseCase
+}
+
+// NewMockMentionUseCase creates a new mock instance.
+func NewMockMentionUseCase(ctrl *gomock.Controller) *MockMentionUseCase {
+	mock := &MockMentionUseCase{ctrl: ctrl}
+	mock.recorder = &MockMentionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionUseCase) EXPECT() *MockMentionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetByUsername mocks base method.
+func (m *MockMentionUseCase) GetByUsername(ctx context.Context, username string, limit, offset int) ([]model.Mention, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsername", ctx, username, limit, offset)
+	ret0, _ := ret[0].([]model.Mention)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByUsername indicates an expected call of GetByUsername.
+func (mr *MockMentionUseCaseMockRecorder) GetByUsername(ctx, username, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockMentionUseCase)(nil).GetByUsername), ctx, username, limit, offset)
+}
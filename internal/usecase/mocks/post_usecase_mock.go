@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/post_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/post_usecase.go -destination=internal/usecase/mocks/post_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPostUseCase is a mock of PostUseCase interface.
+type MockPostUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPostUseCaseMockRecorder is the mock recorder for MockPostUseCase.
+type MockPostUseCaseMockRecorder struct {
+	mock *MockPostUseCase
+}
+
+// NewMockPostUseCase creates a new mock instance.
+func NewMockPostUseCase(ctrl *gomock.Controller) *MockPostUseCase {
+	mock := &MockPostUseCase{ctrl: ctrl}
+	mock.recorder = &MockPostUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostUseCase) EXPECT() *MockPostUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPostUseCase) Create(ctx context.Context, username string, post *model.Post) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, username, post)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPostUseCaseMockRecorder) Create(ctx, username, post any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPostUseCase)(nil).Create), ctx, username, post)
+}
+
+// Delete mocks base method.
+func (m *MockPostUseCase) Delete(ctx context.Context, id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPostUseCaseMockRecorder) Delete(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPostUseCase)(nil).Delete), ctx, id, userID)
+}
+
+// GetByID mocks base method.
+func (m *MockPostUseCase) GetByID(ctx context.Context, id, viewerID int) (*model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, viewerID)
+	ret0, _ := ret[0].(*model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPostUseCaseMockRecorder) GetByID(ctx, id, viewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPostUseCase)(nil).GetByID), ctx, id, viewerID)
+}
+
+// List mocks base method.
+func (m *MockPostUseCase) List(ctx context.Context, viewerID int, f model.PostFilter) ([]model.Post, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, viewerID, f)
+	ret0, _ := ret[0].([]model.Post)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockPostUseCaseMockRecorder) List(ctx, viewerID, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPostUseCase)(nil).List), ctx, viewerID, f)
+}
+
+// Move mocks base method.
+func (m *MockPostUseCase) Move(ctx context.Context, ids []int, topicID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, ids, topicID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockPostUseCaseMockRecorder) Move(ctx, ids, topicID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockPostUseCase)(nil).Move), ctx, ids, topicID)
+}
+
+// Update mocks base method.
+func (m *MockPostUseCase) Update(ctx context.Context, userID int, req *model.PostUpdateRequest) (*model.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userID, req)
+	ret0, _ := ret[0].(*model.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPostUseCaseMockRecorder) Update(ctx, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPostUseCase)(nil).Update), ctx, userID, req)
+}
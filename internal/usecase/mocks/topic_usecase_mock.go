@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/topic_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/topic_usecase.go -destination=internal/usecase/mocks/topic_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTopicUseCase is a mock of TopicUseCase interface.
+type MockTopicUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopicUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockTopicUseCaseMockRecorder is the mock recorder for MockTopicUseCase.
+type MockTopicUseCaseMockRecorder struct {
+	mock *MockTopicUseCase
+}
+
+// NewMockTopicUseCase creates a new mock instance.
+func NewMockTopicUseCase(ctrl *gomock.Controller) *MockTopicUseCase {
+	mock := &MockTopicUseCase{ctrl: ctrl}
+	mock.recorder = &MockTopicUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopicUseCase) EXPECT() *MockTopicUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTopicUseCase) Create(ctx context.Context, topic *model.Topic) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, topic)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTopicUseCaseMockRecorder) Create(ctx, topic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTopicUseCase)(nil).Create), ctx, topic)
+}
+
+// Delete mocks base method.
+func (m *MockTopicUseCase) Delete(ctx context.Context, id, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTopicUseCaseMockRecorder) Delete(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTopicUseCase)(nil).Delete), ctx, id, userID)
+}
+
+// GetAll mocks base method.
+func (m *MockTopicUseCase) GetAll(ctx context.Context, includeArchived bool) ([]model.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, includeArchived)
+	ret0, _ := ret[0].([]model.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTopicUseCaseMockRecorder) GetAll(ctx, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTopicUseCase)(nil).GetAll), ctx, includeArchived)
+}
+
+// GetByID mocks base method.
+func (m *MockTopicUseCase) GetByID(ctx context.Context, id int) (*model.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*model.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTopicUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTopicUseCase)(nil).GetByID), ctx, id)
+}
+
+// GetTree mocks base method.
+func (m *MockTopicUseCase) GetTree(ctx context.Context, includeArchived bool) ([]model.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", ctx, includeArchived)
+	ret0, _ := ret[0].([]model.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockTopicUseCaseMockRecorder) GetTree(ctx, includeArchived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockTopicUseCase)(nil).GetTree), ctx, includeArchived)
+}
+
+// Move mocks base method.
+func (m *MockTopicUseCase) Move(ctx context.Context, id int, parentID *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, id, parentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTopicUseCaseMockRecorder) Move(ctx, id, parentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTopicUseCase)(nil).Move), ctx, id, parentID)
+}
+
+// RecomputeStats mocks base method.
+func (m *MockTopicUseCase) RecomputeStats(ctx context.Context) (model.StatsRecount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeStats", ctx)
+	ret0, _ := ret[0].(model.StatsRecount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeStats indicates an expected call of RecomputeStats.
+func (mr *MockTopicUseCaseMockRecorder) RecomputeStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeStats", reflect.TypeOf((*MockTopicUseCase)(nil).RecomputeStats), ctx)
+}
+
+// SetState mocks base method.
+func (m *MockTopicUseCase) SetState(ctx context.Context, id int, archived, locked *bool) (*model.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetState", ctx, id, archived, locked)
+	ret0, _ := ret[0].(*model.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetState indicates an expected call of SetState.
+func (mr *MockTopicUseCaseMockRecorder) SetState(ctx, id, archived, locked any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetState", reflect.TypeOf((*MockTopicUseCase)(nil).SetState), ctx, id, archived, locked)
+}
+
+// Update mocks base method.
+func (m *MockTopicUseCase) Update(ctx context.Context, req *model.TopicUpdateRequest) (*model.Topic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(*model.Topic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTopicUseCaseMockRecorder) Update(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTopicUseCase)(nil).Update), ctx, req)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/trash_usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/trash_usecase.go -destination=internal/usecase/mocks/trash_usecase_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	model "golangforum/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashUseCase is a mock of TrashUseCase interface.
+type MockTrashUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockTrashUseCaseMockRecorder is the mock recorder for MockTrashUseCase.
+type MockTrashUseCaseMockRecorder struct {
+	mock *MockTrashUseCase
+}
+
+// NewMockTrashUseCase creates a new mock instance.
+func NewMockTrashUseCase(ctrl *gomock.Controller) *MockTrashUseCase {
+	mock := &MockTrashUseCase{ctrl: ctrl}
+	mock.recorder = &MockTrashUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashUseCase) EXPECT() *MockTrashUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CountPurgeable mocks base method.
+func (m *MockTrashUseCase) CountPurgeable(ctx context.Context, olderThan time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPurgeable", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPurgeable indicates an expected call of CountPurgeable.
+func (mr *MockTrashUseCaseMockRecorder) CountPurgeable(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPurgeable", reflect.TypeOf((*MockTrashUseCase)(nil).CountPurgeable), ctx, olderThan)
+}
+
+// List mocks base method.
+func (m *MockTrashUseCase) List(ctx context.Context, itemType string, limit, offset int) ([]model.TrashItem, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, itemType, limit, offset)
+	ret0, _ := ret[0].([]model.TrashItem)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockTrashUseCaseMockRecorder) List(ctx, itemType, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTrashUseCase)(nil).List), ctx, itemType, limit, offset)
+}
+
+// Purge mocks base method.
+func (m *MockTrashUseCase) Purge(ctx context.Context, olderThan time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashUseCaseMockRecorder) Purge(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrashUseCase)(nil).Purge), ctx, olderThan)
+}
+
+// Restore mocks base method.
+func (m *MockTrashUseCase) Restore(ctx context.Context, itemType string, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, itemType, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTrashUseCaseMockRecorder) Restore(ctx, itemType, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTrashUseCase)(nil).Restore), ctx, itemType, id)
+}
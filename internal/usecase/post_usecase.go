@@ -1,10 +1,16 @@
 package usecase
 
-import "golangforum/internal/model"
+import (
+	"context"
+
+	"golangforum/internal/model"
+)
 
 type PostUseCase interface {
-	Create(username string, post *model.Post) error
-	GetByTopic(topicID int) ([]model.Post, error)
-	GetAll() ([]model.Post, error)
-	Delete(id int) error
+	Create(ctx context.Context, username string, post *model.Post) error
+	GetByID(ctx context.Context, id, viewerID int) (*model.Post, error)
+	List(ctx context.Context, viewerID int, f model.PostFilter) ([]model.Post, int, error)
+	Update(ctx context.Context, userID int, req *model.PostUpdateRequest) (*model.Post, error)
+	Delete(ctx context.Context, id, userID int) error
+	Move(ctx context.Context, ids []int, topicID int) (int, error)
 }
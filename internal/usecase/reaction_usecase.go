@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"golangforum/internal/model"
+)
+
+type ReactionUseCase interface {
+	Vote(ctx context.Context, userID int, req model.VoteRequest) (*model.VoteResult, error)
+	ToggleReaction(ctx context.Context, userID int, req model.ReactionRequest) (*model.ReactionCount, error)
+}
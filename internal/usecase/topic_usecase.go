@@ -1,9 +1,19 @@
 package usecase
 
-import "golangforum/internal/model"
+import (
+	"context"
+
+	"golangforum/internal/model"
+)
 
 type TopicUseCase interface {
-	Create(title, description string) error
-	GetAll() ([]model.Topic, error)
-	Delete(id int) error
+	Create(ctx context.Context, topic *model.Topic) error
+	GetByID(ctx context.Context, id int) (*model.Topic, error)
+	GetAll(ctx context.Context, includeArchived bool) ([]model.Topic, error)
+	GetTree(ctx context.Context, includeArchived bool) ([]model.Topic, error)
+	Update(ctx context.Context, req *model.TopicUpdateRequest) (*model.Topic, error)
+	Move(ctx context.Context, id int, parentID *int) error
+	SetState(ctx context.Context, id int, archived, locked *bool) (*model.Topic, error)
+	RecomputeStats(ctx context.Context) (model.StatsRecount, error)
+	Delete(ctx context.Context, id, userID int) error
 }
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"golangforum/internal/model"
+)
+
+type TrashUseCase interface {
+	List(ctx context.Context, itemType string, limit, offset int) ([]model.TrashItem, int, error)
+	Restore(ctx context.Context, itemType string, id int) error
+	Purge(ctx context.Context, olderThan time.Duration) (int, error)
+	CountPurgeable(ctx context.Context, olderThan time.Duration) (int, error)
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS mentions (
+  id SERIAL PRIMARY KEY,
+  post_id INTEGER REFERENCES posts(id) ON DELETE CASCADE,
+  comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+  message_id INTEGER REFERENCES messages(id) ON DELETE CASCADE,
+  username TEXT NOT NULL,
+  author TEXT NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  CHECK (num_nonnulls(post_id, comment_id, message_id) = 1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mentions_username ON mentions (LOWER(username), timestamp DESC);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS attachments (
+  id SERIAL PRIMARY KEY,
+  post_id INTEGER REFERENCES posts(id) ON DELETE SET NULL,
+  comment_id INTEGER REFERENCES comments(id) ON DELETE SET NULL,
+  user_id INTEGER NOT NULL,
+  username TEXT NOT NULL,
+  filename TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size BIGINT NOT NULL,
+  storage_key TEXT NOT NULL UNIQUE,
+  thumbnail_key TEXT,
+  timestamp TIMESTAMP NOT NULL,
+  CHECK (post_id IS NULL OR comment_id IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_post_id ON attachments (post_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_comment_id ON attachments (comment_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_orphans ON attachments (timestamp) WHERE post_id IS NULL AND comment_id IS NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_comments_post_score;
+DROP INDEX IF EXISTS idx_posts_topic_score;
+DROP TABLE IF EXISTS reactions;
+DROP TABLE IF EXISTS votes;
+ALTER TABLE comments DROP COLUMN IF EXISTS score;
+ALTER TABLE comments DROP COLUMN IF EXISTS downvotes;
+ALTER TABLE comments DROP COLUMN IF EXISTS upvotes;
+ALTER TABLE posts DROP COLUMN IF EXISTS score;
+ALTER TABLE posts DROP COLUMN IF EXISTS downvotes;
+ALTER TABLE posts DROP COLUMN IF EXISTS upvotes;
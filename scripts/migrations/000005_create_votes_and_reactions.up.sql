@@ -0,0 +1,35 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS upvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS downvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS upvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS downvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS score INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS votes (
+  id SERIAL PRIMARY KEY,
+  post_id INTEGER REFERENCES posts(id) ON DELETE CASCADE,
+  comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+  user_id INTEGER NOT NULL,
+  value SMALLINT NOT NULL CHECK (value IN (-1, 1)),
+  timestamp TIMESTAMP NOT NULL,
+  CHECK (num_nonnulls(post_id, comment_id) = 1)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_votes_post_user ON votes (post_id, user_id) WHERE post_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_votes_comment_user ON votes (comment_id, user_id) WHERE comment_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS reactions (
+  id SERIAL PRIMARY KEY,
+  post_id INTEGER REFERENCES posts(id) ON DELETE CASCADE,
+  comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+  user_id INTEGER NOT NULL,
+  emoji TEXT NOT NULL,
+  timestamp TIMESTAMP NOT NULL,
+  CHECK (num_nonnulls(post_id, comment_id) = 1)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reactions_post_user_emoji ON reactions (post_id, user_id, emoji) WHERE post_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reactions_comment_user_emoji ON reactions (comment_id, user_id, emoji) WHERE comment_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_topic_score ON posts (topic_id, score DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_comments_post_score ON comments (post_id, score DESC, id DESC);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_comments_post_id;
+DROP INDEX IF EXISTS idx_posts_username_lower;
+DROP INDEX IF EXISTS idx_posts_score;
+DROP INDEX IF EXISTS idx_posts_last_activity;
+DROP INDEX IF EXISTS idx_posts_timestamp;
+DROP INDEX IF EXISTS idx_posts_topic_last_activity;
+DROP INDEX IF EXISTS idx_posts_topic_comment_count;
+DROP INDEX IF EXISTS idx_posts_topic_timestamp;
+ALTER TABLE posts DROP COLUMN IF EXISTS last_activity_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS comment_count;
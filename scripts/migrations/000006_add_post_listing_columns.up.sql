@@ -0,0 +1,20 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS comment_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS last_activity_at TIMESTAMP;
+
+UPDATE posts p SET
+  comment_count = c.cnt,
+  last_activity_at = GREATEST(p.timestamp, c.last_at)
+FROM (SELECT post_id, COUNT(*) AS cnt, MAX(timestamp) AS last_at FROM comments GROUP BY post_id) c
+WHERE c.post_id = p.id;
+
+UPDATE posts SET last_activity_at = timestamp WHERE last_activity_at IS NULL;
+ALTER TABLE posts ALTER COLUMN last_activity_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_topic_timestamp ON posts (topic_id, timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_topic_comment_count ON posts (topic_id, comment_count DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_topic_last_activity ON posts (topic_id, last_activity_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_timestamp ON posts (timestamp DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_last_activity ON posts (last_activity_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_score ON posts (score DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_username_lower ON posts (LOWER(username));
+CREATE INDEX IF NOT EXISTS idx_comments_post_id ON comments (post_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_topics_parent_position;
+ALTER TABLE topics DROP CONSTRAINT IF EXISTS topics_parent_not_self;
+ALTER TABLE topics DROP COLUMN IF EXISTS position;
+ALTER TABLE topics DROP COLUMN IF EXISTS parent_id;
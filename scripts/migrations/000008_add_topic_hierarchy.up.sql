@@ -0,0 +1,11 @@
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES topics(id) ON DELETE CASCADE;
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'topics_parent_not_self') THEN
+        ALTER TABLE topics ADD CONSTRAINT topics_parent_not_self CHECK (parent_id <> id);
+    END IF;
+END
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_topics_parent_position ON topics (parent_id, position, id);
@@ -0,0 +1,4 @@
+ALTER TABLE topics DROP COLUMN IF EXISTS last_activity_at;
+ALTER TABLE topics DROP COLUMN IF EXISTS last_post_id;
+ALTER TABLE topics DROP COLUMN IF EXISTS comment_count;
+ALTER TABLE topics DROP COLUMN IF EXISTS post_count;
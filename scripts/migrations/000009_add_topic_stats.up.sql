@@ -0,0 +1,21 @@
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS post_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS comment_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS last_post_id INTEGER REFERENCES posts(id) ON DELETE SET NULL;
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS last_activity_at TIMESTAMP;
+
+UPDATE topics t SET
+  post_count = s.post_count,
+  comment_count = s.comment_count,
+  last_post_id = s.last_post_id,
+  last_activity_at = s.last_activity_at
+FROM (
+  SELECT
+    p.topic_id,
+    COUNT(*) AS post_count,
+    SUM(p.comment_count) AS comment_count,
+    (ARRAY_AGG(p.id ORDER BY p.timestamp DESC, p.id DESC))[1] AS last_post_id,
+    MAX(p.last_activity_at) AS last_activity_at
+  FROM posts p
+  GROUP BY p.topic_id
+) s
+WHERE s.topic_id = t.id;
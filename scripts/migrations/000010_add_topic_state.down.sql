@@ -0,0 +1,2 @@
+ALTER TABLE topics DROP COLUMN IF EXISTS locked;
+ALTER TABLE topics DROP COLUMN IF EXISTS archived;
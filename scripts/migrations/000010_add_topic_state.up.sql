@@ -0,0 +1,2 @@
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS locked BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_comments_deleted_at;
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+DROP INDEX IF EXISTS idx_topics_deleted_at;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE topics DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE topics DROP COLUMN IF EXISTS deleted_at;
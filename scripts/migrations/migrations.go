@@ -0,0 +1,10 @@
+// Package migrations встраивает SQL-миграции схемы в бинарный файл сервера. Новая миграция создается
+// командой make create_migration и подхватывается при следующей сборке
+package migrations
+
+import "embed"
+
+// FS содержит файлы миграций вида 000001_name.up.sql и 000001_name.down.sql; тестовые данные из test не входят
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/impl"
+	usecase "golangforum/internal/usecase/impl"
+	"golangforum/test/utils"
+)
+
+func TestAttachmentUseCase_WithPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+
+	store, err := impl.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachments := impl.NewAttachmentRepository(db)
+	uc := usecase.NewAttachmentUseCase(attachments, store, 1<<20)
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), attachments, impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+
+	linked, err := uc.Upload(ctx, 2, "bob", "app.log", strings.NewReader("line 1\nline 2\n"))
+	assert.NoError(t, err)
+	orphan, err := uc.Upload(ctx, 2, "bob", "other.log", strings.NewReader("never attached\n"))
+	assert.NoError(t, err)
+
+	p := &model.Post{TopicID: 1, UserID: 2, Title: "Logs", Content: "see attached", AttachmentIDs: []int{linked.ID}}
+	assert.NoError(t, posts.Create(ctx, "bob", p))
+	if assert.Len(t, p.Attachments, 1) {
+		assert.Equal(t, "app.log", p.Attachments[0].Filename)
+	}
+
+	a, content, err := uc.Open(ctx, linked.ID, false)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(content)
+	content.Close()
+	assert.Equal(t, "line 1\nline 2\n", string(data))
+	assert.Equal(t, p.ID, *a.PostID)
+
+	removed, err := uc.CollectOrphans(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, _, err = uc.Open(ctx, orphan.ID, false)
+	assert.Error(t, err)
+	_, _, err = uc.Open(ctx, linked.ID, false)
+	assert.NoError(t, err)
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golangforum/internal/model"
 	"golangforum/internal/repository/impl"
-	"golangforum/internal/usecase"
+	usecase "golangforum/internal/usecase/impl"
 	"golangforum/test/utils"
 )
 
@@ -24,15 +24,17 @@ func TestChatRepository_CRUD(t *testing.T) {
 
 	repo := impl.NewChatRepository(db)
 	now := time.Now()
-	assert.NoError(t, repo.SaveMessage(&model.Message{UserID: 1, Username: "u1", Content: "hello", Timestamp: now}))
-	msgs, err := repo.GetAllMessages()
+	assert.NoError(t, repo.SaveMessage(ctx, &model.Message{UserID: 1, Username: "u1", Content: "hello", Timestamp: now}))
+	msgs, err := repo.GetAllMessages(ctx)
 	assert.NoError(t, err)
 	assert.Len(t, msgs, 1)
 	assert.Equal(t, "hello", msgs[0].Content)
 
-	assert.NoError(t, repo.SaveMessage(&model.Message{UserID: 2, Username: "u2", Content: "old", Timestamp: now.Add(-48 * time.Hour)}))
-	assert.NoError(t, repo.DeleteMessagesOlderThan(now.Add(-24*time.Hour)))
-	msgs, err = repo.GetAllMessages()
+	assert.NoError(t, repo.SaveMessage(ctx, &model.Message{UserID: 2, Username: "u2", Content: "old", Timestamp: now.Add(-48 * time.Hour)}))
+	n, err := repo.DeleteMessagesOlderThan(ctx, now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	msgs, err = repo.GetAllMessages(ctx)
 	assert.NoError(t, err)
 	assert.Len(t, msgs, 1)
 	assert.Equal(t, "hello", msgs[0].Content)
@@ -48,16 +50,16 @@ func TestChatUseCase_WithPostgres(t *testing.T) {
 	_, _ = db.Exec("TRUNCATE messages RESTART IDENTITY CASCADE")
 
 	repo := impl.NewChatRepository(db)
-	uc := usecase.NewChatUseCase(repo)
-	msgs, err := uc.GetAllMessages()
+	uc := usecase.NewChatUseCase(repo, impl.NewMentionRepository(db), 24*time.Hour, nil)
+	msgs, err := uc.GetAllMessages(ctx)
 	assert.NoError(t, err)
 	assert.Empty(t, msgs)
 
 	now := time.Now()
-	repo.SaveMessage(&model.Message{UserID: 1, Username: "u1", Content: "first", Timestamp: now.Add(-time.Minute)})
-	repo.SaveMessage(&model.Message{UserID: 2, Username: "u2", Content: "second", Timestamp: now})
+	repo.SaveMessage(ctx, &model.Message{UserID: 1, Username: "u1", Content: "first", Timestamp: now.Add(-time.Minute)})
+	repo.SaveMessage(ctx, &model.Message{UserID: 2, Username: "u2", Content: "second", Timestamp: now})
 
-	msgs, err = uc.GetAllMessages()
+	msgs, err = uc.GetAllMessages(ctx)
 	assert.NoError(t, err)
 	assert.Len(t, msgs, 2)
 	assert.True(t, msgs[0].Timestamp.Before(msgs[1].Timestamp))
@@ -7,39 +7,41 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"golangforum/internal/markdown"
 	"golangforum/internal/model"
 	"golangforum/internal/repository/impl"
-	"golangforum/internal/usecase"
+	usecase "golangforum/internal/usecase/impl"
 	"golangforum/test/utils"
 )
 
 func TestCommentUseCase_CRUD(t *testing.T) {
-	db, terminate, err := utils.SetupPostgres(context.Background(), "db")
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
 	if err != nil {
 		t.Fatalf("postgres setup: %v", err)
 	}
 	defer terminate()
 
 	repo := impl.NewCommentRepository(db)
-	uc := usecase.NewCommentUseCase(repo)
+	uc := usecase.NewCommentUseCase(repo, impl.NewMentionRepository(db), impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
 
 	now := time.Now().Truncate(time.Second)
 	c := &model.Comment{PostID: 1, UserID: 2, Content: "nice"}
-	err = uc.Create("bob", c)
+	err = uc.Create(ctx, "bob", c)
 	assert.NoError(t, err)
 	assert.Equal(t, "bob", c.Username)
 	assert.WithinDuration(t, now, c.Timestamp, time.Minute)
 
-	comments, err := uc.GetByPost(1)
+	comments, err := uc.GetByPost(ctx, 1, 0, "")
 	assert.NoError(t, err)
 	assert.Len(t, comments, 1)
 	assert.Equal(t, c.Content, comments[0].Content)
 	assert.Equal(t, c.UserID, comments[0].UserID)
 
-	err = uc.Delete(comments[0].ID)
+	err = uc.Delete(ctx, comments[0].ID, 2)
 	assert.NoError(t, err)
 
-	comments, err = uc.GetByPost(1)
+	comments, err = uc.GetByPost(ctx, 1, 0, "")
 	assert.NoError(t, err)
 	assert.Empty(t, comments)
 }
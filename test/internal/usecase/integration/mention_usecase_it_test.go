@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/impl"
+	usecase "golangforum/internal/usecase/impl"
+	"golangforum/test/utils"
+)
+
+func TestMentionUseCase_WithPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+
+	mentions := impl.NewMentionRepository(db)
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), mentions, impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+	comments := usecase.NewCommentUseCase(impl.NewCommentRepository(db), mentions, impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+	uc := usecase.NewMentionUseCase(mentions)
+
+	p := &model.Post{TopicID: 1, Title: "Hi", Content: "@Alice, посмотри"}
+	assert.NoError(t, posts.Create(ctx, "bob", p))
+	c := &model.Comment{PostID: p.ID, Content: "@alice @alice и @bob"}
+	assert.NoError(t, comments.Create(ctx, "bob", c))
+
+	res, total, err := uc.GetByUsername(ctx, "ALICE", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, model.MentionSourceComment, res[0].SourceType)
+		assert.Equal(t, c.ID, res[0].SourceID)
+		assert.Equal(t, model.MentionSourcePost, res[1].SourceType)
+		assert.Equal(t, p.ID, res[1].SourceID)
+	}
+
+	res, total, err = uc.GetByUsername(ctx, "bob", 10, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, res)
+
+	// правка текста переносит упоминание поста с @alice на @carol
+	edited := "@carol, посмотри"
+	_, err = posts.Update(ctx, p.UserID, &model.PostUpdateRequest{ID: p.ID, Content: &edited})
+	assert.NoError(t, err)
+	res, total, err = uc.GetByUsername(ctx, "alice", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, model.MentionSourceComment, res[0].SourceType)
+	}
+	_, total, err = uc.GetByUsername(ctx, "carol", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
+
+func TestMentionUseCase_DeletedSources_WithPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+
+	mentions := impl.NewMentionRepository(db)
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), mentions, impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+	comments := usecase.NewCommentUseCase(impl.NewCommentRepository(db), mentions, impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+	trash := usecase.NewTrashUseCase(impl.NewTrashRepository(db))
+	uc := usecase.NewMentionUseCase(mentions)
+
+	p := &model.Post{TopicID: 1, UserID: 1, Title: "Hi", Content: "@alice"}
+	assert.NoError(t, posts.Create(ctx, "bob", p))
+	c := &model.Comment{PostID: p.ID, UserID: 1, Content: "@alice"}
+	assert.NoError(t, comments.Create(ctx, "bob", c))
+
+	assert.NoError(t, comments.Delete(ctx, c.ID, 1))
+	res, total, err := uc.GetByUsername(ctx, "alice", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, model.MentionSourcePost, res[0].SourceType)
+	}
+
+	assert.NoError(t, posts.Delete(ctx, p.ID, 1))
+	res, total, err = uc.GetByUsername(ctx, "alice", 10, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, res)
+
+	// восстановленный пост снова виден в упоминаниях, а комментарий, удаленный раньше него, остается скрытым
+	assert.NoError(t, trash.Restore(ctx, model.TrashPost, p.ID))
+	_, total, err = uc.GetByUsername(ctx, "alice", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+}
@@ -8,9 +8,11 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 
+	"golangforum/internal/markdown"
 	"golangforum/internal/model"
 	"golangforum/internal/repository/impl"
-	"golangforum/internal/usecase"
+	ucerr "golangforum/internal/usecase"
+	usecase "golangforum/internal/usecase/impl"
 	"golangforum/test/utils"
 )
 
@@ -25,30 +27,118 @@ func TestPostUseCase_CRUD(t *testing.T) {
 	db.Exec(`TRUNCATE posts RESTART IDENTITY CASCADE`)
 
 	r := impl.NewPostRepository(db)
-	uc := usecase.NewPostUseCase(r)
+	uc := usecase.NewPostUseCase(r, impl.NewMentionRepository(db), impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
 
 	now := time.Now().Truncate(time.Second)
 	p := &model.Post{TopicID: 1, Title: "Hello", Content: "World", UserID: 2}
-	assert.NoError(t, uc.Create("alice", p))
+	assert.NoError(t, uc.Create(ctx, "alice", p))
 	assert.Equal(t, "alice", p.Username)
 	assert.WithinDuration(t, now, p.Timestamp, time.Minute)
 
-	byTopic, err := uc.GetByTopic(1)
+	got, err := uc.GetByID(ctx, p.ID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", got.Title)
+	assert.Equal(t, "alice", got.Username)
+
+	byTopic, _, err := uc.List(ctx, 0, model.PostFilter{TopicID: 1})
 	assert.NoError(t, err)
 	assert.Len(t, byTopic, 1)
 	assert.Equal(t, "World", byTopic[0].Content)
 
-	all, err := uc.GetAll()
+	all, _, err := uc.List(ctx, 0, model.PostFilter{})
 	assert.NoError(t, err)
 	assert.Len(t, all, 1)
 
-	assert.NoError(t, uc.Delete(byTopic[0].ID))
+	deletedID := byTopic[0].ID
+	assert.NoError(t, uc.Delete(ctx, deletedID, 1))
 
-	byTopic, err = uc.GetByTopic(1)
+	byTopic, _, err = uc.List(ctx, 0, model.PostFilter{TopicID: 1})
 	assert.NoError(t, err)
 	assert.Empty(t, byTopic)
 
-	all, err = uc.GetAll()
+	all, _, err = uc.List(ctx, 0, model.PostFilter{})
 	assert.NoError(t, err)
 	assert.Empty(t, all)
+
+	assert.ErrorIs(t, uc.Delete(ctx, deletedID, 1), ucerr.ErrPostNotFound)
+	_, err = uc.GetByID(ctx, deletedID, 0)
+	assert.ErrorIs(t, err, ucerr.ErrPostNotFound)
+	assert.ErrorIs(t, uc.Delete(ctx, 999999, 1), ucerr.ErrPostNotFound)
+	assert.ErrorIs(t, uc.Create(ctx, "alice", &model.Post{TopicID: 999999, Title: "Lost", Content: "nowhere"}), ucerr.ErrTopicNotFound)
+}
+
+func TestPostUseCase_ListFilters(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	db.Exec(`TRUNCATE posts RESTART IDENTITY CASCADE`)
+
+	uc := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+	comments := usecase.NewCommentUseCase(impl.NewCommentRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+
+	first := &model.Post{TopicID: 1, Title: "First", Content: "first", UserID: 1}
+	assert.NoError(t, uc.Create(ctx, "Alice", first))
+	second := &model.Post{TopicID: 1, Title: "Second", Content: "second", UserID: 2}
+	assert.NoError(t, uc.Create(ctx, "bob", second))
+	other := &model.Post{TopicID: 2, Title: "Other", Content: "other", UserID: 1}
+	assert.NoError(t, uc.Create(ctx, "alice", other))
+	assert.NoError(t, comments.Create(ctx, "bob", &model.Comment{PostID: first.ID, UserID: 2, Content: "reply"}))
+
+	newest, total, err := uc.List(ctx, 0, model.PostFilter{TopicID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	if assert.Len(t, newest, 2) {
+		assert.Equal(t, second.ID, newest[0].ID)
+	}
+
+	active, _, err := uc.List(ctx, 0, model.PostFilter{TopicID: 1, Sort: model.SortActivity})
+	assert.NoError(t, err)
+	if assert.Len(t, active, 2) {
+		assert.Equal(t, first.ID, active[0].ID)
+		assert.Equal(t, 1, active[0].CommentCount)
+	}
+
+	byAuthor, total, err := uc.List(ctx, 0, model.PostFilter{Author: "ALICE", Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, byAuthor, 1)
+
+	hasComments := false
+	quiet, _, err := uc.List(ctx, 0, model.PostFilter{HasComments: &hasComments, Sort: model.SortOldest})
+	assert.NoError(t, err)
+	if assert.Len(t, quiet, 2) {
+		assert.Equal(t, second.ID, quiet[0].ID)
+		assert.Equal(t, other.ID, quiet[1].ID)
+	}
+}
+
+func TestPostUseCase_CanceledContext(t *testing.T) {
+	ctx := context.Background()
+	db, cleanup, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	db.Exec(`TRUNCATE posts RESTART IDENTITY CASCADE`)
+
+	uc := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = uc.Create(canceled, "alice", &model.Post{TopicID: 1, Title: "Lost", Content: "never saved", UserID: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	expired, cancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+	_, _, err = uc.List(expired, 0, model.PostFilter{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	posts, total, err := uc.List(ctx, 0, model.PostFilter{})
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, posts)
 }
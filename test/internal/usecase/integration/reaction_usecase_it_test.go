@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/impl"
+	usecase "golangforum/internal/usecase/impl"
+	"golangforum/test/utils"
+)
+
+func TestReactionUseCase_WithPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+	db.Exec(`TRUNCATE posts RESTART IDENTITY CASCADE`)
+
+	reactions := impl.NewReactionRepository(db)
+	uc := usecase.NewReactionUseCase(reactions)
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db), reactions, impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+
+	first := &model.Post{TopicID: 1, UserID: 1, Title: "First", Content: "first"}
+	assert.NoError(t, posts.Create(ctx, "alice", first))
+	second := &model.Post{TopicID: 1, UserID: 1, Title: "Second", Content: "second"}
+	assert.NoError(t, posts.Create(ctx, "alice", second))
+
+	res, err := uc.Vote(ctx, 2, model.VoteRequest{TargetType: model.TargetPost, TargetID: second.ID, Value: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, model.VoteResult{Score: 1, Upvotes: 1, MyVote: 1}, *res)
+	res, err = uc.Vote(ctx, 3, model.VoteRequest{TargetType: model.TargetPost, TargetID: second.ID, Value: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Score)
+	res, err = uc.Vote(ctx, 3, model.VoteRequest{TargetType: model.TargetPost, TargetID: second.ID, Value: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, model.VoteResult{Score: 0, Upvotes: 1, Downvotes: 1, MyVote: -1}, *res)
+	res, err = uc.Vote(ctx, 3, model.VoteRequest{TargetType: model.TargetPost, TargetID: second.ID, Value: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, model.VoteResult{Score: 1, Upvotes: 1}, *res)
+
+	rc, err := uc.ToggleReaction(ctx, 2, model.ReactionRequest{TargetType: model.TargetPost, TargetID: second.ID, Emoji: "🎉"})
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReactionCount{Emoji: "🎉", Count: 1, Reacted: true}, *rc)
+	_, err = uc.ToggleReaction(ctx, 3, model.ReactionRequest{TargetType: model.TargetPost, TargetID: second.ID, Emoji: "🎉"})
+	assert.NoError(t, err)
+	rc, err = uc.ToggleReaction(ctx, 3, model.ReactionRequest{TargetType: model.TargetPost, TargetID: second.ID, Emoji: "🎉"})
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReactionCount{Emoji: "🎉", Count: 1}, *rc)
+
+	top, _, err := posts.List(ctx, 2, model.PostFilter{TopicID: 1, Sort: model.SortTop})
+	assert.NoError(t, err)
+	if assert.Len(t, top, 2) {
+		assert.Equal(t, second.ID, top[0].ID)
+		assert.Equal(t, 1, top[0].Score)
+		assert.Equal(t, 1, top[0].MyVote)
+		assert.Equal(t, []model.ReactionCount{{Emoji: "🎉", Count: 1, Reacted: true}}, top[0].Reactions)
+		assert.Empty(t, top[1].Reactions)
+	}
+
+	_, err = uc.Vote(ctx, 2, model.VoteRequest{TargetType: model.TargetComment, TargetID: 100500, Value: 1})
+	assert.Error(t, err)
+}
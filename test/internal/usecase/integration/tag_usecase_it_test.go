@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/impl"
+	usecase "golangforum/internal/usecase/impl"
+	"golangforum/test/utils"
+)
+
+func TestTagUseCase_WithPostgres(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+	db.Exec(`TRUNCATE posts RESTART IDENTITY CASCADE`)
+
+	tags := impl.NewTagRepository(db)
+	uc := usecase.NewTagUseCase(tags)
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db), impl.NewReactionRepository(db), tags, impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+
+	first := &model.Post{TopicID: 1, UserID: 1, Title: "First", Content: "first", Tags: []string{"Go", "PostgreSQL"}}
+	assert.NoError(t, posts.Create(ctx, "alice", first))
+	second := &model.Post{TopicID: 1, UserID: 1, Title: "Second", Content: "second", Tags: []string{"golang", "postgres"}}
+	assert.NoError(t, posts.Create(ctx, "alice", second))
+
+	both, total, err := posts.List(ctx, 0, model.PostFilter{Tags: []string{"go", "postgresql"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	if assert.Len(t, both, 1) {
+		assert.Equal(t, []string{"go", "postgresql"}, both[0].Tags)
+	}
+	either, _, err := posts.List(ctx, 0, model.PostFilter{Tags: []string{"go", "golang"}, TagMode: model.TagModeAny})
+	assert.NoError(t, err)
+	assert.Len(t, either, 2)
+
+	suggestions, err := uc.List(ctx, "post", 10)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 2)
+
+	byName := make(map[string]model.Tag)
+	all, err := uc.List(ctx, "", 10)
+	assert.NoError(t, err)
+	for _, tg := range all {
+		byName[tg.Name] = tg
+	}
+	_, err = uc.Rename(ctx, byName["golang"].ID, "Go")
+	assert.Error(t, err)
+	merged, err := uc.Merge(ctx, byName["golang"].ID, byName["go"].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, merged.PostCount)
+	renamed, err := uc.Rename(ctx, byName["postgres"].ID, "pg")
+	assert.NoError(t, err)
+	assert.Equal(t, "pg", renamed.Name)
+
+	updated, err := posts.Update(ctx, 1, &model.PostUpdateRequest{ID: second.ID, Tags: &[]string{"go", "sql"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "sql"}, updated.Tags)
+	assert.Equal(t, second.Title, updated.Title)
+
+	// правка одного текста оставляет теги поста
+	content := "only the text changes"
+	updated, err = posts.Update(ctx, 1, &model.PostUpdateRequest{ID: second.ID, Content: &content})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "sql"}, updated.Tags)
+	assert.Equal(t, content, updated.Content)
+}
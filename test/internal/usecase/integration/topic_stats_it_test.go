@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/impl"
+	usecase "golangforum/internal/usecase/impl"
+	"golangforum/test/utils"
+)
+
+func TestTopicUseCase_Stats(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+
+	topics := usecase.NewTopicUseCase(impl.NewTopicRepository(db))
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db),
+		impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+	comments := usecase.NewCommentUseCase(impl.NewCommentRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db),
+		impl.NewReactionRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+
+	topic := &model.Topic{Title: "Stats", Description: "Counters"}
+	assert.NoError(t, topics.Create(ctx, topic))
+
+	first := &model.Post{TopicID: topic.ID, UserID: 1, Title: "First", Content: "first"}
+	assert.NoError(t, posts.Create(ctx, "alice", first))
+	second := &model.Post{TopicID: topic.ID, UserID: 2, Title: "Second", Content: "second"}
+	assert.NoError(t, posts.Create(ctx, "bob", second))
+	assert.NoError(t, comments.Create(ctx, "carol", &model.Comment{PostID: first.ID, UserID: 3, Content: "reply"}))
+	assert.NoError(t, comments.Create(ctx, "dave", &model.Comment{PostID: second.ID, UserID: 4, Content: "reply"}))
+
+	stats := findTopic(t, topics, topic.ID)
+	assert.Equal(t, 2, stats.PostCount)
+	assert.Equal(t, 2, stats.CommentCount)
+	if assert.NotNil(t, stats.LastPost) {
+		assert.Equal(t, second.ID, stats.LastPost.ID)
+		assert.Equal(t, "Second", stats.LastPost.Title)
+		assert.Equal(t, "bob", stats.LastPost.Username)
+	}
+	assert.NotNil(t, stats.LastActivityAt)
+
+	assert.NoError(t, posts.Delete(ctx, second.ID, 2))
+
+	stats = findTopic(t, topics, topic.ID)
+	assert.Equal(t, 1, stats.PostCount)
+	assert.Equal(t, 1, stats.CommentCount)
+	if assert.NotNil(t, stats.LastPost) {
+		assert.Equal(t, first.ID, stats.LastPost.ID)
+	}
+
+	before, err := posts.GetByID(ctx, first.ID, 0)
+	assert.NoError(t, err)
+	_, err = db.Exec("UPDATE topics SET post_count = 42, comment_count = 0, last_post_id = NULL WHERE id = $1", topic.ID)
+	assert.NoError(t, err)
+	_, err = db.Exec("UPDATE posts SET comment_count = 7, last_activity_at = last_activity_at + INTERVAL '1 day' WHERE id = $1", first.ID)
+	assert.NoError(t, err)
+
+	n, err := topics.RecomputeStats(ctx)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, n.Topics, 1)
+	assert.GreaterOrEqual(t, n.Posts, 1)
+
+	after, err := posts.GetByID(ctx, first.ID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, after.CommentCount)
+	assert.WithinDuration(t, before.LastActivityAt, after.LastActivityAt, time.Millisecond)
+
+	stats = findTopic(t, topics, topic.ID)
+	assert.Equal(t, 1, stats.PostCount)
+	assert.Equal(t, 1, stats.CommentCount)
+	if assert.NotNil(t, stats.LastPost) {
+		assert.Equal(t, first.ID, stats.LastPost.ID)
+	}
+}
+
+func findTopic(t *testing.T, uc *usecase.TopicUseCase, id int) model.Topic {
+	t.Helper()
+	all, err := uc.GetAll(context.Background(), false)
+	assert.NoError(t, err)
+	for _, topic := range all {
+		if topic.ID == id {
+			return topic
+		}
+	}
+	t.Fatalf("topic %d not found", id)
+	return model.Topic{}
+}
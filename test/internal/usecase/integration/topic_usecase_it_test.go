@@ -6,8 +6,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
 	"golangforum/internal/repository/impl"
-	"golangforum/internal/usecase"
+	ucerr "golangforum/internal/usecase"
+	usecase "golangforum/internal/usecase/impl"
 	"golangforum/test/utils"
 )
 
@@ -24,9 +27,9 @@ func TestTopicUseCase_CRUD(t *testing.T) {
 	repo := impl.NewTopicRepository(db)
 	uc := usecase.NewTopicUseCase(repo)
 
-	assert.NoError(t, uc.Create("Title1", "Desc1"))
+	assert.NoError(t, uc.Create(ctx, &model.Topic{Title: "Title1", Description: "Desc1"}))
 
-	topics, err := uc.GetAll()
+	topics, err := uc.GetAll(ctx, false)
 	assert.NoError(t, err)
 	assert.Len(t, topics, 1)
 	topic := topics[0]
@@ -34,9 +37,106 @@ func TestTopicUseCase_CRUD(t *testing.T) {
 	assert.Equal(t, "Desc1", topic.Description)
 	assert.False(t, topic.CreatedAt.IsZero())
 
-	assert.NoError(t, uc.Delete(topic.ID))
+	assert.NoError(t, uc.Delete(ctx, topic.ID, 1))
 
-	topics, err = uc.GetAll()
+	topics, err = uc.GetAll(ctx, false)
 	assert.NoError(t, err)
 	assert.Empty(t, topics)
 }
+
+func TestTopicUseCase_Hierarchy(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+
+	_, _ = db.Exec("TRUNCATE topics RESTART IDENTITY CASCADE")
+
+	uc := usecase.NewTopicUseCase(impl.NewTopicRepository(db))
+
+	backend := &model.Topic{Title: "Backend", Description: "Server side", Position: 1}
+	frontend := &model.Topic{Title: "Frontend", Description: "Client side", Position: 0}
+	assert.NoError(t, uc.Create(ctx, backend))
+	assert.NoError(t, uc.Create(ctx, frontend))
+	golang := &model.Topic{ParentID: &backend.ID, Title: "Go", Description: "Go services"}
+	assert.NoError(t, uc.Create(ctx, golang))
+	modules := &model.Topic{ParentID: &golang.ID, Title: "Modules", Description: "Go modules"}
+	assert.NoError(t, uc.Create(ctx, modules))
+
+	tree, err := uc.GetTree(ctx, false)
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "Frontend", tree[0].Title)
+	assert.Equal(t, "Backend", tree[1].Title)
+	assert.Equal(t, "Modules", tree[1].Children[0].Children[0].Title)
+
+	paths, err := impl.NewTopicRepository(db).GetPaths(ctx, []int{modules.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.TopicRef{
+		{ID: backend.ID, Title: "Backend"},
+		{ID: golang.ID, Title: "Go"},
+		{ID: modules.ID, Title: "Modules"},
+	}, paths[modules.ID])
+
+	assert.ErrorIs(t, uc.Move(ctx, backend.ID, &modules.ID), ucerr.ErrTopicCycle)
+	assert.ErrorIs(t, uc.Move(ctx, backend.ID, &backend.ID), ucerr.ErrTopicCycle)
+
+	assert.NoError(t, uc.Move(ctx, golang.ID, &frontend.ID))
+	assert.NoError(t, uc.Move(ctx, modules.ID, nil))
+
+	tree, err = uc.GetTree(ctx, false)
+	assert.NoError(t, err)
+	assert.Len(t, tree, 3)
+	assert.Equal(t, "Go", tree[0].Children[0].Title)
+	assert.Empty(t, tree[1].Children)
+
+	title := "Golang"
+	renamed, err := uc.Update(ctx, &model.TopicUpdateRequest{ID: golang.ID, Title: &title})
+	assert.NoError(t, err)
+	assert.Equal(t, "Golang", renamed.Title)
+	assert.Equal(t, golang.Description, renamed.Description)
+	assert.Equal(t, frontend.ID, *renamed.ParentID)
+	_, err = uc.Update(ctx, &model.TopicUpdateRequest{ID: 999, Title: &title})
+	assert.ErrorIs(t, err, ucerr.ErrTopicNotFound)
+}
+
+func TestTopicUseCase_ArchiveAndLock(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+
+	topics := usecase.NewTopicUseCase(impl.NewTopicRepository(db))
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db),
+		impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+
+	topic := &model.Topic{Title: "Releases", Description: "Release notes"}
+	assert.NoError(t, topics.Create(ctx, topic))
+
+	yes, no := true, false
+	locked, err := topics.SetState(ctx, topic.ID, nil, &yes)
+	assert.NoError(t, err)
+	assert.True(t, locked.Locked)
+	assert.False(t, locked.Archived)
+	assert.ErrorIs(t, posts.Create(ctx, "alice", &model.Post{TopicID: topic.ID, UserID: 1, Title: "v1", Content: "notes"}), ucerr.ErrTopicLocked)
+
+	_, err = topics.SetState(ctx, topic.ID, &yes, &no)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, posts.Create(ctx, "alice", &model.Post{TopicID: topic.ID, UserID: 1, Title: "v1", Content: "notes"}), ucerr.ErrTopicArchived)
+
+	visible, err := topics.GetAll(ctx, false)
+	assert.NoError(t, err)
+	for _, v := range visible {
+		assert.NotEqual(t, topic.ID, v.ID)
+	}
+	all, err := topics.GetAll(ctx, true)
+	assert.NoError(t, err)
+	assert.Len(t, all, len(visible)+1)
+
+	_, err = topics.SetState(ctx, 999, &yes, nil)
+	assert.ErrorIs(t, err, ucerr.ErrTopicNotFound)
+}
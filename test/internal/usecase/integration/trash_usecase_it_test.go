@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"golangforum/internal/markdown"
+	"golangforum/internal/model"
+	"golangforum/internal/repository/impl"
+	ucerr "golangforum/internal/usecase"
+	usecase "golangforum/internal/usecase/impl"
+	"golangforum/test/utils"
+)
+
+func TestTrashUseCase_DeleteAndRestoreTopic(t *testing.T) {
+	ctx := context.Background()
+	db, terminate, err := utils.SetupPostgres(ctx, "db")
+	if err != nil {
+		t.Fatalf("postgres setup: %v", err)
+	}
+	defer terminate()
+
+	topics := usecase.NewTopicUseCase(impl.NewTopicRepository(db))
+	posts := usecase.NewPostUseCase(impl.NewPostRepository(db), impl.NewMentionRepository(db), impl.NewAttachmentRepository(db),
+		impl.NewReactionRepository(db), impl.NewTagRepository(db), impl.NewTopicRepository(db), markdown.NewRenderer(0), nil)
+	trash := usecase.NewTrashUseCase(impl.NewTrashRepository(db))
+
+	topic := &model.Topic{Title: "Trash", Description: "Soft delete"}
+	assert.NoError(t, topics.Create(ctx, topic))
+	child := &model.Topic{ParentID: &topic.ID, Title: "Child", Description: "Nested"}
+	assert.NoError(t, topics.Create(ctx, child))
+	post := &model.Post{TopicID: child.ID, UserID: 1, Title: "Inside", Content: "content"}
+	assert.NoError(t, posts.Create(ctx, "alice", post))
+
+	assert.NoError(t, topics.Delete(ctx, topic.ID, 1))
+
+	list, _, err := posts.List(ctx, 0, model.PostFilter{TopicID: child.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	items, total, err := trash.List(ctx, model.TrashTopic, 20, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, items, 2)
+
+	assert.ErrorIs(t, trash.Restore(ctx, model.TrashTopic, child.ID), ucerr.ErrParentDeleted)
+	assert.NoError(t, trash.Restore(ctx, model.TrashTopic, topic.ID))
+
+	list, _, err = posts.List(ctx, 0, model.PostFilter{TopicID: child.ID})
+	assert.NoError(t, err)
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, post.ID, list[0].ID)
+	}
+	assert.Equal(t, 1, findTopic(t, topics, child.ID).PostCount)
+
+	assert.ErrorIs(t, trash.Restore(ctx, model.TrashTopic, topic.ID), ucerr.ErrTrashNotFound)
+}